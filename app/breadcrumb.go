@@ -0,0 +1,84 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/ui/tab"
+)
+
+// breadcrumbSeparator separates the parts of the header breadcrumb
+const breadcrumbSeparator = " › "
+
+// syncActiveTab points the current connection, database and table at the active tab,
+// so actions and the header breadcrumb follow tab switches
+func (m *Model) syncActiveTab() {
+	if !m.Tabs.HasTabs() {
+		return
+	}
+
+	tabType := m.Tabs.GetActiveTabType()
+	if tabType != tab.TabTypeTable && tabType != tab.TabTypeStructure {
+		return
+	}
+
+	connectionName, tableName, ok := m.splitTabName(m.Tabs.GetActiveTabName())
+	if !ok {
+		return
+	}
+
+	m.currentConnection = connectionName
+	m.currentTable = tableName
+	for _, conn := range m.Sidebar.GetConnections() {
+		if conn.Name == connectionName {
+			m.currentDatabase = extractDatabaseName(conn.Host, conn.Type)
+			break
+		}
+	}
+}
+
+// breadcrumb returns the connection › database › schema › table path of the active tab
+func (m Model) breadcrumb() string {
+	if !m.Tabs.HasTabs() {
+		return ""
+	}
+
+	var parts []string
+	if m.Tabs.GetActiveTabType() == tab.TabTypeQuery {
+		if qe := m.Tabs.GetActiveQueryEditor(); qe != nil {
+			parts = []string{qe.GetConnectionName(), filepath.Base(qe.GetDatabaseName())}
+		}
+	} else {
+		parts = []string{m.currentConnection, filepath.Base(m.currentDatabase)}
+		// Only drivers with schemas have them between the database and its tables
+		if qualifier, ok := m.dbConnections[m.currentConnection].(drivers.SchemaQualifier); ok && qualifier.DefaultSchema() != "" {
+			parts = append(parts, qualifier.DefaultSchema())
+		}
+		parts = append(parts, m.currentTable)
+	}
+
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" && part != "." {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, breadcrumbSeparator)
+}
+
+// fitHeaderText truncates header text so it never wraps onto a second line
+func (m Model) fitHeaderText(text string) string {
+	// The header style pads two columns on each side
+	maxWidth := m.TerminalWidth - 4
+	if maxWidth <= 0 || lipgloss.Width(text) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	modalaction "github.com/sheenazien8/sq/ui/modal-action"
+)
+
+// markedRowsWhereClause matches every marked row by its primary key, one
+// parenthesized condition per row joined by OR
+func (m Model) markedRowsWhereClause(stmt *statementBuilder, structure *drivers.TableStructure, columnNames []string, rows [][]string) (string, error) {
+	conditions := make([]string, 0, len(rows))
+	for _, row := range rows {
+		condition, err := buildPrimaryKeyWhereClause(stmt, structure, columnNames, row)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	if len(conditions) == 0 {
+		return "", fmt.Errorf("no rows are marked")
+	}
+	return strings.Join(conditions, " OR "), nil
+}
+
+// markedRowsTarget returns the driver of the current connection, a statement
+// builder for it and the WHERE clause matching the rows marked when the modal
+// was opened, their keys bound to the builder
+func (m Model) markedRowsTarget(modal *modalaction.Model) (drivers.Driver, *statementBuilder, string, error) {
+	if m.currentConnection == "" || m.currentDatabase == "" {
+		return nil, nil, "", fmt.Errorf("no active connection or database")
+	}
+	driver, exists := m.dbConnections[m.currentConnection]
+	if !exists {
+		return nil, nil, "", fmt.Errorf("no active connection")
+	}
+
+	structure, err := driver.GetTableStructure(m.currentDatabase, modal.GetTableName())
+	if err != nil {
+		return nil, nil, "", err
+	}
+	stmt := &statementBuilder{driver: driver}
+	whereClause, err := m.markedRowsWhereClause(stmt, structure, modal.GetColumnNames(), modal.GetMarkedRows())
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return driver, stmt, whereClause, nil
+}
+
+// handleDeleteMarkedRows deletes every marked row with a single DELETE
+func (m Model) handleDeleteMarkedRows(modal *modalaction.Model) (Model, tea.Cmd) {
+	driver, stmt, whereClause, err := m.markedRowsTarget(modal)
+	if err != nil {
+		logger.Error("Failed to target marked rows", map[string]any{"error": err.Error()})
+		return m.showError("Failed to delete rows: " + err.Error())
+	}
+
+	quotedTable := drivers.QuoteTableName(driver, modal.GetTableName())
+	query := stmt.build(fmt.Sprintf("DELETE FROM %s WHERE %s", quotedTable, whereClause))
+	logger.Info("Executing DELETE query", map[string]any{"query": query.query, "rows": modal.MarkedRowCount()})
+
+	var executed bool
+	m, executed, err = m.executeWrite(driver, query)
+	if err != nil {
+		logger.Error("Failed to delete marked rows", map[string]any{"error": err.Error()})
+		return m.showError("Failed to delete rows: " + err.Error())
+	}
+	if !executed {
+		return m, nil
+	}
+
+	logger.Info("Marked rows deleted successfully", map[string]any{"rows": modal.MarkedRowCount()})
+	m, reloadCmd := m.reloadTableData()
+	m, toastCmd := m.showSuccess(fmt.Sprintf("Deleted %d rows", modal.MarkedRowCount()))
+	return m, tea.Batch(reloadCmd, toastCmd)
+}
+
+// handleSetNullMarkedRows sets the selected column to NULL in every marked row
+func (m Model) handleSetNullMarkedRows(modal *modalaction.Model) (Model, tea.Cmd) {
+	columnNames := modal.GetColumnNames()
+	selectedCol := modal.GetSelectedColumn()
+	if selectedCol < 0 || selectedCol >= len(columnNames) {
+		logger.Error("Invalid column index", map[string]any{"selectedCol": selectedCol})
+		return m, nil
+	}
+
+	driver, stmt, whereClause, err := m.markedRowsTarget(modal)
+	if err != nil {
+		logger.Error("Failed to target marked rows", map[string]any{"error": err.Error()})
+		return m.showError("Failed to update rows: " + err.Error())
+	}
+
+	quotedTable := drivers.QuoteTableName(driver, modal.GetTableName())
+	quotedColumn := driver.QuoteIdentifier(columnNames[selectedCol])
+	query := stmt.build(fmt.Sprintf("UPDATE %s SET %s = NULL WHERE %s", quotedTable, quotedColumn, whereClause))
+	logger.Info("Executing UPDATE query", map[string]any{"query": query.query, "rows": modal.MarkedRowCount()})
+
+	var executed bool
+	m, executed, err = m.executeWrite(driver, query)
+	if err != nil {
+		logger.Error("Failed to update marked rows", map[string]any{"error": err.Error()})
+		return m.showError("Failed to update rows: " + err.Error())
+	}
+	if !executed {
+		return m, nil
+	}
+
+	logger.Info("Marked rows updated successfully", map[string]any{"rows": modal.MarkedRowCount()})
+	m, reloadCmd := m.reloadTableData()
+	m, toastCmd := m.showSuccess(fmt.Sprintf("Set %s to NULL in %d rows", columnNames[selectedCol], modal.MarkedRowCount()))
+	return m, tea.Batch(reloadCmd, toastCmd)
+}
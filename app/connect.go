@@ -0,0 +1,192 @@
+package app
+
+import (
+	"net/url"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/storage"
+	"github.com/sheenazien8/sq/ui/sidebar"
+)
+
+// connectionReadyMsg carries a connection opened in the background, with the
+// tables and views to show for it
+type connectionReadyMsg struct {
+	name   string
+	driver drivers.Driver
+	tables []sidebar.Table
+	views  map[string]bool
+}
+
+// connectionFailedMsg reports a connection that could not be opened
+type connectionFailedMsg struct {
+	name string
+	err  error
+}
+
+// openDatabase creates a driver, connects it with opts (its SSH tunnel and SSL
+// settings) and reads the tables and views of the database. It blocks for as
+// long as the server takes to answer.
+func openDatabase(name, connType, url string, opts drivers.ConnectionOptions) (connectionReadyMsg, error) {
+	driver, err := drivers.NewWithOptions(connType, opts)
+	if err != nil {
+		return connectionReadyMsg{}, err
+	}
+
+	err = driver.Connect(url)
+	if err != nil {
+		return connectionReadyMsg{}, err
+	}
+
+	// Extract database name from URL for MySQL
+	dbName := extractDatabaseName(url, connType)
+
+	// Get tables from database
+	tables, err := driver.GetTables(dbName)
+	if err != nil {
+		_ = driver.Close()
+		return connectionReadyMsg{}, err
+	}
+
+	// Remember which objects are views so their tabs open read-only
+	viewSet := make(map[string]bool)
+	views, err := driver.GetViews(dbName)
+	if err != nil {
+		logger.Warn("Failed to load views", map[string]any{
+			"connection": name,
+			"error":      err.Error(),
+		})
+	}
+	for _, view := range views {
+		viewSet[view] = true
+	}
+
+	// Combine all tables from all schemas for display
+	// In PostgreSQL and SQL Server, tables are organized by schema in the returned map
+	// In MySQL, tables are keyed by database name
+	keys := make([]string, 0, len(tables))
+	for key := range tables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var allTables []sidebar.Table
+	for _, key := range keys {
+		// Only keys of drivers with schemas are schemas; the sidebar can show them as schema.table
+		schema := ""
+		if _, ok := driver.(drivers.SchemaQualifier); ok {
+			schema = key
+		}
+		for _, tableName := range tables[key] {
+			allTables = append(allTables, sidebar.Table{Name: tableName, Schema: schema})
+		}
+	}
+
+	return connectionReadyMsg{name: name, driver: driver, tables: allTables, views: viewSet}, nil
+}
+
+// connectionOptions returns the SSH tunnel and SSL settings of the named
+// connection, unset for connections without them
+func (m Model) connectionOptions(name string) drivers.ConnectionOptions {
+	if conn := m.findConnection(name); conn != nil {
+		return conn.Options
+	}
+	return drivers.ConnectionOptions{}
+}
+
+// editConnectionOptions returns the SSH tunnel and SSL settings to fill the edit
+// form with. The form rebuilds the URL on save, so an sslmode the URL of a
+// PostgreSQL connection carries moves to the SSL mode field.
+func editConnectionOptions(conn *storage.Connection) drivers.ConnectionOptions {
+	opts := conn.Options()
+	if opts.TLS.Configured() || conn.Driver != drivers.DriverTypePostgreSQL {
+		return opts
+	}
+	u, err := url.Parse(conn.URL)
+	if err != nil {
+		return opts
+	}
+	if mode, err := drivers.ParseTLSMode(u.Query().Get("sslmode")); err == nil && mode != drivers.TLSModeDisable {
+		opts.TLS.Mode = mode
+	}
+	return opts
+}
+
+// applyConnection stores an opened connection and shows its tables in the sidebar
+func (m *Model) applyConnection(ready connectionReadyMsg) {
+	if previous, exists := m.dbConnections[ready.name]; exists && previous != ready.driver {
+		if err := previous.Close(); err != nil {
+			logger.Warn("Failed to close connection", map[string]any{
+				"connection": ready.name,
+				"error":      err.Error(),
+			})
+		}
+	}
+	m.dbConnections[ready.name] = ready.driver
+	m.views[ready.name] = ready.views
+	m.lastUsed[ready.name] = time.Now()
+	delete(m.idleClosed, ready.name)
+
+	// Update sidebar with real tables and connected status
+	m.Sidebar.UpdateConnection(ready.name, ready.tables, true)
+}
+
+// startConnect connects to a database in the background, showing the connection
+// as connecting in the sidebar until a connectionReadyMsg or connectionFailedMsg arrives
+func (m Model) startConnect(name, connType, url string) (Model, tea.Cmd) {
+	if m.Sidebar.IsConnecting(name) {
+		return m, nil
+	}
+	m.Sidebar.SetConnecting(name, true)
+
+	opts := m.connectionOptions(name)
+	return m, func() tea.Msg {
+		ready, err := openDatabase(name, connType, url, opts)
+		if err != nil {
+			return connectionFailedMsg{name: name, err: err}
+		}
+		return ready
+	}
+}
+
+// handleConnectionReady shows the tables of a connection opened in the background
+// and starts counting their rows
+func (m Model) handleConnectionReady(msg connectionReadyMsg) (Model, tea.Cmd) {
+	m.Sidebar.SetConnecting(msg.name, false)
+	if !m.Sidebar.HasConnection(msg.name) {
+		// Deleted while connecting
+		_ = msg.driver.Close()
+		return m, nil
+	}
+
+	logger.Info("Connected to database", map[string]any{
+		"connection": msg.name,
+		"tables":     len(msg.tables),
+	})
+	m.applyConnection(msg)
+
+	// Replay what waited for the connection
+	cmds := []tea.Cmd{m.rowCountsCmd(msg.name)}
+	for _, pending := range m.afterConnect[msg.name] {
+		cmds = append(cmds, func() tea.Msg { return pending })
+	}
+	delete(m.afterConnect, msg.name)
+	return m, tea.Batch(cmds...)
+}
+
+// handleConnectionFailed reports a connection that could not be opened
+func (m Model) handleConnectionFailed(msg connectionFailedMsg) (Model, tea.Cmd) {
+	m.Sidebar.SetConnecting(msg.name, false)
+	delete(m.afterConnect, msg.name)
+	if _, connected := m.dbConnections[msg.name]; !connected {
+		// A failed reconnect leaves nothing to show
+		m.Sidebar.UpdateConnection(msg.name, nil, false)
+	}
+	logger.Error("Failed to connect to database", map[string]any{
+		"connection": msg.name,
+		"error":      msg.err.Error(),
+	})
+	return m.showError("Failed to connect to " + msg.name + ": " + msg.err.Error())
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/sidebar"
+)
+
+// copyTableNames copies the marked sidebar tables, or the table under the cursor
+// when none is marked, as a comma-separated list. Qualified names are prefixed
+// with the schema or database, quoted for the connection's driver.
+func (m Model) copyTableNames(qualified bool) (Model, tea.Cmd) {
+	tables := m.Sidebar.MarkedTables()
+	if len(tables) == 0 {
+		selectedItem := m.Sidebar.SelectedItem()
+		connections := m.Sidebar.GetConnections()
+		if selectedItem == nil || selectedItem.Level != 1 || selectedItem.ConnectionIndex >= len(connections) {
+			return m, nil
+		}
+		conn := connections[selectedItem.ConnectionIndex]
+		if selectedItem.TableIndex < 0 || selectedItem.TableIndex >= len(conn.Tables) {
+			return m, nil
+		}
+		table := conn.Tables[selectedItem.TableIndex]
+		tables = []sidebar.MarkedTable{{
+			ConnectionName: conn.Name,
+			TableName:      table.Name,
+			Schema:         table.Schema,
+		}}
+	}
+
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		names[i] = t.TableName
+		if !qualified {
+			continue
+		}
+		driver, exists := m.dbConnections[t.ConnectionName]
+		if !exists {
+			continue
+		}
+		names[i] = driver.QuoteIdentifier(t.TableName)
+		schema := t.Schema
+		if schema == "" {
+			schema = m.connectionSchema(t.ConnectionName)
+		}
+		if schema != "" {
+			names[i] = driver.QuoteIdentifier(schema) + "." + names[i]
+		}
+	}
+
+	text := strings.Join(names, ", ")
+	if err := clipboard.WriteAll(text); err != nil {
+		logger.Error("Failed to copy to clipboard", map[string]any{"error": err.Error()})
+		return m.showError("Copy failed: " + err.Error())
+	}
+	logger.Info("Table names copied to clipboard", map[string]any{"tables": len(names)})
+
+	if len(names) == 1 {
+		return m.showSuccess("Copied " + text)
+	}
+	return m.showSuccess(fmt.Sprintf("Copied %d table names", len(names)))
+}
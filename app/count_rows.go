@@ -0,0 +1,71 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+)
+
+// matchingRowsCountedMsg carries the number of rows of a table matching its filter
+type matchingRowsCountedMsg struct {
+	table       string
+	whereClause string
+	count       int64
+	err         error
+}
+
+// countMatchingRows counts the rows of the active table matching its current
+// filter in the background, without touching the loaded page
+func (m Model) countMatchingRows() tea.Cmd {
+	connectionName, tableName, ok := m.activeTable()
+	if !ok {
+		return nil
+	}
+	driver, exists := m.dbConnections[connectionName]
+	if !exists {
+		logger.Error("No active connection", map[string]any{"connection": connectionName})
+		return nil
+	}
+	conn := m.findConnection(connectionName)
+	if conn == nil {
+		return nil
+	}
+	dbName := extractDatabaseName(conn.Host, conn.Type)
+	whereClause := m.activeTabWhereClause()
+
+	return func() tea.Msg {
+		count, err := driver.GetRowCountWithFilter(dbName, tableName, whereClause)
+		return matchingRowsCountedMsg{table: tableName, whereClause: whereClause, count: count, err: err}
+	}
+}
+
+// handleMatchingRowsCounted shows the count of matching rows in a toast
+func (m Model) handleMatchingRowsCounted(msg matchingRowsCountedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		logger.Error("Failed to count matching rows", map[string]any{
+			"table": msg.table,
+			"error": msg.err.Error(),
+		})
+		return m.showError("Failed to count rows of " + msg.table + ": " + msg.err.Error())
+	}
+
+	logger.Debug("Counted matching rows", map[string]any{
+		"table": msg.table,
+		"where": msg.whereClause,
+		"count": msg.count,
+	})
+
+	noun := "rows"
+	if msg.count == 1 {
+		noun = "row"
+	}
+	if msg.whereClause == "" {
+		return m.showToast(fmt.Sprintf("%d %s in %s", msg.count, noun, msg.table))
+	}
+	verb := "match"
+	if msg.count == 1 {
+		verb = "matches"
+	}
+	return m.showToast(fmt.Sprintf("%d %s %s %s", msg.count, noun, verb, msg.whereClause))
+}
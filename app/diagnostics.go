@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/internal/version"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// diagnosticsLoadedMsg carries the diagnostics report once the server versions are read
+type diagnosticsLoadedMsg struct {
+	report string
+}
+
+// diagnosticsConnection is a connected database to report the server version of
+type diagnosticsConnection struct {
+	name       string
+	driverType string
+	url        string
+	driver     drivers.Driver
+}
+
+// loadDiagnostics gathers the sq and Go versions, theme, paths and the server version
+// of every connected database for bug reports. Server versions need a round trip
+// to each server, so the report is built in the background.
+func (m Model) loadDiagnostics() tea.Cmd {
+	var conns []diagnosticsConnection
+	for _, conn := range m.Sidebar.GetConnections() {
+		if driver, ok := m.dbConnections[conn.Name]; ok {
+			conns = append(conns, diagnosticsConnection{conn.Name, conn.Type, conn.Host, driver})
+		}
+	}
+	sort.Slice(conns, func(i, j int) bool { return conns[i].name < conns[j].name })
+
+	themeName := theme.Current.Name
+
+	return func() tea.Msg {
+		var lines []string
+		lines = append(lines,
+			fmt.Sprintf("%-11s %s", "sq:", version.Version),
+			fmt.Sprintf("%-11s %s", "Go:", runtime.Version()),
+			fmt.Sprintf("%-11s %s/%s", "Platform:", runtime.GOOS, runtime.GOARCH),
+			fmt.Sprintf("%-11s %s", "Theme:", themeName),
+			fmt.Sprintf("%-11s %s", "Drivers:", strings.Join(drivers.Registered(), ", ")),
+			"",
+		)
+
+		for _, p := range appPaths() {
+			lines = append(lines, fmt.Sprintf("%-11s %s", p.label+":", p.path))
+		}
+		lines = append(lines, "", "Connections:")
+
+		if len(conns) == 0 {
+			lines = append(lines, "  none connected")
+		}
+		for _, conn := range conns {
+			serverVersion, err := conn.driver.ServerVersion(conn.url)
+			if err != nil {
+				serverVersion = "unknown (" + err.Error() + ")"
+			}
+			lines = append(lines, fmt.Sprintf("  %s (%s): %s", conn.name, conn.driverType, serverVersion))
+		}
+
+		return diagnosticsLoadedMsg{report: strings.Join(lines, "\n")}
+	}
+}
+
+// showDiagnostics opens the diagnostics modal with a finished report
+func (m Model) showDiagnostics(report string) Model {
+	m.diagnostics = report
+	m.DiagnosticsModal.Show(report + "\n\ny: copy diagnostics")
+	m.Focus = FocusDiagnosticsModal
+	return m.updateFooter()
+}
+
+// copyDiagnostics copies the last diagnostics report to the clipboard
+func (m Model) copyDiagnostics() (Model, tea.Cmd) {
+	if err := clipboard.WriteAll(m.diagnostics); err != nil {
+		logger.Error("Failed to copy diagnostics to clipboard", map[string]any{"error": err.Error()})
+		return m.showError("Copy failed: " + err.Error())
+	}
+	logger.Info("Diagnostics copied to clipboard", nil)
+	return m.showSuccess("Copied diagnostics")
+}
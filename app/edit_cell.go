@@ -0,0 +1,29 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/sheenazien8/sq/ui/table"
+)
+
+// editedColumnIsJSON returns whether the column of the cell being edited from the
+// action modal holds JSON (MySQL json, PostgreSQL json or jsonb), which is edited
+// in the multi-line JSON editor
+func (m Model) editedColumnIsJSON() bool {
+	activeTab := m.Tabs.ActiveTab()
+	if activeTab == nil {
+		return false
+	}
+	tableModel, ok := activeTab.Content.(table.Model)
+	if !ok {
+		return false
+	}
+	columns := tableModel.GetAllColumns()
+	selectedCol := m.ActionModal.GetSelectedColumn()
+	if selectedCol < 0 || selectedCol >= len(columns) {
+		return false
+	}
+
+	dataType := strings.ToLower(strings.TrimSpace(columns[selectedCol].Type))
+	return dataType == "json" || dataType == "jsonb"
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/sidebar"
+)
+
+// AddEphemeralConnection adds a connection for url to the sidebar without saving
+// it to storage, and connects to it once the program starts. The driver is picked
+// from the URL scheme, which may be any dburl knows.
+func (m *Model) AddEphemeralConnection(url string) error {
+	connType, url, err := drivers.ParseDSN(url)
+	if err != nil {
+		return err
+	}
+	if connType == drivers.DriverTypeSQLite {
+		if _, err := drivers.SQLiteFilePath(url); err != nil {
+			return err
+		}
+	}
+
+	name := ephemeralConnectionName(url, connType)
+	base := name
+	for i := 2; m.Sidebar.HasConnection(name); i++ {
+		name = fmt.Sprintf("%s %d", base, i)
+	}
+
+	logger.Info("Adding unsaved connection", map[string]any{
+		"name": name,
+		"type": connType,
+	})
+
+	m.Sidebar.AddEphemeralConnection(name, connType, url)
+	m.startupConnection = &sidebar.ConnectionSelectedMsg{
+		ConnectionName: name,
+		ConnectionType: connType,
+		ConnectionURL:  url,
+	}
+	return nil
+}
+
+// ephemeralConnectionName names an unsaved connection after its database, or
+// its driver when the URL has no database
+func ephemeralConnectionName(url, connType string) string {
+	name := extractDatabaseName(url, connType)
+	if connType == drivers.DriverTypeSQLite {
+		name = filepath.Base(name)
+	}
+	if name == "" || name == "." {
+		name = connType
+	}
+	return name + " (unsaved)"
+}
+
+// connectStartup connects to the connection given on the command line, if any
+func (m Model) connectStartup() tea.Cmd {
+	if m.startupConnection == nil {
+		return nil
+	}
+	msg := *m.startupConnection
+	return func() tea.Msg { return msg }
+}
@@ -0,0 +1,263 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	modalexport "github.com/sheenazien8/sq/ui/modal-export"
+	queryeditor "github.com/sheenazien8/sq/ui/query-editor"
+	"github.com/sheenazien8/sq/ui/table"
+)
+
+// queryResultTable is the table INSERT statements exported from query results go into
+const queryResultTable = "query_result"
+
+// exportSource holds the rows the export modal was opened on. The page and the
+// marked rows are copied when it opens; all rows of a table are fetched from the
+// database when the export runs.
+type exportSource struct {
+	name        string // Shown in the modal and notifications
+	connection  string
+	database    string
+	table       string // Empty for query results
+	whereClause string
+	pagination  drivers.Pagination
+	columns     []string
+	rows        [][]string
+	marked      [][]string
+}
+
+// exportedMsg reports the end of an export
+type exportedMsg struct {
+	name string
+	path string
+	rows int // -1 when the driver streamed the rows without counting them
+	err  error
+}
+
+// showTableExport opens the export modal for the active table tab
+func (m Model) showTableExport() Model {
+	connectionName, tableName, ok := m.activeTable()
+	if !ok {
+		return m
+	}
+	tableModel, ok := m.Tabs.ActiveTab().Content.(table.Model)
+	if !ok {
+		return m
+	}
+
+	source := &exportSource{
+		name:        tableName,
+		connection:  connectionName,
+		table:       tableName,
+		whereClause: m.activeTabWhereClause(),
+		pagination:  m.activeTabPagination(m.Tabs.GetActiveTabName(), 1),
+		columns:     columnTitles(tableModel.GetAllColumns()),
+		rows:        rowStrings(tableModel.Rows()),
+		marked:      rowStrings(tableModel.MarkedRows()),
+	}
+	if conn := m.findConnection(connectionName); conn != nil {
+		source.database = extractDatabaseName(conn.Host, conn.Type)
+	}
+
+	scopes := []modalexport.Scope{modalexport.ScopePage, modalexport.ScopeAll}
+	return m.showExport(source, scopes)
+}
+
+// showResultsExport opens the export modal for the result set shown in the active query tab
+func (m Model) showResultsExport(msg queryeditor.ExportResultsMsg) Model {
+	source := &exportSource{
+		name:    "query results",
+		columns: columnTitles(msg.Columns),
+		rows:    rowStrings(msg.Rows),
+		marked:  rowStrings(msg.Marked),
+	}
+	if qe := m.Tabs.GetActiveQueryEditor(); qe != nil {
+		source.connection = qe.GetConnectionName()
+	}
+
+	// Query results are all in memory, there is no page to tell apart from them
+	return m.showExport(source, []modalexport.Scope{modalexport.ScopeAll})
+}
+
+// showExport opens the export modal on source, adding the marked rows scope when there are any
+func (m Model) showExport(source *exportSource, scopes []modalexport.Scope) Model {
+	if len(source.marked) > 0 {
+		scopes = append(scopes, modalexport.ScopeMarked)
+	}
+	name := source.table
+	if name == "" {
+		name = source.connection + "-query"
+	}
+	path := exportPath(name, drivers.ExportFormats[0].Extension(), time.Now())
+
+	m.pendingExport = source
+	m.ExportModal.Show(source.name, path, scopes, len(source.marked))
+	m.Focus = FocusExportModal
+	return m.updateFooter()
+}
+
+// resolveExport runs the export once the modal is submitted, in the background,
+// and returns focus to where it was
+func (m Model) resolveExport(submitted bool) (Model, tea.Cmd) {
+	source := m.pendingExport
+	m.pendingExport = nil
+	m.Focus = FocusMain
+	m = m.updateFooter()
+	if !submitted || source == nil {
+		return m, nil
+	}
+
+	format := m.ExportModal.Format()
+	scope := m.ExportModal.Scope()
+	path := expandHome(m.ExportModal.Path())
+
+	driver, connected := m.dbConnections[source.connection]
+	if scope == modalexport.ScopeAll && source.table != "" && !connected {
+		return m.showWarning("Connect to " + source.connection + " before exporting all rows of " + source.name)
+	}
+
+	// INSERT statements are quoted for the connection's database when it is still open
+	quote := quoteANSI
+	insertTable := quote(queryResultTable)
+	if connected {
+		quote = driver.QuoteIdentifier
+		insertTable = quote(queryResultTable)
+		if source.table != "" {
+			insertTable = drivers.QuoteTableName(driver, source.table)
+		}
+	}
+
+	logger.Info("Exporting rows", map[string]any{
+		"source": source.name,
+		"format": format.String(),
+		"scope":  int(scope),
+		"path":   path,
+	})
+
+	m, cmd := m.showToast("Exporting " + source.name + "...")
+	return m, tea.Batch(cmd, func() tea.Msg {
+		rows, err := writeExportFile(path, func(w io.Writer) (int, error) {
+			switch {
+			case scope == modalexport.ScopeMarked:
+				return drivers.ExportRows(format, w, source.columns, source.marked, insertTable, quote)
+			case scope == modalexport.ScopeAll && source.table != "":
+				return drivers.ExportTable(driver, source.database, source.table, source.whereClause, source.pagination, format, w)
+			default:
+				return drivers.ExportRows(format, w, source.columns, source.rows, insertTable, quote)
+			}
+		})
+		return exportedMsg{name: source.name, path: path, rows: rows, err: err}
+	})
+}
+
+// handleExported reports where the rows were written, or why they weren't
+func (m Model) handleExported(msg exportedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		logger.Error("Failed to export rows", map[string]any{
+			"source": msg.name,
+			"error":  msg.err.Error(),
+		})
+		return m.showError("Failed to export " + msg.name + ": " + msg.err.Error())
+	}
+
+	path := msg.path
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	logger.Info("Rows exported", map[string]any{
+		"source": msg.name,
+		"path":   path,
+		"rows":   msg.rows,
+	})
+	if msg.rows < 0 {
+		return m.showSuccess(fmt.Sprintf("Exported %s to %s", msg.name, path))
+	}
+	noun := "rows"
+	if msg.rows == 1 {
+		noun = "row"
+	}
+	return m.showSuccess(fmt.Sprintf("Exported %d %s of %s to %s", msg.rows, noun, msg.name, path))
+}
+
+// writeExportFile creates path and writes it with write, buffered, removing the
+// file if writing fails. It returns what write returns.
+func writeExportFile(path string, write func(w io.Writer) (int, error)) (int, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+
+	bw := bufio.NewWriter(f)
+	n, err := write(bw)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return n, err
+	}
+	return n, nil
+}
+
+// exportPath returns a file name in the working directory made of name and the
+// time, so exports never overwrite each other
+func exportPath(name, extension string, now time.Time) string {
+	return filepath.Join(".", fmt.Sprintf("%s-%s.%s", fileNamePart(name), now.Format("20060102-150405"), extension))
+}
+
+// fileNamePart replaces the characters of name that don't belong in a file name
+func fileNamePart(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ' ' || r == ':' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// expandHome replaces a leading ~ in path with the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// quoteANSI quotes an identifier with double quotes, as standard SQL does
+func quoteANSI(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// columnTitles returns the names of columns
+func columnTitles(columns []table.Column) []string {
+	titles := make([]string, len(columns))
+	for i, col := range columns {
+		titles[i] = col.Title
+	}
+	return titles
+}
+
+// rowStrings converts table rows to the plain rows the exporters take
+func rowStrings(rows []table.Row) [][]string {
+	result := make([][]string, len(rows))
+	for i, row := range rows {
+		result[i] = row
+	}
+	return result
+}
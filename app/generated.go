@@ -0,0 +1,53 @@
+package app
+
+import (
+	"github.com/sheenazien8/sq/logger"
+	modalaction "github.com/sheenazien8/sq/ui/modal-action"
+)
+
+// generatedColumnReason explains why a cell edit can't be made when the selected
+// column is generated by the database, and returns "" when the edit can go ahead
+func (m Model) generatedColumnReason(action modalaction.Action) string {
+	if !modalaction.IsCellEditAction(action) {
+		return ""
+	}
+
+	columnNames := m.ActionModal.GetColumnNames()
+	selectedCol := m.ActionModal.GetSelectedColumn()
+	if selectedCol < 0 || selectedCol >= len(columnNames) {
+		return ""
+	}
+	columnName := columnNames[selectedCol]
+	tableName := m.ActionModal.GetTableName()
+
+	connectionName, _, ok := m.splitTabName(m.Tabs.GetActiveTabName())
+	if !ok {
+		return ""
+	}
+	driver, exists := m.dbConnections[connectionName]
+	conn := m.findConnection(connectionName)
+	if !exists || conn == nil {
+		return ""
+	}
+
+	// Without column info the edit goes ahead and the database has the last word
+	columns, err := driver.GetColumnInfo(extractDatabaseName(conn.Host, conn.Type), tableName)
+	if err != nil {
+		logger.Warn("Failed to check for generated columns", map[string]any{
+			"table": tableName,
+			"error": err.Error(),
+		})
+		return ""
+	}
+
+	for _, col := range columns {
+		if col.Name == columnName && col.Generated {
+			logger.Info("Edit of generated column refused", map[string]any{
+				"table":  tableName,
+				"column": columnName,
+			})
+			return "Column '" + columnName + "' is generated by the database (" + col.Extra + ") and can't be edited"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/filter"
+	"github.com/sheenazien8/sq/ui/tab"
+)
+
+// maxHistoryEntries caps how many navigation steps are remembered
+const maxHistoryEntries = 50
+
+// historyEntry is one step of table navigation: a table tab and the filter applied to it
+type historyEntry struct {
+	tabID       string
+	whereClause string
+}
+
+// currentHistoryEntry describes the active table tab, if any
+func (m Model) currentHistoryEntry() (historyEntry, bool) {
+	if !m.Tabs.HasTabs() || m.Tabs.GetActiveTabType() != tab.TabTypeTable {
+		return historyEntry{}, false
+	}
+
+	entry := historyEntry{tabID: m.Tabs.ActiveTab().ID}
+	if f := m.Tabs.GetActiveTabFilter(); f != nil {
+		entry.whereClause = f.WhereClause
+	}
+	return entry, true
+}
+
+// recordHistory pushes the active table tab onto the navigation history,
+// dropping any steps that were gone back over, like a browser does
+func (m *Model) recordHistory() {
+	entry, ok := m.currentHistoryEntry()
+	if !ok {
+		return
+	}
+
+	if m.historyIndex < len(m.history)-1 {
+		m.history = m.history[:m.historyIndex+1]
+	}
+	if len(m.history) > 0 && m.history[len(m.history)-1] == entry {
+		return
+	}
+
+	m.history = append(m.history, entry)
+	if len(m.history) > maxHistoryEntries {
+		m.history = m.history[len(m.history)-maxHistoryEntries:]
+	}
+	m.historyIndex = len(m.history) - 1
+}
+
+// navigateHistory moves back (-1) or forward (+1) through the navigation history
+// and restores that tab and filter. Steps whose tab has since been closed are skipped.
+func (m Model) navigateHistory(direction int) (Model, tea.Cmd) {
+	for {
+		next := m.historyIndex + direction
+		if next < 0 || next >= len(m.history) {
+			return m, nil
+		}
+
+		entry := m.history[next]
+		tabIdx := m.Tabs.FindTabByID(entry.tabID)
+		if tabIdx == -1 {
+			logger.Debug("Skipping history entry for closed tab", map[string]any{"tab": entry.tabID})
+			m.history = append(m.history[:next], m.history[next+1:]...)
+			if direction < 0 {
+				m.historyIndex--
+			}
+			continue
+		}
+
+		m.historyIndex = next
+		return m.restoreHistoryEntry(tabIdx, entry)
+	}
+}
+
+// restoreHistoryEntry switches to a tab and reloads it with the remembered filter
+func (m Model) restoreHistoryEntry(tabIdx int, entry historyEntry) (Model, tea.Cmd) {
+	logger.Debug("Restoring history entry", map[string]any{
+		"tab":   entry.tabID,
+		"where": entry.whereClause,
+	})
+
+	m.Tabs.SwitchTab(tabIdx)
+	m.Focus = FocusMain
+	m.Sidebar.SetFocused(false)
+	m.Tabs.SetFocused(true)
+
+	current, _ := m.currentHistoryEntry()
+	if current.whereClause != entry.whereClause {
+		if entry.whereClause == "" {
+			m.Tabs.ClearActiveTabFilters()
+		} else {
+			m.Tabs.AddActiveTabFilter(filter.Filter{WhereClause: entry.whereClause})
+		}
+		m = m.updateTabSize()
+		m = m.updateFooter()
+		return m.applyFilterToActiveTab()
+	}
+
+	m = m.updateFooter()
+	return m, nil
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+	queryeditor "github.com/sheenazien8/sq/ui/query-editor"
+	"github.com/sheenazien8/sq/ui/tab"
+)
+
+// idleCheckInterval is how often connections are checked for having gone idle
+const idleCheckInterval = time.Minute
+
+// idleCheckMsg closes the connections unused for longer than the idle timeout
+type idleCheckMsg struct{}
+
+// idleCheckCmd schedules the next idle check, or nothing when idle_disconnect_minutes is unset
+func (m Model) idleCheckCmd() tea.Cmd {
+	timeout := m.config.IdleTimeout()
+	if timeout == 0 {
+		return nil
+	}
+	return tea.Tick(min(idleCheckInterval, timeout), func(time.Time) tea.Msg {
+		return idleCheckMsg{}
+	})
+}
+
+// handleIdleCheck closes the drivers of connections unused for longer than the
+// idle timeout. Their tables stay in the sidebar and touchConnection reopens
+// them on next use.
+func (m Model) handleIdleCheck() (Model, tea.Cmd) {
+	timeout := m.config.IdleTimeout()
+	if timeout == 0 {
+		return m, nil
+	}
+
+	now := time.Now()
+	for name, driver := range m.dbConnections {
+		if now.Sub(m.lastUsed[name]) < timeout || m.Sidebar.IsConnecting(name) {
+			continue
+		}
+		// Leave a table that is still loading, queries still running, and
+		// connections that can't be reopened
+		if (m.loading && m.currentConnection == name) || m.queryRunningOn(name) || m.findConnection(name) == nil {
+			continue
+		}
+
+		if err := driver.Close(); err != nil {
+			logger.Warn("Failed to close idle connection", map[string]any{
+				"connection": name,
+				"error":      err.Error(),
+			})
+		}
+		delete(m.dbConnections, name)
+		m.idleClosed[name] = true
+		logger.Info("Closed idle connection", map[string]any{
+			"connection": name,
+			"idle":       now.Sub(m.lastUsed[name]).Round(time.Second).String(),
+		})
+	}
+	return m, m.idleCheckCmd()
+}
+
+// queryRunningOn reports whether a query tab is running a query on the named connection
+func (m Model) queryRunningOn(name string) bool {
+	for tabID := range m.runningQueries {
+		tabIdx := m.Tabs.FindTabByID(tabID)
+		if tabIdx == -1 {
+			continue
+		}
+		if qe, ok := m.Tabs.GetTab(tabIdx).Content.(queryeditor.Model); ok && qe.GetConnectionName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// usedConnection returns the connection a key press acts on: the connection of
+// the active tab, or the one under the sidebar cursor
+func (m Model) usedConnection() string {
+	switch m.Focus {
+	case FocusMain:
+		if m.Tabs.GetActiveTabType() == tab.TabTypeQuery {
+			if qe := m.Tabs.GetActiveQueryEditor(); qe != nil {
+				return qe.GetConnectionName()
+			}
+			return ""
+		}
+		if connectionName, _, ok := m.splitTabName(m.Tabs.GetActiveTabName()); ok {
+			return connectionName
+		}
+	case FocusSidebar:
+		connections := m.Sidebar.GetConnections()
+		if item := m.Sidebar.SelectedItem(); item != nil && item.ConnectionIndex >= 0 && item.ConnectionIndex < len(connections) {
+			return connections[item.ConnectionIndex].Name
+		}
+	}
+	return ""
+}
+
+// touchConnection records a use of the named connection. A connection closed
+// for being idle is reopened in the background by the returned command, like
+// selecting it in the sidebar; reopening returns nil while it is under way.
+func (m Model) touchConnection(name string) (Model, tea.Cmd) {
+	if name == "" {
+		return m, nil
+	}
+	if m.idleClosed[name] {
+		conn := m.findConnection(name)
+		if conn == nil {
+			delete(m.idleClosed, name)
+			return m, nil
+		}
+		if m.Sidebar.IsConnecting(name) {
+			return m, nil
+		}
+		logger.Info("Reopening idle connection", map[string]any{"connection": name})
+		return m.startConnect(name, conn.Type, conn.Host)
+	}
+	if _, connected := m.dbConnections[name]; connected {
+		m.lastUsed[name] = time.Now()
+	}
+	return m, nil
+}
+
+// reopening reports whether the named connection is being reopened after it was
+// closed for being idle
+func (m Model) reopening(name string) bool {
+	return m.idleClosed[name] && m.Sidebar.IsConnecting(name)
+}
+
+// deferUntilConnected keeps msg to replay once the named connection is ready
+func (m Model) deferUntilConnected(name string, msg tea.Msg) Model {
+	m.afterConnect[name] = append(m.afterConnect[name], msg)
+	return m
+}
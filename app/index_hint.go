@@ -0,0 +1,73 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/table"
+)
+
+// unindexedFilterMsg names the filtered columns of a table that no index starts with
+type unindexedFilterMsg struct {
+	table   string
+	columns []string
+}
+
+// checkFilterIndexes looks up the indexes of the active table in the background
+// and reports the columns the filter uses that no index starts with
+func (m Model) checkFilterIndexes(driver drivers.Driver, dbName, tableName, whereClause string) tea.Cmd {
+	if whereClause == "" || (m.config != nil && !m.config.WarnUnindexedFilters()) {
+		return nil
+	}
+
+	activeTab := m.Tabs.ActiveTab()
+	if activeTab == nil {
+		return nil
+	}
+	tableModel, ok := activeTab.Content.(table.Model)
+	if !ok {
+		return nil
+	}
+	allColumns := tableModel.GetAllColumns()
+	columnNames := make([]string, len(allColumns))
+	for i, col := range allColumns {
+		columnNames[i] = col.Title
+	}
+
+	filtered := drivers.ReferencedColumns(whereClause, columnNames)
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		indexes, err := driver.GetIndexInfo(dbName, tableName)
+		if err != nil {
+			logger.Debug("Failed to load indexes for filter hint", map[string]any{
+				"table": tableName,
+				"error": err.Error(),
+			})
+			return nil
+		}
+		unindexed := drivers.UnindexedColumns(filtered, indexes)
+		if len(unindexed) == 0 {
+			return nil
+		}
+		return unindexedFilterMsg{table: tableName, columns: unindexed}
+	}
+}
+
+// handleUnindexedFilter warns that the filter may scan the whole table
+func (m Model) handleUnindexedFilter(msg unindexedFilterMsg) (Model, tea.Cmd) {
+	logger.Debug("Filter on unindexed columns", map[string]any{
+		"table":   msg.table,
+		"columns": msg.columns,
+	})
+
+	noun := "column"
+	if len(msg.columns) > 1 {
+		noun = "columns"
+	}
+	return m.showWarning("Filtering on unindexed " + noun + " '" + strings.Join(msg.columns, "', '") + "' — may be slow")
+}
@@ -3,5 +3,8 @@ package app
 import tea "github.com/charmbracelet/bubbletea"
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	// Offer to restore a query left behind by a session that did not exit cleanly,
+	// connect to a URL given on the command line and start closing idle connections
+	// when idle_disconnect_minutes is set
+	return tea.Batch(checkRecovery, m.connectStartup(), m.idleCheckCmd())
 }
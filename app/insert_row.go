@@ -0,0 +1,103 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	modalinsertrow "github.com/sheenazien8/sq/ui/modal-insert-row"
+)
+
+// openInsertRow shows the insert row modal for the active table tab, with each
+// column's nullability and default read from the database
+func (m Model) openInsertRow() (Model, tea.Cmd) {
+	connectionName, tableName, ok := m.splitTabName(m.Tabs.GetActiveTabName())
+	if !ok {
+		return m, nil
+	}
+
+	driver, exists := m.dbConnections[connectionName]
+	if !exists {
+		logger.Error("No active connection", map[string]any{"connection": connectionName})
+		return m, nil
+	}
+	conn := m.findConnection(connectionName)
+	if conn == nil {
+		return m, nil
+	}
+
+	columns, err := driver.GetColumnInfo(extractDatabaseName(conn.Host, conn.Type), tableName)
+	if err != nil {
+		logger.Error("Failed to load column info", map[string]any{
+			"table": tableName,
+			"error": err.Error(),
+		})
+		return m.showError("Failed to load the columns of " + tableName + ": " + err.Error())
+	}
+
+	m.insertConnection = connectionName
+	m.InsertRowModal.Show(tableName, columns)
+	m.Focus = FocusInsertRowModal
+	return m.updateFooter(), nil
+}
+
+// insertRow runs the INSERT for the values entered in the insert row modal and
+// reloads the table
+func (m Model) insertRow() (Model, tea.Cmd) {
+	driver, exists := m.dbConnections[m.insertConnection]
+	conn := m.findConnection(m.insertConnection)
+	if !exists || conn == nil {
+		logger.Error("No active connection", map[string]any{"connection": m.insertConnection})
+		return m, nil
+	}
+
+	query := buildInsertQuery(driver, conn.Type, m.InsertRowModal.TableName(), m.InsertRowModal.Values())
+	logger.Info("Executing INSERT query", map[string]any{"query": query.query})
+
+	m, executed, err := m.executeWrite(driver, query)
+	if err != nil {
+		logger.Error("Failed to insert row", map[string]any{"error": err.Error()})
+		return m.showError("Failed to insert row: " + err.Error())
+	}
+	if !executed {
+		return m, nil
+	}
+
+	logger.Info("Row inserted successfully", nil)
+	return m.reloadTableData()
+}
+
+// buildInsertQuery builds the INSERT for a new row. Columns left at their default
+// are omitted so the database fills them in; NULL and typed values are listed,
+// the typed ones bound to placeholders.
+func buildInsertQuery(driver drivers.Driver, connType, tableName string, values []modalinsertrow.FieldValue) writeStatement {
+	stmt := &statementBuilder{driver: driver}
+	var columns, placeholders []string
+	for _, v := range values {
+		switch v.Mode {
+		case modalinsertrow.FieldNull:
+			placeholders = append(placeholders, stmt.bind(nil))
+		case modalinsertrow.FieldText:
+			placeholders = append(placeholders, stmt.bind(v.Text))
+		default:
+			continue
+		}
+		columns = append(columns, driver.QuoteIdentifier(v.Column))
+	}
+
+	quotedTable := drivers.QuoteTableName(driver, tableName)
+	if len(columns) == 0 {
+		// Every column at its default; MySQL and Oracle have no DEFAULT VALUES
+		switch {
+		case connType == drivers.DriverTypeMySQL:
+			return rawStatement(fmt.Sprintf("INSERT INTO %s () VALUES ()", quotedTable))
+		case connType == drivers.DriverTypeOracle && len(values) > 0:
+			return rawStatement(fmt.Sprintf("INSERT INTO %s (%s) VALUES (DEFAULT)", quotedTable, driver.QuoteIdentifier(values[0].Column)))
+		}
+		return rawStatement(fmt.Sprintf("INSERT INTO %s DEFAULT VALUES", quotedTable))
+	}
+	return stmt.build(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quotedTable, strings.Join(columns, ", "), strings.Join(placeholders, ", ")))
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+)
+
+// namedKeys maps bubbletea key names ("enter", "ctrl+o", ...) to their key types
+var namedKeys = func() map[string]tea.KeyType {
+	keys := make(map[string]tea.KeyType)
+	for k := tea.KeyCtrlShiftEnd; k <= tea.KeyBackspace; k++ {
+		if name := k.String(); name != "" {
+			keys[name] = k
+		}
+	}
+	return keys
+}()
+
+// remapKey applies the keymap from the config, turning a pressed key into the
+// built-in key it is mapped to. Unmapped keys and unknown targets pass through.
+func (m Model) remapKey(msg tea.KeyMsg) tea.KeyMsg {
+	if m.config == nil {
+		return msg
+	}
+
+	pressed := msg.String()
+	target := m.config.MappedKey(pressed)
+	if target == pressed {
+		return msg
+	}
+
+	key, ok := keyFromString(target)
+	if !ok {
+		logger.Warn("Ignoring keymap entry with unknown target key", map[string]any{
+			"key":    pressed,
+			"target": target,
+		})
+		return msg
+	}
+	return key
+}
+
+// keyFromString builds the key message whose String() is s, e.g. "G", "alt+j" or "ctrl+d"
+func keyFromString(s string) (tea.KeyMsg, bool) {
+	var key tea.Key
+	if rest, ok := strings.CutPrefix(s, "alt+"); ok {
+		key.Alt = true
+		s = rest
+	}
+
+	if keyType, ok := namedKeys[s]; ok {
+		key.Type = keyType
+		return tea.KeyMsg(key), true
+	}
+	if runes := []rune(s); len(runes) == 1 {
+		key.Type = tea.KeyRunes
+		key.Runes = runes
+		return tea.KeyMsg(key), true
+	}
+	return tea.KeyMsg{}, false
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/modal"
+	"github.com/sheenazien8/sq/ui/sidebar"
+)
+
+// largeTableRows returns the estimated row count of a table when it is at least
+// threshold, so opening it can be confirmed first. Tables without an estimate are
+// never reported. It queries the database, so it runs in the background.
+func largeTableRows(driver drivers.Driver, dbName, tableName string, threshold int64) (int64, bool) {
+	if threshold <= 0 {
+		return 0, false
+	}
+
+	estimate, err := driver.GetEstimatedRowCount(dbName, tableName)
+	if err != nil {
+		logger.Debug("No row estimate for table", map[string]any{
+			"table": tableName,
+			"error": err.Error(),
+		})
+		return 0, false
+	}
+	return estimate, estimate >= threshold
+}
+
+// confirmLargeTable asks before opening a table with an estimated rows rows
+func (m Model) confirmLargeTable(msg sidebar.TableSelectedMsg, rows int64) Model {
+	logger.Info("Large table selected, asking before loading", map[string]any{
+		"table":     msg.TableName,
+		"estimated": rows,
+	})
+
+	message := fmt.Sprintf("This table has ~%s rows. Open anyway?", groupDigits(rows))
+	m.pendingLargeTable = &msg
+	m.LargeTableModal.SetContent(modal.NewConfirmContent(message))
+	m.LargeTableModal.Show()
+	m.Focus = FocusLargeTableModal
+	return m.updateFooter()
+}
+
+// resolveLargeTable opens the pending large table, or returns to the sidebar
+func (m Model) resolveLargeTable(open bool) (Model, tea.Cmd) {
+	pending := m.pendingLargeTable
+	m.pendingLargeTable = nil
+
+	m.Focus = FocusSidebar
+	m.Sidebar.SetFocused(true)
+	m = m.updateFooter()
+
+	if pending == nil || !open {
+		return m, nil
+	}
+
+	msg := *pending
+	msg.Confirmed = true
+	return m, func() tea.Msg { return msg }
+}
+
+// groupDigits renders n with comma thousands separators
+func groupDigits(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/table"
+)
+
+// loadingDelay is how long a table load may run before the loading overlay is shown
+const loadingDelay = 300 * time.Millisecond
+
+// tableDataLoadedMsg carries the result of an asynchronous table data load
+type tableDataLoadedMsg struct {
+	id     int
+	tabID  string
+	result *drivers.PaginatedResult
+	err    error
+}
+
+// loadingTickMsg fires once a load has been running for loadingDelay
+type loadingTickMsg struct {
+	id int
+}
+
+// startTableLoad cancels any in-flight table load and starts a new one for the given tab.
+// The returned command runs the query in the background and reports back with a tableDataLoadedMsg.
+func (m Model) startTableLoad(tabID string, driver drivers.Driver, dbName, tableName, whereClause string, pagination drivers.Pagination) (Model, tea.Cmd) {
+	pagination.EstimateTotal = m.estimateRowCounts
+
+	m, ctx, id, tick := m.beginLoad()
+	load := func() tea.Msg {
+		result, err := fetchTablePage(ctx, driver, dbName, tableName, whereClause, pagination)
+		return tableDataLoadedMsg{id: id, tabID: tabID, result: result, err: err}
+	}
+
+	return m, tea.Batch(load, tick)
+}
+
+// beginLoad cancels any in-flight load and starts tracking a new one. It returns
+// the context cancelling the new load, its id, and the tick that shows the
+// loading overlay once the load has run for loadingDelay.
+func (m Model) beginLoad() (Model, context.Context, int, tea.Cmd) {
+	if m.loadingCancel != nil {
+		m.loadingCancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.loadingID++
+	m.loading = true
+	m.loadingVisible = false
+	m.loadingCancel = cancel
+
+	id := m.loadingID
+	tick := tea.Tick(loadingDelay, func(time.Time) tea.Msg {
+		return loadingTickMsg{id: id}
+	})
+	return m, ctx, id, tick
+}
+
+// finishLoad ends the load with the given id. It reports false for loads that
+// were cancelled or superseded, whose results must be dropped.
+func (m Model) finishLoad(id int) (Model, bool) {
+	if id != m.loadingID || !m.loading {
+		return m, false
+	}
+
+	m.loading = false
+	m.loadingVisible = false
+	if m.loadingCancel != nil {
+		m.loadingCancel()
+		m.loadingCancel = nil
+	}
+	return m, true
+}
+
+// fetchTablePage reads a page of a table, only the rows matching whereClause when it isn't empty
+func fetchTablePage(ctx context.Context, driver drivers.Driver, dbName, tableName, whereClause string, pagination drivers.Pagination) (*drivers.PaginatedResult, error) {
+	if whereClause == "" {
+		return driver.GetTableDataPaginatedContext(ctx, dbName, tableName, pagination)
+	}
+	return driver.GetTableDataWithFilterPaginatedContext(ctx, dbName, tableName, whereClause, pagination)
+}
+
+// tableRows converts the rows of a page to table rows, skipping its header row
+func tableRows(result *drivers.PaginatedResult) []table.Row {
+	rows := make([]table.Row, max(len(result.Data)-1, 0))
+	for i := 1; i < len(result.Data); i++ {
+		rows[i-1] = table.Row(result.Data[i])
+	}
+	return rows
+}
+
+// cancelTableLoad aborts the in-flight table load, if any
+func (m Model) cancelTableLoad() Model {
+	if !m.loading {
+		return m
+	}
+
+	if m.loadingCancel != nil {
+		m.loadingCancel()
+	}
+	m.loading = false
+	m.loadingVisible = false
+	m.loadingCancel = nil
+
+	logger.Info("Table load cancelled", nil)
+	return m
+}
+
+// handleTableDataLoaded applies a finished table load to the tab that requested it
+func (m Model) handleTableDataLoaded(msg tableDataLoadedMsg) Model {
+	// Ignore results from loads that were cancelled or superseded
+	m, current := m.finishLoad(msg.id)
+	if !current {
+		return m
+	}
+
+	if msg.err != nil {
+		if errors.Is(msg.err, context.Canceled) {
+			return m
+		}
+		logger.Error("Failed to load table data", map[string]any{
+			"tab":   msg.tabID,
+			"error": msg.err.Error(),
+		})
+		return m
+	}
+
+	tabIdx := m.Tabs.FindTabByID(msg.tabID)
+	if tabIdx == -1 {
+		// Tab was closed while loading
+		return m
+	}
+
+	result := msg.result
+	rows := tableRows(result)
+
+	logger.Debug("Loaded page", map[string]any{
+		"tab":         msg.tabID,
+		"page":        result.Page,
+		"total_pages": result.TotalPages,
+		"total_rows":  result.TotalRows,
+		"rows_loaded": len(rows),
+	})
+
+	if tabIdx == m.Tabs.ActiveTabIndex() {
+		m.currentPage = result.Page
+	}
+
+	if tableModel, ok := m.Tabs.GetTab(tabIdx).Content.(table.Model); ok {
+		tableModel.SetRows(rows)
+		tableModel.SetPagination(result.Page, result.TotalPages, result.TotalRows, result.PageSize)
+		tableModel.SetTotalApprox(result.TotalApprox)
+		m.Tabs.UpdateTabContent(tabIdx, tableModel)
+	}
+
+	return m
+}
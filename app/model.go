@@ -1,8 +1,14 @@
 package app
 
 import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/sheenazien8/sq/config"
 	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/storage"
 	"github.com/sheenazien8/sq/ui/modal"
 	"github.com/sheenazien8/sq/ui/modal-action"
 	"github.com/sheenazien8/sq/ui/modal-cell-preview"
@@ -12,7 +18,16 @@ import (
 	"github.com/sheenazien8/sq/ui/modal-edit-cell"
 	modaleditconnection "github.com/sheenazien8/sq/ui/modal-edit-connection"
 	"github.com/sheenazien8/sq/ui/modal-exit"
+	modalexport "github.com/sheenazien8/sq/ui/modal-export"
 	"github.com/sheenazien8/sq/ui/modal-help"
+	modalinsertrow "github.com/sheenazien8/sq/ui/modal-insert-row"
+	modalrecenttables "github.com/sheenazien8/sq/ui/modal-recent-tables"
+	"github.com/sheenazien8/sq/ui/modal-record"
+	modalsnippets "github.com/sheenazien8/sq/ui/modal-snippets"
+	modaltableaction "github.com/sheenazien8/sq/ui/modal-table-action"
+	"github.com/sheenazien8/sq/ui/modal-table-info"
+	modaltruncatetable "github.com/sheenazien8/sq/ui/modal-truncate-table"
+	"github.com/sheenazien8/sq/ui/notify"
 	"github.com/sheenazien8/sq/ui/sidebar"
 	"github.com/sheenazien8/sq/ui/tab"
 	"github.com/sheenazien8/sq/ui/table"
@@ -39,6 +54,19 @@ const (
 	FocusEditCellModal
 	FocusConfirmModal
 	FocusHelpModal
+	FocusTableInfoModal
+	FocusDryRunModal
+	FocusRecoveryModal
+	FocusRecordModal
+	FocusPathsModal
+	FocusDiagnosticsModal
+	FocusLargeTableModal
+	FocusRecentTablesModal
+	FocusInsertRowModal
+	FocusTableActionModal
+	FocusTruncateTableModal
+	FocusExportModal
+	FocusSnippetsModal
 )
 
 type Model struct {
@@ -55,15 +83,37 @@ type Model struct {
 	ConfirmModal          modal.Model
 	HelpModal             modalhelp.Model
 	ColumnVisibilityModal modal.Model
+	TableInfoModal        modaltableinfo.Model
+	DryRunModal           modalcellpreview.Model
+	RecoveryModal         modal.Model
+	RecordModal           modalrecord.Model
+	PathsModal            modalcellpreview.Model
+	DiagnosticsModal      modalcellpreview.Model
+	LargeTableModal       modal.Model
+	RecentTablesModal     modalrecenttables.Model
+	InsertRowModal        modalinsertrow.Model
+	TableActionModal      modaltableaction.Model
+	TruncateTableModal    modaltruncatetable.Model
+	ExportModal           modalexport.Model
+	SnippetsModal         modalsnippets.Model
+	Notification          notify.Model
 	Focus                 Focus
 
-	allRows     []table.Row
-	columns     []table.Column
-	columnNames []string
-
 	// Database connections
 	dbConnections map[string]drivers.Driver
 
+	// Views per connection, used to open them read-only
+	views map[string]map[string]bool
+
+	// Last use of each connection, and the connections closed for being idle
+	// that reopen on next use (see app/idle.go)
+	lastUsed   map[string]time.Time
+	idleClosed map[string]bool
+
+	// Messages waiting for a connection being opened in the background, replayed
+	// once it is ready and dropped if it fails
+	afterConnect map[string][]tea.Msg
+
 	// Track current table context for reloading with filters
 	currentConnection string
 	currentDatabase   string
@@ -73,6 +123,50 @@ type Model struct {
 	currentPage int
 	pageSize    int
 
+	// Last sort and page size per "connection.table", restored when a table is reopened
+	tableSettings map[string]tableSettings
+
+	// Table loading state
+	loading        bool               // A table load is in flight
+	loadingVisible bool               // The load has run long enough to show the overlay
+	loadingID      int                // Incremented per load so stale results can be ignored
+	loadingCancel  context.CancelFunc // Cancels the in-flight load's query
+
+	// Query tabs whose query runs in the background, by tab ID, and the spinner shown in them
+	runningQueries map[string]context.CancelFunc
+	querySpinner   spinner.Model
+
+	// Back/forward history of table tabs and filters visited by following foreign keys
+	history      []historyEntry
+	historyIndex int
+
+	// Use table statistics instead of COUNT(*) for pagination totals
+	estimateRowCounts bool
+
+	// Dry-run mode: data-changing actions show their SQL instead of executing it
+	dryRun bool
+
+	// Last diagnostics report, copied with y in the diagnostics modal
+	diagnostics string
+
+	// Table selection waiting for the large table prompt to be answered
+	pendingLargeTable *sidebar.TableSelectedMsg
+
+	// Connection of the table the insert row modal adds a row to
+	insertConnection string
+
+	// Connection of the table the table action menu was opened on
+	tableActionConnection string
+
+	// Rows the export modal was opened on, exported once it is submitted
+	pendingExport *exportSource
+
+	// Unsaved connection given with -url, connected to on startup
+	startupConnection *sidebar.ConnectionSelectedMsg
+
+	// Unsaved query from a previous session, waiting for the user to restore or discard it
+	recovery *storage.Recovery
+
 	// Key sequence state for multi-key commands
 	gPressed bool // Track if 'g' was pressed for 'gd' sequence
 
@@ -105,6 +199,21 @@ type Model struct {
 	config *config.Config
 }
 
+// tableSettings is the view state remembered for a table within a session
+type tableSettings struct {
+	sortColumnIdx int
+	sortDirection table.SortDirection
+	pageSize      int
+	autoFit       bool
+}
+
+// Sidebar width bounds, in columns
+const (
+	defaultSidebarWidth = 32
+	minSidebarWidth     = 20
+	sidebarWidthStep    = 4
+)
+
 func New() Model {
 	s := sidebar.New()
 	s.SetFocused(true)
@@ -133,7 +242,15 @@ func New() Model {
 	helpModal := modalhelp.New()
 	columnVisibilityContent := modalcolumnvisibility.New()
 	columnVisibilityModal := modal.New("Column Visibility", columnVisibilityContent)
+	tableInfoModal := modaltableinfo.New()
+	createConnectionModal.SetDefaultDriver(cfg.DefaultDriver)
+	s.SetQualifiedNames(cfg.QualifiedTableNames)
 	tabs := tab.New()
+	tabs.SetQueryEditorOptions(cfg.AutoIndent(), cfg.FormatOptions())
+	tabs.SetTableDisplayFormat(table.DisplayFormat{
+		DateLayout:         cfg.DateFormat(),
+		ThousandsSeparator: cfg.NumberFormat(),
+	})
 
 	return Model{
 		Sidebar:               s,
@@ -148,11 +265,32 @@ func New() Model {
 		ConfirmModal:          confirmModal,
 		HelpModal:             helpModal,
 		ColumnVisibilityModal: columnVisibilityModal,
+		TableInfoModal:        tableInfoModal,
+		DryRunModal:           modalcellpreview.NewWithTitle("Dry Run (not executed)"),
+		RecoveryModal:         modal.NewConfirm("Recover Query", "Recover unsaved query?"),
+		RecordModal:           modalrecord.New(),
+		RecentTablesModal:     modalrecenttables.New(),
+		InsertRowModal:        modalinsertrow.New(),
+		TableActionModal:      modaltableaction.New(),
+		TruncateTableModal:    modaltruncatetable.New(),
+		ExportModal:           modalexport.New(),
+		SnippetsModal:         modalsnippets.New(),
+		PathsModal:            modalcellpreview.NewWithTitle("Paths"),
+		DiagnosticsModal:      modalcellpreview.NewWithTitle("Diagnostics"),
+		LargeTableModal:       modal.NewConfirm("Large Table", "Open anyway?"),
+		Notification:          notify.New(),
 		Focus:                 FocusSidebar,
 		dbConnections:         make(map[string]drivers.Driver),
+		views:                 make(map[string]map[string]bool),
+		lastUsed:              make(map[string]time.Time),
+		idleClosed:            make(map[string]bool),
+		afterConnect:          make(map[string][]tea.Msg),
+		tableSettings:         make(map[string]tableSettings),
+		runningQueries:        make(map[string]context.CancelFunc),
+		querySpinner:          spinner.New(spinner.WithSpinner(spinner.MiniDot)),
 		themeIndex:            themeIdx,
 		config:                cfg,
 		currentPage:           1,
-		pageSize:              100,
+		pageSize:              cfg.PageSize(),
 	}
 }
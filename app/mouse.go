@@ -0,0 +1,56 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/ui/tab"
+)
+
+// handleMouse routes mouse events to the component under the pointer.
+// Clicks and wheel scrolls focus that component first; events are ignored
+// while a modal is open.
+func (m Model) handleMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if m.Focus != FocusMain && m.Focus != FocusSidebar {
+		return m, nil
+	}
+
+	headerHeight := lipgloss.Height(m.HeaderStyle)
+	if msg.Y < headerHeight || msg.Y >= headerHeight+m.ContentHeight {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	if !m.sidebarCollapsed && msg.X < m.SidebarWidth {
+		if msg.Action == tea.MouseActionPress && m.Focus != FocusSidebar {
+			m.Focus = FocusSidebar
+			m.Sidebar.SetFocused(true)
+			m.Tabs.SetFocused(false)
+			m = m.updateFooter()
+		}
+		m.Sidebar, cmd = m.Sidebar.Update(msg)
+		return m, cmd
+	}
+
+	if !m.Tabs.HasTabs() {
+		return m, nil
+	}
+	if msg.Action == tea.MouseActionPress && m.Focus != FocusMain {
+		m.Focus = FocusMain
+		m.Sidebar.SetFocused(false)
+		m.Tabs.SetFocused(true)
+		m = m.updateFooter()
+	}
+	if m.Focus != FocusMain || m.Tabs.GetActiveTabType() != tab.TabTypeTable {
+		return m, nil
+	}
+
+	// The main area starts right of the sidebar and below the header, inside its border
+	mainX := 1
+	if !m.sidebarCollapsed {
+		mainX += m.SidebarWidth
+	}
+	msg.X -= mainX
+	msg.Y -= headerHeight + 1
+	m.Tabs, cmd = m.Tabs.Update(msg)
+	return m, cmd
+}
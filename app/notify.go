@@ -0,0 +1,61 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/ui/notify"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// showNotification shows text in place of the footer help until it expires or
+// Esc dismisses it
+func (m Model) showNotification(level notify.Level, text string) (Model, tea.Cmd) {
+	cmd := m.Notification.Show(level, text)
+	return m.updateFooter(), cmd
+}
+
+// showToast shows an informational message in the footer
+func (m Model) showToast(text string) (Model, tea.Cmd) {
+	return m.showNotification(notify.LevelInfo, text)
+}
+
+// showSuccess reports a finished action in the footer
+func (m Model) showSuccess(text string) (Model, tea.Cmd) {
+	return m.showNotification(notify.LevelSuccess, text)
+}
+
+// showWarning shows a message about something that may not work as expected
+func (m Model) showWarning(text string) (Model, tea.Cmd) {
+	return m.showNotification(notify.LevelWarning, text)
+}
+
+// showError reports a failure in the footer, for longer than other messages
+func (m Model) showError(text string) (Model, tea.Cmd) {
+	return m.showNotification(notify.LevelError, text)
+}
+
+// handleNotificationExpired hides the notification when msg belongs to the one still shown
+func (m Model) handleNotificationExpired(msg notify.ExpiredMsg) Model {
+	if !m.Notification.Expire(msg) {
+		return m
+	}
+	return m.updateFooter()
+}
+
+// dismissNotification hides the shown notification, reporting whether there was one
+func (m Model) dismissNotification() (Model, bool) {
+	if !m.Notification.Visible() {
+		return m, false
+	}
+	m.Notification.Dismiss()
+	return m.updateFooter(), true
+}
+
+// footerStyle returns the footer style, coloured by the severity of a shown notification
+func (m Model) footerStyle() lipgloss.Style {
+	style := theme.Current.Footer
+	if m.Notification.Visible() && !m.loadingVisible {
+		style = m.Notification.Style(style)
+	}
+	return style.Width(m.TerminalWidth)
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/sheenazien8/sq/config"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/storage"
+)
+
+// appPath is a file sq reads or writes, for troubleshooting
+type appPath struct {
+	label string
+	path  string
+}
+
+// appPaths returns the resolved locations of the config file, connection storage,
+// query recovery file and debug log
+func appPaths() []appPath {
+	resolve := func(path string, err error) string {
+		if err != nil {
+			return "unavailable: " + err.Error()
+		}
+		return path
+	}
+
+	logPath := logger.Output()
+	if logPath != logger.OutputStderr {
+		if abs, err := filepath.Abs(logPath); err == nil {
+			logPath = abs
+		}
+	}
+
+	return []appPath{
+		{"Config", resolve(config.Path())},
+		{"Storage", resolve(storage.Path())},
+		{"Recovery", resolve(storage.RecoveryPath())},
+		{"Debug log", logPath},
+	}
+}
+
+// showPaths lists the paths in a modal and copies them to the clipboard
+func (m Model) showPaths() Model {
+	var lines []string
+	for _, p := range appPaths() {
+		lines = append(lines, fmt.Sprintf("%-10s %s", p.label+":", p.path))
+	}
+	text := strings.Join(lines, "\n")
+
+	copied := "Paths copied to clipboard."
+	if err := clipboard.WriteAll(text); err != nil {
+		logger.Error("Failed to copy paths to clipboard", map[string]any{"error": err.Error()})
+		copied = "Copying the paths failed: " + err.Error() + "."
+	}
+	m.PathsModal.Show(text + "\n\n" + copied + " o: open the config directory")
+	m.Focus = FocusPathsModal
+	return m.updateFooter()
+}
+
+// openConfigDir opens the directory holding the config and storage files in the OS file manager
+func openConfigDir() {
+	path, err := config.Path()
+	if err != nil {
+		logger.Error("Failed to resolve config directory", map[string]any{"error": err.Error()})
+		return
+	}
+	dir := filepath.Dir(path)
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", dir)
+	case "windows":
+		cmd = exec.Command("explorer", dir)
+	default:
+		cmd = exec.Command("xdg-open", dir)
+	}
+	if err := cmd.Start(); err != nil {
+		logger.Error("Failed to open config directory", map[string]any{"dir": dir, "error": err.Error()})
+		return
+	}
+	// Reap the file manager launcher once it exits
+	go func() { _ = cmd.Wait() }()
+	logger.Info("Opened config directory", map[string]any{"dir": dir})
+}
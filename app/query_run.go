@@ -0,0 +1,240 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	queryeditor "github.com/sheenazien8/sq/ui/query-editor"
+	"github.com/sheenazien8/sq/ui/tab"
+)
+
+// queryExecutedMsg carries the results of a query run in the background for a query tab
+type queryExecutedMsg struct {
+	tabID      string
+	connection string
+	results    []drivers.StatementResult
+	err        error
+}
+
+// resultCountMsg carries how many rows the statement of a LIMITed result set
+// matches without its LIMIT
+type resultCountMsg struct {
+	tabID     string
+	set       int
+	statement string
+	total     int64
+	err       error
+}
+
+// startQuery runs the query of the active query tab in the background, showing a
+// spinner in the tab until a queryExecutedMsg arrives. The editor stays usable
+// meanwhile; executing again in the same tab waits for the running query, which
+// cancelActiveQuery stops.
+func (m Model) startQuery(msg queryeditor.QueryExecuteMsg) (Model, tea.Cmd) {
+	activeTab := m.Tabs.ActiveTab()
+	if activeTab == nil || activeTab.Type != tab.TabTypeQuery {
+		return m, nil
+	}
+	tabID := activeTab.ID
+	if _, running := m.runningQueries[tabID]; running {
+		return m.showWarning("A query is already running in this tab (Ctrl+C cancels it)")
+	}
+
+	driver, exists := m.dbConnections[msg.ConnectionName]
+	if !exists {
+		logger.Error("No active connection for query", map[string]any{
+			"connection": msg.ConnectionName,
+		})
+		m.Tabs.SetQueryError("No active connection: " + msg.ConnectionName)
+		return m, nil
+	}
+
+	// The spinner ticks while any query runs, so only the first one starts it
+	var tick tea.Cmd
+	if len(m.runningQueries) == 0 {
+		tick = m.querySpinner.Tick
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.runningQueries[tabID] = cancel
+	m = m.setQueryRunning(tabID, m.querySpinner.View())
+
+	query := msg.Query
+	connection := msg.ConnectionName
+	run := func() tea.Msg {
+		// Execute every statement of the script, one result set per SELECT
+		results, err := driver.ExecuteScriptContext(ctx, query)
+		return queryExecutedMsg{tabID: tabID, connection: connection, results: results, err: err}
+	}
+	return m, tea.Batch(run, tick)
+}
+
+// handleQueryExecuted shows the results of a finished query in the tab that ran
+// it, which need not be the active one any more. Errors are also notified, as
+// the tab may not be in view.
+func (m Model) handleQueryExecuted(msg queryExecutedMsg) (Model, tea.Cmd) {
+	if cancel, running := m.runningQueries[msg.tabID]; running {
+		cancel()
+		delete(m.runningQueries, msg.tabID)
+	}
+	// The connection was in use for as long as the query ran
+	if _, connected := m.dbConnections[msg.connection]; connected {
+		m.lastUsed[msg.connection] = time.Now()
+	}
+
+	tabIdx := m.Tabs.FindTabByID(msg.tabID)
+	if tabIdx == -1 {
+		// Tab was closed while the query ran
+		return m, nil
+	}
+	qe, ok := m.Tabs.GetTab(tabIdx).Content.(queryeditor.Model)
+	if !ok {
+		return m, nil
+	}
+	qe.SetRunning("")
+
+	if errors.Is(msg.err, context.Canceled) {
+		logger.Info("Query cancelled", map[string]any{"executed": len(msg.results)})
+		qe.SetError("Query cancelled")
+	} else if msg.err != nil {
+		logger.Error("Query execution failed", map[string]any{
+			"error":    msg.err.Error(),
+			"executed": len(msg.results),
+		})
+		qe.SetError(msg.err.Error())
+		m.Tabs.UpdateTabContent(tabIdx, qe)
+		return m.showError("Query failed: " + msg.err.Error())
+	} else {
+		sets, summary := queryResultSets(msg.results)
+		qe.SetResultSets(sets, summary)
+		logger.Info("Query executed successfully", map[string]any{
+			"statements":  len(msg.results),
+			"result_sets": len(sets),
+		})
+		m.Tabs.UpdateTabContent(tabIdx, qe)
+		return m, m.countLimitedResults(msg.tabID, msg.connection, sets)
+	}
+	m.Tabs.UpdateTabContent(tabIdx, qe)
+	return m, nil
+}
+
+// countLimitedResults counts in the background the rows the LIMITed result sets
+// match without their LIMIT, when count_limited_results is on. The count runs
+// the statement again without its LIMIT, which may scan whole tables.
+func (m Model) countLimitedResults(tabID, connection string, sets []queryeditor.ResultSet) tea.Cmd {
+	if !m.config.CountLimitedResults() {
+		return nil
+	}
+	driver, exists := m.dbConnections[connection]
+	if !exists {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for i, set := range sets {
+		if !set.Limited {
+			continue
+		}
+		countQuery, ok := drivers.CountWithoutLimitQuery(set.Statement)
+		if !ok {
+			continue
+		}
+		index, statement := i, set.Statement
+		cmds = append(cmds, func() tea.Msg {
+			msg := resultCountMsg{tabID: tabID, set: index, statement: statement, total: -1}
+			rows, err := driver.ExecuteQuery(countQuery)
+			if err != nil {
+				msg.err = err
+			} else if len(rows) > 1 && len(rows[1]) > 0 {
+				msg.total, msg.err = strconv.ParseInt(rows[1][0], 10, 64)
+			}
+			return msg
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleResultCount shows the counted total of a LIMITed result set
+func (m Model) handleResultCount(msg resultCountMsg) (Model, tea.Cmd) {
+	if msg.err != nil || msg.total < 0 {
+		if msg.err != nil {
+			logger.Debug("Failed to count rows without LIMIT", map[string]any{"error": msg.err.Error()})
+		}
+		return m, nil
+	}
+	tabIdx := m.Tabs.FindTabByID(msg.tabID)
+	if tabIdx == -1 {
+		return m, nil
+	}
+	if qe, ok := m.Tabs.GetTab(tabIdx).Content.(queryeditor.Model); ok {
+		qe.SetResultTotal(msg.set, msg.statement, int(msg.total))
+		m.Tabs.UpdateTabContent(tabIdx, qe)
+	}
+	return m, nil
+}
+
+// handleQuerySpinnerTick advances the spinner of the running queries, letting it
+// stop once none is left
+func (m Model) handleQuerySpinnerTick(msg spinner.TickMsg) (Model, tea.Cmd) {
+	if len(m.runningQueries) == 0 {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.querySpinner, cmd = m.querySpinner.Update(msg)
+	for tabID, cancel := range m.runningQueries {
+		if m.Tabs.FindTabByID(tabID) == -1 {
+			// The tab was closed, nothing is left to show the results in
+			cancel()
+			delete(m.runningQueries, tabID)
+			continue
+		}
+		m = m.setQueryRunning(tabID, m.querySpinner.View())
+	}
+	return m, cmd
+}
+
+// cancelActiveQuery stops the query running in the active query tab. Ctrl+C
+// always cancels it; Esc only outside insert and visual mode, where it already
+// has a meaning. Returns false when the key was not used to cancel.
+func (m Model) cancelActiveQuery(key string) (Model, bool) {
+	if m.Focus != FocusMain || m.Tabs.GetActiveTabType() != tab.TabTypeQuery {
+		return m, false
+	}
+	cancel, running := m.runningQueries[m.Tabs.ActiveTab().ID]
+	if !running {
+		return m, false
+	}
+	switch key {
+	case "ctrl+c":
+	case "esc":
+		if qe := m.Tabs.GetActiveQueryEditor(); qe == nil || qe.GetVimMode() != "NORMAL" {
+			return m, false
+		}
+	default:
+		return m, false
+	}
+
+	// The query goroutine reports back with context.Canceled
+	cancel()
+	logger.Info("Cancelling query", map[string]any{"tab": m.Tabs.ActiveTab().ID})
+	return m, true
+}
+
+// setQueryRunning sets the running indicator of the query tab with the given ID
+func (m Model) setQueryRunning(tabID, indicator string) Model {
+	tabIdx := m.Tabs.FindTabByID(tabID)
+	if tabIdx == -1 {
+		return m
+	}
+	if qe, ok := m.Tabs.GetTab(tabIdx).Content.(queryeditor.Model); ok {
+		qe.SetRunning(indicator)
+		m.Tabs.UpdateTabContent(tabIdx, qe)
+	}
+	return m
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+	modalrecenttables "github.com/sheenazien8/sq/ui/modal-recent-tables"
+	"github.com/sheenazien8/sq/ui/sidebar"
+)
+
+// findConnection returns the sidebar connection with the given name, or nil
+func (m Model) findConnection(name string) *sidebar.Connection {
+	connections := m.Sidebar.GetConnections()
+	for i := range connections {
+		if connections[i].Name == name {
+			return &connections[i]
+		}
+	}
+	return nil
+}
+
+// recordRecentTable remembers an opened table in the config. Tables of unsaved
+// connections are left out since the connection is gone on the next launch.
+func (m *Model) recordRecentTable(connectionName, tableName string) {
+	if m.config == nil {
+		return
+	}
+	if conn := m.findConnection(connectionName); conn == nil || conn.Ephemeral {
+		return
+	}
+
+	if !m.config.AddRecentTable(connectionName + "." + tableName) {
+		return
+	}
+	if err := m.config.Save(); err != nil {
+		logger.Error("Failed to save recent tables", map[string]any{"error": err.Error()})
+	}
+}
+
+// showRecentTables opens the picker with the recently opened tables whose
+// connection still exists
+func (m Model) showRecentTables() Model {
+	var entries []modalrecenttables.Entry
+	if m.config != nil {
+		for _, key := range m.config.RecentTables {
+			connectionName, tableName, ok := m.splitTabName(key)
+			if !ok || m.findConnection(connectionName) == nil {
+				continue
+			}
+			entries = append(entries, modalrecenttables.Entry{
+				ConnectionName: connectionName,
+				TableName:      tableName,
+			})
+		}
+	}
+
+	m.RecentTablesModal.Show(entries)
+	m.Focus = FocusRecentTablesModal
+	return m.updateFooter()
+}
+
+// openRecentTable reopens a table picked from the recent tables. When its
+// connection isn't open yet it connects in the background and opens the table
+// once the connection is ready.
+func (m Model) openRecentTable(msg modalrecenttables.TableSelectedMsg) (Model, tea.Cmd) {
+	conn := m.findConnection(msg.ConnectionName)
+	if conn == nil {
+		return m.showWarning("Connection " + msg.ConnectionName + " no longer exists")
+	}
+	m.Sidebar.ActivateConnection(conn.Name)
+
+	selected := sidebar.TableSelectedMsg{
+		ConnectionName: msg.ConnectionName,
+		TableName:      msg.TableName,
+	}
+	if _, connected := m.dbConnections[msg.ConnectionName]; !connected {
+		m = m.deferUntilConnected(conn.Name, selected)
+		return m.startConnect(conn.Name, conn.Type, conn.Host)
+	}
+	return m, func() tea.Msg { return selected }
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/storage"
+	"github.com/sheenazien8/sq/ui/modal"
+	queryeditor "github.com/sheenazien8/sq/ui/query-editor"
+)
+
+// recoveryPreviewLength is how much of the recovered query the prompt shows
+const recoveryPreviewLength = 48
+
+// recoveryFoundMsg carries a query left behind by a previous session that did not exit cleanly
+type recoveryFoundMsg struct {
+	recovery *storage.Recovery
+}
+
+// checkRecovery looks for a recovery file on launch
+func checkRecovery() tea.Msg {
+	recovery, err := storage.LoadRecovery()
+	if err != nil {
+		logger.Warn("Failed to read query recovery file", map[string]any{"error": err.Error()})
+		return nil
+	}
+	if recovery == nil {
+		return nil
+	}
+	return recoveryFoundMsg{recovery: recovery}
+}
+
+// handleRecoveryFound asks whether to restore the recovered query
+func (m Model) handleRecoveryFound(msg recoveryFoundMsg) Model {
+	logger.Info("Unsaved query found", map[string]any{
+		"connection": msg.recovery.ConnectionName,
+		"saved_at":   msg.recovery.SavedAt,
+	})
+
+	preview := strings.TrimSpace(strings.SplitN(msg.recovery.Query, "\n", 2)[0])
+	if runes := []rune(preview); len(runes) > recoveryPreviewLength {
+		preview = string(runes[:recoveryPreviewLength]) + "…"
+	}
+	message := fmt.Sprintf("Recover unsaved query?\n\n%s\n\n%s, saved %s",
+		preview, msg.recovery.ConnectionName, msg.recovery.SavedAt.Format("2006-01-02 15:04"))
+
+	m.recovery = msg.recovery
+	m.RecoveryModal.SetContent(modal.NewConfirmContent(message))
+	m.RecoveryModal.Show()
+	m.Focus = FocusRecoveryModal
+	return m.updateFooter()
+}
+
+// resolveRecovery restores the recovered query into a new query tab, or discards it
+func (m Model) resolveRecovery(restore bool) Model {
+	recovery := m.recovery
+	m.recovery = nil
+	if recovery == nil || !restore {
+		logger.Info("Discarded unsaved query", nil)
+		if err := storage.ClearRecovery(); err != nil {
+			logger.Error("Failed to remove query recovery file", map[string]any{"error": err.Error()})
+		}
+		m.Focus = FocusSidebar
+		m.Sidebar.SetFocused(true)
+		m.Tabs.SetFocused(false)
+		return m.updateFooter()
+	}
+
+	logger.Info("Recovered unsaved query", map[string]any{"connection": recovery.ConnectionName})
+	m.Tabs.AddQueryTab("Query", recovery.ConnectionName, recovery.DatabaseName)
+	m.Tabs.SetActiveQuery(recovery.Query)
+	m = m.updateTabSize()
+
+	m.Focus = FocusMain
+	m.Sidebar.SetFocused(false)
+	m.Tabs.SetFocused(true)
+	return m.updateFooter()
+}
+
+// saveRecovery writes a query editor buffer to the recovery file, or removes
+// the file once the buffer has been emptied
+func (m Model) saveRecovery(msg queryeditor.RecoverySaveMsg) {
+	var err error
+	if msg.Query == "" {
+		err = storage.ClearRecovery()
+	} else {
+		err = storage.SaveRecovery(storage.Recovery{
+			ConnectionName: msg.ConnectionName,
+			DatabaseName:   msg.DatabaseName,
+			Query:          msg.Query,
+		})
+	}
+	if err != nil {
+		logger.Error("Failed to save query recovery file", map[string]any{"error": err.Error()})
+		return
+	}
+	logger.Debug("Query saved for recovery", map[string]any{"length": len(msg.Query)})
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/sidebar"
+)
+
+// rowCountWorkers bounds how many row count queries run at once per connection
+const rowCountWorkers = 4
+
+// rowCountLoadedMsg delivers the row count of one sidebar table
+type rowCountLoadedMsg struct {
+	connection string
+	table      string // Qualified as in the sidebar
+	count      int64  // -1 when the table statistics have no estimate
+	estimate   bool   // count comes from the table statistics
+	err        error
+}
+
+// fetchRowCounts fetches the row counts of tables in the background: the estimates
+// of the table statistics, or exact COUNT(*)s when exact is set. Each table is its
+// own command so counts reach the sidebar as they complete, while a semaphore
+// keeps at most rowCountWorkers queries in flight.
+func fetchRowCounts(driver drivers.Driver, connectionName, dbName string, tables []sidebar.Table, exact bool) tea.Cmd {
+	if len(tables) == 0 {
+		return nil
+	}
+
+	sem := make(chan struct{}, rowCountWorkers)
+	cmds := make([]tea.Cmd, len(tables))
+	for i, table := range tables {
+		cmds[i] = func() tea.Msg {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			tableName := drivers.QualifiedTableName(driver, table.Schema, table.Name)
+			msg := rowCountLoadedMsg{
+				connection: connectionName,
+				table:      table.QualifiedName(),
+				estimate:   !exact,
+			}
+			if exact {
+				msg.count, msg.err = driver.GetRowCount(dbName, tableName)
+			} else {
+				msg.count, msg.err = driver.GetEstimatedRowCount(dbName, tableName)
+			}
+			return msg
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleRowCountLoaded shows a fetched row count in the sidebar
+func (m Model) handleRowCountLoaded(msg rowCountLoadedMsg) Model {
+	if msg.err != nil {
+		logger.Debug("Failed to count table rows", map[string]any{
+			"connection": msg.connection,
+			"table":      msg.table,
+			"error":      msg.err.Error(),
+		})
+		return m
+	}
+	if msg.count < 0 {
+		// No statistics yet, the count stays unknown rather than scanning the table
+		return m
+	}
+
+	m.Sidebar.SetTableRowCount(msg.connection, msg.table, msg.count, msg.estimate)
+	return m
+}
+
+// rowCountsCmd starts fetching the row counts of a connected connection's tables
+// once it is expanded in the sidebar, for the tables not counted yet
+func (m Model) rowCountsCmd(connectionName string) tea.Cmd {
+	driver, ok := m.dbConnections[connectionName]
+	if !ok {
+		return nil
+	}
+
+	for _, conn := range m.Sidebar.GetConnections() {
+		if conn.Name != connectionName {
+			continue
+		}
+		if !conn.Expanded {
+			return nil
+		}
+		var uncounted []sidebar.Table
+		for _, table := range conn.Tables {
+			if table.RowCount < 0 {
+				uncounted = append(uncounted, table)
+			}
+		}
+		return fetchRowCounts(driver, connectionName, extractDatabaseName(conn.Host, conn.Type), uncounted, m.config.ExactRowCounts())
+	}
+	return nil
+}
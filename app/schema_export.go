@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+)
+
+// schemaExportedMsg reports the end of a schema export
+type schemaExportedMsg struct {
+	connection string
+	path       string
+	tables     int
+	err        error
+}
+
+// exportSchema writes the schema of the connection under the sidebar cursor to a
+// .sql file in the working directory, in the background
+func (m Model) exportSchema() (Model, tea.Cmd) {
+	selectedItem := m.Sidebar.SelectedItem()
+	if selectedItem == nil || selectedItem.Level != 0 {
+		return m, nil
+	}
+	connections := m.Sidebar.GetConnections()
+	if selectedItem.ConnectionIndex < 0 || selectedItem.ConnectionIndex >= len(connections) {
+		return m, nil
+	}
+	conn := connections[selectedItem.ConnectionIndex]
+
+	driver, exists := m.dbConnections[conn.Name]
+	if !exists {
+		return m.showWarning("Connect to " + conn.Name + " before exporting its schema")
+	}
+
+	dbName := extractDatabaseName(conn.Host, conn.Type)
+	path := schemaExportPath(conn.Name, time.Now())
+	logger.Info("Exporting schema", map[string]any{
+		"connection": conn.Name,
+		"path":       path,
+	})
+
+	m, cmd := m.showToast("Exporting the schema of " + conn.Name + "...")
+	return m, tea.Batch(cmd, func() tea.Msg {
+		tables, err := writeSchemaFile(driver, dbName, path)
+		return schemaExportedMsg{connection: conn.Name, path: path, tables: tables, err: err}
+	})
+}
+
+// writeSchemaFile exports the schema to path, removing the file if the export fails
+func writeSchemaFile(driver drivers.Driver, dbName, path string) (int, error) {
+	return writeExportFile(path, func(w io.Writer) (int, error) {
+		return drivers.ExportSchema(driver, dbName, w)
+	})
+}
+
+// schemaExportPath returns a file name in the working directory made of the
+// connection name and the time, so exports never overwrite each other
+func schemaExportPath(connectionName string, now time.Time) string {
+	return exportPath(connectionName+"-schema", "sql", now)
+}
+
+// handleSchemaExported reports where the schema was written, or why it wasn't
+func (m Model) handleSchemaExported(msg schemaExportedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		logger.Error("Failed to export schema", map[string]any{
+			"connection": msg.connection,
+			"error":      msg.err.Error(),
+		})
+		return m.showError("Failed to export the schema of " + msg.connection + ": " + msg.err.Error())
+	}
+
+	path := msg.path
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	logger.Info("Schema exported", map[string]any{
+		"connection": msg.connection,
+		"path":       path,
+		"tables":     msg.tables,
+	})
+	return m.showSuccess(fmt.Sprintf("Exported %d tables of %s to %s", msg.tables, msg.connection, path))
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/sidebar"
+)
+
+// shellExitedMsg is sent when the native client started from sq exits
+type shellExitedMsg struct {
+	connection string
+	err        error
+}
+
+// shellConnection returns the connection a native shell opens against: the
+// connection of the active table tab, the one under the sidebar cursor, or the
+// active one
+func (m Model) shellConnection() *sidebar.Connection {
+	if m.Focus == FocusMain {
+		if connectionName, _, ok := m.splitTabName(m.Tabs.GetActiveTabName()); ok {
+			if conn := m.findConnection(connectionName); conn != nil {
+				return conn
+			}
+		}
+	}
+	connections := m.Sidebar.GetConnections()
+	if item := m.Sidebar.SelectedItem(); m.Focus == FocusSidebar && item != nil && item.ConnectionIndex >= 0 && item.ConnectionIndex < len(connections) {
+		return &connections[item.ConnectionIndex]
+	}
+	return m.Sidebar.ActiveDatabase()
+}
+
+// openShell suspends the TUI and runs the driver's native client (mysql, psql,
+// sqlite3, sqlcmd, redis-cli or sqlplus) with the connection's credentials, returning to sq when it exits.
+// Connections going through SSH get a tunnel of their own for as long as the
+// client runs.
+func (m Model) openShell() (Model, tea.Cmd) {
+	conn := m.shellConnection()
+	if conn == nil {
+		return m, nil
+	}
+
+	urlstr := conn.Host
+	var tunnel *drivers.Tunnel
+	if conn.Options.SSH.Enabled() {
+		var err error
+		urlstr, tunnel, err = drivers.TunnelURL(context.Background(), conn.Options.SSH, conn.Host, drivers.DefaultPort(conn.Type))
+		if err != nil {
+			logger.Error("Failed to open SSH tunnel for shell", map[string]any{
+				"connection": conn.Name,
+				"error":      err.Error(),
+			})
+			return m.showError("Can't open a shell for " + conn.Name + ": " + err.Error())
+		}
+	}
+
+	cmd, err := drivers.ShellCommand(conn.Type, urlstr, conn.Options.TLS)
+	if err != nil {
+		tunnel.Close()
+		logger.Error("Failed to build shell command", map[string]any{
+			"connection": conn.Name,
+			"error":      err.Error(),
+		})
+		return m.showError("Can't open a shell for " + conn.Name + ": " + err.Error())
+	}
+
+	logger.Info("Opening native shell", map[string]any{
+		"connection": conn.Name,
+		"command":    cmd.Path,
+	})
+	name := conn.Name
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		tunnel.Close()
+		return shellExitedMsg{connection: name, err: err}
+	})
+}
+
+// handleShellExited reports a shell that failed to run or exited with an error
+func (m Model) handleShellExited(msg shellExitedMsg) (Model, tea.Cmd) {
+	if msg.err == nil {
+		logger.Debug("Native shell exited", map[string]any{"connection": msg.connection})
+		return m, nil
+	}
+	logger.Warn("Native shell exited with an error", map[string]any{
+		"connection": msg.connection,
+		"error":      msg.err.Error(),
+	})
+	return m.showWarning("Shell for " + msg.connection + " exited: " + msg.err.Error())
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/storage"
+	modalsnippets "github.com/sheenazien8/sq/ui/modal-snippets"
+)
+
+// loadSnippets returns the saved queries of every connection, by name
+func loadSnippets() ([]modalsnippets.Snippet, error) {
+	queries, err := storage.GetAllSavedQueries()
+	if err != nil {
+		return nil, err
+	}
+	snippets := make([]modalsnippets.Snippet, len(queries))
+	for i, q := range queries {
+		snippets[i] = modalsnippets.Snippet{ID: q.ID, Name: q.Name, Query: q.Query}
+	}
+	return snippets, nil
+}
+
+// showSnippets opens the snippets list for the active query tab
+func (m Model) showSnippets() (Model, tea.Cmd) {
+	snippets, err := loadSnippets()
+	if err != nil {
+		logger.Error("Failed to load snippets", map[string]any{"error": err.Error()})
+		return m.showError("Failed to load snippets: " + err.Error())
+	}
+	m.SnippetsModal.Show(snippets)
+	m.Focus = FocusSnippetsModal
+	return m.updateFooter(), nil
+}
+
+// showSaveSnippet asks for the name to save the active query editor's buffer under
+func (m Model) showSaveSnippet() (Model, tea.Cmd) {
+	qe := m.Tabs.GetActiveQueryEditor()
+	if qe == nil || strings.TrimSpace(qe.GetQuery()) == "" {
+		return m.showWarning("Write a query to save it as a snippet")
+	}
+	cmd := m.SnippetsModal.ShowSave()
+	m.Focus = FocusSnippetsModal
+	return m.updateFooter(), cmd
+}
+
+// refreshSnippets reloads the snippets shown in the modal after a change
+func (m Model) refreshSnippets() Model {
+	snippets, err := loadSnippets()
+	if err != nil {
+		logger.Error("Failed to load snippets", map[string]any{"error": err.Error()})
+		return m
+	}
+	m.SnippetsModal.SetSnippets(snippets)
+	return m
+}
+
+// saveSnippet saves the active query editor's buffer under msg.Name, along with
+// its connection when that is a saved one
+func (m Model) saveSnippet(msg modalsnippets.SaveMsg) (Model, tea.Cmd) {
+	qe := m.Tabs.GetActiveQueryEditor()
+	if qe == nil {
+		return m, nil
+	}
+
+	var connectionID int64
+	if conn := m.findConnection(qe.GetConnectionName()); conn != nil && !conn.Ephemeral {
+		connectionID = conn.ID
+	}
+	if _, err := storage.CreateSavedQuery(connectionID, msg.Name, qe.GetQuery()); err != nil {
+		logger.Error("Failed to save snippet", map[string]any{"name": msg.Name, "error": err.Error()})
+		return m.showError("Failed to save snippet " + msg.Name + ": " + err.Error())
+	}
+	logger.Info("Snippet saved", map[string]any{"name": msg.Name})
+	return m.refreshSnippets().showSuccess("Saved snippet " + msg.Name)
+}
+
+// renameSnippet gives the snippet msg.ID the name msg.Name
+func (m Model) renameSnippet(msg modalsnippets.RenameMsg) (Model, tea.Cmd) {
+	if err := storage.RenameSavedQuery(msg.ID, msg.Name); err != nil {
+		logger.Error("Failed to rename snippet", map[string]any{"id": msg.ID, "error": err.Error()})
+		return m.showError("Failed to rename snippet: " + err.Error())
+	}
+	logger.Info("Snippet renamed", map[string]any{"id": msg.ID, "name": msg.Name})
+	return m.refreshSnippets(), nil
+}
+
+// deleteSnippet deletes the snippet msg.ID
+func (m Model) deleteSnippet(msg modalsnippets.DeleteMsg) (Model, tea.Cmd) {
+	if err := storage.DeleteSavedQuery(msg.ID); err != nil {
+		logger.Error("Failed to delete snippet", map[string]any{"id": msg.ID, "error": err.Error()})
+		return m.showError("Failed to delete snippet " + msg.Name + ": " + err.Error())
+	}
+	logger.Info("Snippet deleted", map[string]any{"id": msg.ID, "name": msg.Name})
+	return m.refreshSnippets().showToast("Deleted snippet " + msg.Name)
+}
+
+// insertSnippet inserts a picked snippet at the cursor of the active query editor
+func (m Model) insertSnippet(msg modalsnippets.InsertMsg) (Model, tea.Cmd) {
+	qe := m.Tabs.GetActiveQueryEditor()
+	if qe == nil {
+		return m, nil
+	}
+	cmd := qe.InsertSnippet(msg.Query)
+	m.Tabs.UpdateActiveTabContent(*qe)
+	return m, cmd
+}
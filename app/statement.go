@@ -0,0 +1,70 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sheenazien8/sq/drivers"
+)
+
+// writeStatement is a data-changing statement with its values bound to the
+// driver's placeholders instead of spliced into the SQL
+type writeStatement struct {
+	query   string // With the driver's placeholders
+	args    []any  // Bound to the placeholders in order
+	display string // With the values written as SQL literals, for dry-run mode
+}
+
+// rawStatement returns a statement without bound values
+func rawStatement(query string) writeStatement {
+	return writeStatement{query: query, display: query}
+}
+
+// statementBuilder binds the values of a statement as it is built. A bound
+// value stands in the SQL as a marker until build, which turns the markers into
+// the driver's placeholders, and into literals for the copy shown in dry-run mode.
+type statementBuilder struct {
+	driver drivers.Driver
+	args   []any
+}
+
+// bind adds a value to the statement and returns what stands for it in the SQL.
+// nil is written as NULL rather than bound, SQL Server won't convert an untyped
+// NULL to every column type.
+func (b *statementBuilder) bind(value any) string {
+	if value == nil {
+		return "NULL"
+	}
+	b.args = append(b.args, value)
+	return "\x00" + strconv.Itoa(len(b.args)) + "\x00"
+}
+
+// build returns sql, written with the markers of bind, as a statement
+func (b *statementBuilder) build(sql string) writeStatement {
+	placeholders := make([]string, 0, 2*len(b.args))
+	literals := make([]string, 0, 2*len(b.args))
+	for i, arg := range b.args {
+		marker := "\x00" + strconv.Itoa(i+1) + "\x00"
+		placeholders = append(placeholders, marker, b.driver.Placeholder(i+1))
+		literals = append(literals, marker, sqlLiteral(b.driver, arg))
+	}
+	return writeStatement{
+		query:   strings.NewReplacer(placeholders...).Replace(sql),
+		args:    b.args,
+		display: strings.NewReplacer(literals...).Replace(sql),
+	}
+}
+
+// sqlLiteral writes a bound value as a string literal, escaping backslashes for
+// MySQL where they start escape sequences
+func sqlLiteral(driver drivers.Driver, value any) string {
+	s, ok := value.(string)
+	if !ok {
+		s = fmt.Sprint(value)
+	}
+	if _, isMySQL := driver.(*drivers.MySQL); isMySQL {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
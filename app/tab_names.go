@@ -0,0 +1,44 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/ui/sidebar"
+)
+
+// selectedTableName returns the name a table picked in the sidebar is opened and
+// tabbed under: "schema.table" for a table outside the connection's default
+// schema, the table name alone otherwise
+func (m Model) selectedTableName(msg sidebar.TableSelectedMsg) string {
+	driver, ok := m.dbConnections[msg.ConnectionName]
+	if !ok {
+		return msg.TableName
+	}
+	return drivers.QualifiedTableName(driver, msg.Schema, msg.TableName)
+}
+
+// splitTabName splits a "connection.table" tab name. Connection names and
+// schema-qualified table names may both contain dots, so the longest known
+// connection name the tab name starts with is taken; tab names of unknown
+// connections are split on the last dot.
+func (m Model) splitTabName(tabName string) (connectionName, tableName string, ok bool) {
+	for _, conn := range m.Sidebar.GetConnections() {
+		if len(conn.Name) > len(connectionName) && strings.HasPrefix(tabName, conn.Name+".") {
+			connectionName = conn.Name
+		}
+	}
+	if connectionName == "" {
+		lastDotIndex := strings.LastIndex(tabName, ".")
+		if lastDotIndex <= 0 {
+			return "", "", false
+		}
+		connectionName = tabName[:lastDotIndex]
+	}
+
+	tableName = tabName[len(connectionName)+1:]
+	if tableName == "" {
+		return "", "", false
+	}
+	return connectionName, tableName, true
+}
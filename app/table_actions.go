@@ -0,0 +1,83 @@
+package app
+
+import (
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+	modaltableaction "github.com/sheenazien8/sq/ui/modal-table-action"
+	"github.com/sheenazien8/sq/ui/tab"
+)
+
+// activeTable returns the connection and table of the active table tab
+func (m Model) activeTable() (connectionName, tableName string, ok bool) {
+	if !m.Tabs.HasTabs() || m.Tabs.GetActiveTabType() != tab.TabTypeTable {
+		return "", "", false
+	}
+	return m.splitTabName(m.Tabs.GetActiveTabName())
+}
+
+// showTableActions opens the table level action menu for the active table tab
+func (m Model) showTableActions() Model {
+	connectionName, tableName, ok := m.activeTable()
+	if !ok {
+		return m
+	}
+	m.tableActionConnection = connectionName
+	m.TableActionModal.Show(tableName, !m.Tabs.IsActiveTabEditable())
+	m.Focus = FocusTableActionModal
+	return m.updateFooter()
+}
+
+// handleTableAction runs the action picked in the table action menu
+func (m Model) handleTableAction(action modaltableaction.Action) (Model, tea.Cmd) {
+	tableName := m.TableActionModal.TableName()
+
+	switch action {
+	case modaltableaction.ActionCopyTableName:
+		if err := clipboard.WriteAll(tableName); err != nil {
+			logger.Error("Failed to copy to clipboard", map[string]any{"error": err.Error()})
+			return m.focusAfterAction().showError("Copy failed: " + err.Error())
+		}
+		logger.Info("Table name copied to clipboard", map[string]any{"table": tableName})
+	case modaltableaction.ActionTruncate:
+		// Read-only tabs never offer truncate, but never run it on one either
+		if !m.Tabs.IsActiveTabEditable() {
+			logger.Warn("Truncate not allowed on read-only tab", map[string]any{"table": tableName})
+			return m.focusAfterAction(), nil
+		}
+		driver, exists := m.dbConnections[m.tableActionConnection]
+		if !exists {
+			logger.Error("No active connection", map[string]any{"connection": m.tableActionConnection})
+			return m.focusAfterAction(), nil
+		}
+		m.TruncateTableModal.Show(tableName, driver.TruncateStatement(tableName))
+		m.Focus = FocusTruncateTableModal
+		return m.updateFooter(), nil
+	}
+	return m.focusAfterAction(), nil
+}
+
+// truncateTable empties the table confirmed in the truncate modal, in a transaction
+func (m Model) truncateTable() (Model, tea.Cmd) {
+	driver, exists := m.dbConnections[m.tableActionConnection]
+	if !exists {
+		logger.Error("No active connection", map[string]any{"connection": m.tableActionConnection})
+		return m, nil
+	}
+
+	statement := m.TruncateTableModal.Statement()
+	if m.dryRun {
+		// Shows the statement instead of running it
+		m, _, _ = m.executeWrite(driver, rawStatement(statement))
+		return m, nil
+	}
+
+	logger.Info("Truncating table", map[string]any{"query": statement})
+	if err := driver.ExecuteInTransaction(statement); err != nil {
+		logger.Error("Failed to truncate table", map[string]any{"error": err.Error()})
+		return m.showError("Failed to truncate " + m.TableActionModal.TableName() + ": " + err.Error())
+	}
+
+	logger.Info("Table truncated", map[string]any{"table": m.TableActionModal.TableName()})
+	return m.reloadTableData()
+}
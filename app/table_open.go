@@ -0,0 +1,281 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/filter"
+	"github.com/sheenazien8/sq/ui/sidebar"
+	"github.com/sheenazien8/sq/ui/table"
+)
+
+// tableOpenedMsg carries a table read in the background for a new tab
+type tableOpenedMsg struct {
+	id        int
+	request   sidebar.TableSelectedMsg
+	dbName    string
+	tableName string // As it is tabbed, schema.table outside the default schema
+	estimate  int64  // Estimated rows of a table too large to open without asking, 0 otherwise
+	columns   []table.Column
+	result    *drivers.PaginatedResult
+	filter    string // Pinned filter the rows are filtered by, "" when there is none or it failed
+	filterErr error  // Why the pinned filter was dropped
+	err       error
+}
+
+// openTable opens the table selected in the sidebar. A table already open in a
+// tab is switched to; otherwise it is read in the background and opened once
+// the tableOpenedMsg arrives.
+func (m Model) openTable(msg sidebar.TableSelectedMsg) (Model, tea.Cmd) {
+	// Tables outside the default schema are loaded and tabbed as schema.table
+	tableName := m.selectedTableName(msg)
+	tabName := msg.ConnectionName + "." + tableName
+
+	if !msg.NewTab && m.Tabs.FindTabByID(tabName) != -1 {
+		// The tab already shows the table's rows, switching to it needs no query
+		m.Tabs.AddTableTab(tabName, nil, nil)
+		if tableModel, ok := m.Tabs.ActiveTab().Content.(table.Model); ok {
+			m.currentPage = tableModel.GetCurrentPage()
+		}
+		logger.Debug("Switched to existing table tab", map[string]any{
+			"table": tabName,
+		})
+		return m.focusOpenedTable(msg.ConnectionName, tableName), nil
+	}
+
+	driver, exists := m.dbConnections[msg.ConnectionName]
+	if !exists {
+		return m.showError("Failed to open " + tableName + ": no active connection for " + msg.ConnectionName)
+	}
+	var dbName string
+	if conn := m.findConnection(msg.ConnectionName); conn != nil {
+		dbName = extractDatabaseName(conn.Host, conn.Type)
+	}
+	if dbName == "" {
+		return m.showError("Failed to open " + tableName + ": could not extract database name from connection")
+	}
+
+	// Ask before loading a table whose estimated size makes it slow to open
+	var threshold int64
+	if !msg.Confirmed && m.config != nil {
+		threshold = m.config.LargeTableThreshold()
+	}
+	// A freshly opened tab starts out with the table's pinned filter, so the
+	// first load already shows only the rows it matches
+	resolveForeignKeys := true
+	defaultFilter := ""
+	if m.config != nil {
+		resolveForeignKeys = m.config.ResolveForeignKeys()
+		defaultFilter = m.config.DefaultFilter(tabName)
+	}
+
+	// The first page comes sorted and sized as the last time this table was open
+	pagination := drivers.Pagination{
+		Page:          1,
+		PageSize:      m.tablePageSize(tabName),
+		EstimateTotal: m.estimateRowCounts,
+	}
+	sortColumnIdx := -1
+	sortOrder := ""
+	if settings, ok := m.tableSettings[tabName]; ok && settings.sortDirection != table.SortNone {
+		sortColumnIdx = settings.sortColumnIdx
+		sortOrder = "ASC"
+		if settings.sortDirection == table.SortDesc {
+			sortOrder = "DESC"
+		}
+	}
+
+	m, ctx, id, tick := m.beginLoad()
+	open := func() tea.Msg {
+		opened := tableOpenedMsg{id: id, request: msg, dbName: dbName, tableName: tableName}
+
+		if estimate, large := largeTableRows(driver, dbName, tableName, threshold); large {
+			opened.estimate = estimate
+			return opened
+		}
+
+		opened.columns, opened.err = tableColumns(driver, dbName, tableName, resolveForeignKeys)
+		if opened.err != nil {
+			return opened
+		}
+		if sortColumnIdx >= 0 && sortColumnIdx < len(opened.columns) {
+			pagination.SortColumn = opened.columns[sortColumnIdx].Title
+			pagination.SortOrder = sortOrder
+		}
+
+		opened.filter = defaultFilter
+		opened.result, opened.err = fetchTablePage(ctx, driver, dbName, tableName, defaultFilter, pagination)
+		if opened.err != nil && defaultFilter != "" && ctx.Err() == nil {
+			// A pinned filter the table no longer accepts shouldn't keep it from opening
+			logger.Warn("Pinned filter failed, loading unfiltered", map[string]any{
+				"table":  tabName,
+				"filter": defaultFilter,
+				"error":  opened.err.Error(),
+			})
+			opened.filterErr = opened.err
+			opened.filter = ""
+			opened.result, opened.err = fetchTablePage(ctx, driver, dbName, tableName, "", pagination)
+		}
+		return opened
+	}
+
+	return m, tea.Batch(open, tick)
+}
+
+// handleTableOpened opens a table read in the background in a tab, or asks
+// first when it turned out to be large
+func (m Model) handleTableOpened(msg tableOpenedMsg) (Model, tea.Cmd) {
+	// Ignore results from opens that were cancelled or superseded
+	m, current := m.finishLoad(msg.id)
+	if !current {
+		return m, nil
+	}
+	m = m.updateFooter()
+
+	request := msg.request
+	if msg.estimate > 0 {
+		return m.confirmLargeTable(request, msg.estimate), nil
+	}
+
+	if msg.err != nil {
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
+		logger.Error("Failed to load table data", map[string]any{
+			"connection": request.ConnectionName,
+			"table":      msg.tableName,
+			"error":      msg.err.Error(),
+		})
+		if drivers.IsPermissionDenied(msg.err) {
+			// Mark the table so the sidebar shows which tables can't be read
+			m.Sidebar.SetTableLocked(request.ConnectionName, sidebar.Table{Name: request.TableName, Schema: request.Schema}.QualifiedName())
+			return m.showError("No permission to read " + msg.tableName + ": " + msg.err.Error())
+		}
+		return m.showError("Failed to open " + msg.tableName + ": " + msg.err.Error())
+	}
+
+	// Store current context for filter reloading
+	m.currentConnection = request.ConnectionName
+	m.currentDatabase = msg.dbName
+	m.currentTable = msg.tableName
+	m.currentPage = msg.result.Page
+
+	// Add tab with table data, or switch to the tab opened while it loaded
+	tabName := request.ConnectionName + "." + msg.tableName
+	newTabCreated := true
+	if request.NewTab {
+		m.Tabs.AddTableTabForced(tabName, msg.columns, tableRows(msg.result))
+	} else {
+		newTabCreated = m.Tabs.AddTableTab(tabName, msg.columns, tableRows(msg.result))
+	}
+
+	if newTabCreated {
+		if m.isView(request.ConnectionName, msg.tableName) {
+			m.Tabs.SetActiveTabEditable(false)
+		}
+		m.restoreTableSettings(tabName)
+		if msg.filter != "" {
+			m.Tabs.AddActiveTabFilter(filter.Filter{WhereClause: msg.filter})
+		}
+
+		// Set pagination info on the new tab; an existing one keeps the page and
+		// the (possibly filtered) total of the data it already shows
+		m.Tabs.SetActiveTabPagination(
+			msg.result.Page,
+			msg.result.TotalPages,
+			msg.result.TotalRows,
+			msg.result.PageSize,
+		)
+		m.Tabs.SetActiveTabTotalApprox(msg.result.TotalApprox)
+
+		logger.Debug("New table tab created", map[string]any{
+			"table": tabName,
+		})
+	} else {
+		logger.Debug("Switched to existing table tab", map[string]any{
+			"table": tabName,
+		})
+	}
+
+	m = m.focusOpenedTable(request.ConnectionName, msg.tableName)
+
+	if msg.filterErr != nil {
+		return m.showWarning("Pinned filter failed, showing all rows: " + msg.filterErr.Error())
+	}
+	if newTabCreated && msg.filter != "" {
+		return m, m.checkFilterIndexes(m.dbConnections[request.ConnectionName], msg.dbName, msg.tableName, msg.filter)
+	}
+	return m, nil
+}
+
+// focusOpenedTable moves focus to the tab a table was just opened or switched to
+func (m Model) focusOpenedTable(connectionName, tableName string) Model {
+	// Set tab dimensions (filter bar is always 3 lines with border)
+	m = m.updateTabSize()
+
+	m.recordRecentTable(connectionName, tableName)
+
+	m.Focus = FocusMain
+	m.Sidebar.SetFocused(false)
+	m.Tabs.SetFocused(true)
+	return m.updateFooter()
+}
+
+// tableColumns reads the columns of a table for its tab, with their types and
+// primary keys and, when resolveForeignKeys is set, the tables their foreign keys
+// reference. It queries the database, so it runs in the background.
+func tableColumns(driver drivers.Driver, dbName, tableName string, resolveForeignKeys bool) ([]table.Column, error) {
+	columnsData, err := driver.GetTableColumns(dbName, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]table.Column, len(columnsData))
+	for i, col := range columnsData {
+		columns[i] = table.Column{
+			Title: col[0], // column name
+			Width: max(10, len(col[0])+2),
+		}
+	}
+
+	// Without foreign key lookups only the column info is read, which still has the keys and types
+	var structure *drivers.TableStructure
+	if resolveForeignKeys {
+		structure, err = driver.GetTableStructure(dbName, tableName)
+	} else {
+		var columnInfo []drivers.ColumnInfo
+		columnInfo, err = driver.GetColumnInfo(dbName, tableName)
+		structure = &drivers.TableStructure{Columns: columnInfo}
+	}
+	if err != nil {
+		// Don't fail if we can't get structure, just continue without key info
+		logger.Warn("Failed to load table structure", map[string]any{
+			"table": tableName,
+			"error": err.Error(),
+		})
+		return columns, nil
+	}
+
+	for i := range columns {
+		colName := columns[i].Title
+		for _, col := range structure.Columns {
+			if col.Name == colName {
+				columns[i].IsPrimaryKey = col.IsPrimaryKey
+				columns[i].Type = col.DataType
+				break
+			}
+		}
+		for _, relation := range structure.Relations {
+			if relation.Column == colName {
+				columns[i].IsForeignKey = true
+				columns[i].ReferencedTable = relation.ReferencedTable
+				columns[i].ReferencedColumn = relation.ReferencedColumn
+				break
+			}
+		}
+	}
+	return columns, nil
+}
@@ -33,16 +33,34 @@ func joinStrings(strs []string, sep string) string {
 	return result
 }
 
+// Smallest terminal the layout is drawn in; below it only a notice is shown
+const (
+	minTerminalWidth  = 60
+	minTerminalHeight = 15
+)
+
 // View renders the main application view
 func (m Model) View() string {
 	if m.TerminalWidth == 0 || m.TerminalHeight == 0 {
 		return "Loading..."
 	}
 
+	if m.TerminalWidth < minTerminalWidth || m.TerminalHeight < minTerminalHeight {
+		return m.tooSmallView()
+	}
+
 	if m.ExitModal.Visible() {
 		return m.ExitModal.View()
 	}
 
+	if m.RecoveryModal.Visible() {
+		return m.RecoveryModal.View()
+	}
+
+	if m.LargeTableModal.Visible() {
+		return m.LargeTableModal.View()
+	}
+
 	if m.CreateConnectionModal.Visible() {
 		return m.CreateConnectionModal.View()
 	}
@@ -59,10 +77,54 @@ func (m Model) View() string {
 		return m.CellPreviewModal.View()
 	}
 
+	if m.TableInfoModal.Visible() {
+		return m.TableInfoModal.View()
+	}
+
+	if m.DryRunModal.Visible() {
+		return m.DryRunModal.View()
+	}
+
+	if m.RecordModal.Visible() {
+		return m.RecordModal.View()
+	}
+
+	if m.PathsModal.Visible() {
+		return m.PathsModal.View()
+	}
+
+	if m.RecentTablesModal.Visible() {
+		return m.RecentTablesModal.View()
+	}
+
+	if m.DiagnosticsModal.Visible() {
+		return m.DiagnosticsModal.View()
+	}
+
 	if m.ActionModal.Visible() {
 		return m.ActionModal.View()
 	}
 
+	if m.TableActionModal.Visible() {
+		return m.TableActionModal.View()
+	}
+
+	if m.TruncateTableModal.Visible() {
+		return m.TruncateTableModal.View()
+	}
+
+	if m.ExportModal.Visible() {
+		return m.ExportModal.View()
+	}
+
+	if m.SnippetsModal.Visible() {
+		return m.SnippetsModal.View()
+	}
+
+	if m.InsertRowModal.Visible() {
+		return m.InsertRowModal.View()
+	}
+
 	if m.EditCellModal.Visible() {
 		return m.EditCellModal.View()
 	}
@@ -97,17 +159,39 @@ func (m Model) View() string {
 		tableBorderStyle = t.BorderUnfocused
 	}
 
-	contentHeight := m.ContentHeight - 2
+	// Sizes are clamped so a layout squeezed by the sidebar never goes negative
+	contentHeight := max(0, m.ContentHeight-2)
+	mainWidth := max(0, m.ContentWidth-4)
 
 	var mainArea string
 
 	// Show tabs if they exist, otherwise show placeholder
-	if m.Tabs.HasTabs() {
+	if m.loadingVisible {
+		// Long-running table load: replace the content with a cancel hint
+		loadingStyle := lipgloss.NewStyle().
+			Foreground(t.Colors.Foreground).
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(t.Colors.Primary).
+			Padding(0, 2)
+
+		overlay := lipgloss.Place(mainWidth, max(0, contentHeight-2),
+			lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading… (Esc to cancel)"))
+
+		mainArea = tableBorderStyle.
+			Width(mainWidth).
+			Height(contentHeight).
+			Render(overlay)
+	} else if m.Tabs.HasTabs() {
 		// For all tabs, use full height since filter is now inside tab for table tabs
+		tabsView := m.Tabs.View()
+		if m.tableBoxed() {
+			tabsView = m.boxTabs(tabsView)
+		}
 		contentView := tableBorderStyle.
-			Width(m.ContentWidth - 4).
+			Width(mainWidth).
 			Height(contentHeight).
-			Render(m.Tabs.View())
+			Render(tabsView)
 		mainArea = contentView
 	} else {
 		// Show placeholder when no tabs are open
@@ -115,13 +199,13 @@ func (m Model) View() string {
 		placeholderStyle := lipgloss.NewStyle().
 			Foreground(t.Colors.ForegroundDim).
 			Align(lipgloss.Center, lipgloss.Center).
-			Width(m.ContentWidth - 4).
-			Height(contentHeight - 2)
+			Width(mainWidth).
+			Height(max(0, contentHeight-2))
 
 		placeholder := placeholderStyle.Render("Select a table from the sidebar to open it in a tab\n(Press Enter on a table to open)")
 
 		mainArea = tableBorderStyle.
-			Width(m.ContentWidth - 4).
+			Width(mainWidth).
 			Height(contentHeight).
 			Render(placeholder)
 	}
@@ -142,3 +226,36 @@ func (m Model) View() string {
 
 	return lipgloss.JoinVertical(lipgloss.Left, m.HeaderStyle, middleSection, m.FooterStyle)
 }
+
+// boxTabs draws the tabs in a border, titled with the active tab's name
+func (m Model) boxTabs(tabsView string) string {
+	t := theme.Current
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Colors.Primary).
+		Bold(true).
+		MaxWidth(max(0, m.ContentWidth-4)).
+		Render(m.Tabs.GetActiveTabName())
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Colors.BorderUnfocused).
+		Width(max(0, m.ContentWidth-6)).
+		Render(tabsView)
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, box)
+}
+
+// tooSmallView replaces the layout in a terminal smaller than minTerminalWidth x
+// minTerminalHeight, where the panes would not fit
+func (m Model) tooSmallView() string {
+	t := theme.Current
+	notice := lipgloss.NewStyle().
+		Foreground(t.Colors.Warning).
+		Bold(true).
+		Align(lipgloss.Center).
+		MaxWidth(m.TerminalWidth).
+		Render("Terminal too small (min " + intToStr(minTerminalWidth) + "x" + intToStr(minTerminalHeight) + ")\n" +
+			"Current " + intToStr(m.TerminalWidth) + "x" + intToStr(m.TerminalHeight))
+	return lipgloss.Place(m.TerminalWidth, m.TerminalHeight, lipgloss.Center, lipgloss.Center, notice)
+}
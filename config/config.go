@@ -4,12 +4,101 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
 )
 
+// defaultPageSize is the number of rows per page when page_size is unset
+const defaultPageSize = 100
+
+// defaultLargeTableRows is the estimated row count above which opening a table
+// asks for confirmation when large_table_rows is unset
+const defaultLargeTableRows = 1_000_000
+
+// maxRecentTables caps how many recently opened tables are remembered
+const maxRecentTables = 20
+
 // Config holds the application configuration
 type Config struct {
 	Theme          string `json:"theme"`
 	AutoFitColumns bool   `json:"auto_fit_columns"`
+	LogOutput      string `json:"log_output,omitempty"` // "file" (default) or "stderr"
+	SidebarWidth   int    `json:"sidebar_width,omitempty"`
+
+	// Confirmation prompts for row actions, unset means confirm
+	ConfirmEditActions   *bool `json:"confirm_edits,omitempty"`
+	ConfirmDeleteActions *bool `json:"confirm_deletes,omitempty"`
+
+	// Style of SQL generated by the copy actions, unset means the defaults of SQLStyle
+	SQLQuoteIdentifiers  *bool `json:"sql_quote_identifiers,omitempty"`
+	SQLQualifySchema     bool  `json:"sql_qualify_schema,omitempty"`
+	SQLTrailingSemicolon *bool `json:"sql_trailing_semicolon,omitempty"`
+
+	// Filters applied when a table is opened, keyed by "connection.table"
+	DefaultFilters map[string]string `json:"default_filters,omitempty"`
+
+	// Recently opened tables as "connection.table", most recent first
+	RecentTables []string `json:"recent_tables,omitempty"`
+
+	TablePageSize int    `json:"page_size,omitempty"`      // Rows per page in table tabs, unset means 100
+	DefaultDriver string `json:"default_driver,omitempty"` // Driver preselected when creating a connection
+
+	// Estimated rows above which opening a table asks first, unset means 1,000,000 and negative never asks
+	LargeTableRows int64 `json:"large_table_rows,omitempty"`
+
+	// Warn when a filter uses a column no index starts with, unset means warn
+	UnindexedFilterWarning *bool `json:"warn_unindexed_filters,omitempty"`
+
+	// Look up foreign keys when a table opens, for the FK markers; unset means on
+	ForeignKeyLookup *bool `json:"resolve_foreign_keys,omitempty"`
+
+	// Query editor behaviour, unset means auto-indent on and the formatter defaults of FormatOptions
+	EditorAutoIndent *bool `json:"auto_indent,omitempty"`
+	FormatLineWidth  int   `json:"format_line_width,omitempty"`
+	FormatTabWidth   int   `json:"format_tab_width,omitempty"`
+	FormatSimplify   *bool `json:"format_simplify,omitempty"`
+
+	// Display of typed values in table tabs; stored values are used unchanged for editing and copying
+	DateLayout         string `json:"date_format,omitempty"`         // Go time layout for date/time columns, e.g. "02/01/2006 15:04"
+	ThousandsSeparator string `json:"thousands_separator,omitempty"` // Separator grouping digits of numeric columns, e.g. ","
+
+	// Draw a border titled with the tab name around the tab content, off by default to leave the space to the data
+	TableBox bool `json:"table_box,omitempty"`
+
+	// List PostgreSQL tables in the sidebar as schema.table, off by default
+	QualifiedTableNames bool `json:"qualified_table_names,omitempty"`
+
+	// Minutes without use after which a connection is closed, reopened on next use; unset means never
+	IdleDisconnectMinutes int `json:"idle_disconnect_minutes,omitempty"`
+
+	// Count the rows of every table listed in the sidebar exactly with COUNT(*),
+	// off by default in favour of the estimates of the table statistics
+	SidebarExactCounts bool `json:"exact_row_counts,omitempty"`
+
+	// Count the rows a query ending in LIMIT matches without it, off by default
+	// as counting runs the query again without the LIMIT
+	LimitedResultCount bool `json:"count_limited_results,omitempty"`
+
+	// Keys remapped onto built-in keys, e.g. {"ctrl+e": "e"} makes ctrl+e act like e
+	Keymap map[string]string `json:"keymap,omitempty"`
+
+	// Keys in the file this version doesn't know about, written back unchanged by Save
+	unknown map[string]json.RawMessage
+}
+
+// FormatOptions controls how the query editor formats SQL
+type FormatOptions struct {
+	LineWidth int  // Wrap formatted SQL at this width
+	TabWidth  int  // Indent width
+	Simplify  bool // Remove redundant parentheses and the like
+}
+
+// SQLStyle controls how generated SQL statements are written
+type SQLStyle struct {
+	QuoteIdentifiers  bool // Quote table and column names for the active driver
+	QualifySchema     bool // Prefix table names with the schema or database
+	TrailingSemicolon bool // End full statements with a semicolon
 }
 
 // DefaultConfig returns the default configuration
@@ -38,6 +127,11 @@ func configPath() (string, error) {
 	return filepath.Join(dir, "config.json"), nil
 }
 
+// Path returns the config file path
+func Path() (string, error) {
+	return configPath()
+}
+
 // Load reads the config from disk
 func Load() (*Config, error) {
 	path, err := configPath()
@@ -61,6 +155,62 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
+// UnmarshalJSON decodes the known fields and keeps any other keys, so a config
+// written by a newer version survives being saved by this one
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type plain Config
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for _, key := range jsonKeys() {
+		delete(raw, key)
+	}
+	if len(raw) > 0 {
+		c.unknown = raw
+	} else {
+		c.unknown = nil
+	}
+	return nil
+}
+
+// MarshalJSON encodes the known fields followed by the preserved unknown keys
+func (c Config) MarshalJSON() ([]byte, error) {
+	type plain Config
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknown) == 0 {
+		return data, err
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range c.unknown {
+		if _, ok := merged[key]; !ok {
+			merged[key] = value
+		}
+	}
+	return json.Marshal(merged)
+}
+
+// jsonKeys returns the JSON keys of the Config fields
+func jsonKeys() []string {
+	var keys []string
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
 // Save writes the config to disk
 func (c *Config) Save() error {
 	dir, err := configDir()
@@ -86,6 +236,156 @@ func (c *Config) Save() error {
 	return os.WriteFile(path, data, 0644)
 }
 
+// LogToStderr returns whether logs should be written to stderr instead of debug.log
+func (c *Config) LogToStderr() bool {
+	return c.LogOutput == "stderr"
+}
+
+// ConfirmEdits returns whether cell edits (set NULL, set empty, edit) ask for confirmation
+func (c *Config) ConfirmEdits() bool {
+	return c.ConfirmEditActions == nil || *c.ConfirmEditActions
+}
+
+// ConfirmDeletes returns whether row deletes ask for confirmation
+func (c *Config) ConfirmDeletes() bool {
+	return c.ConfirmDeleteActions == nil || *c.ConfirmDeleteActions
+}
+
+// SQLStyle returns the style for generated SQL, quoting identifiers and
+// ending statements with a semicolon unless configured otherwise
+func (c *Config) SQLStyle() SQLStyle {
+	return SQLStyle{
+		QuoteIdentifiers:  c.SQLQuoteIdentifiers == nil || *c.SQLQuoteIdentifiers,
+		QualifySchema:     c.SQLQualifySchema,
+		TrailingSemicolon: c.SQLTrailingSemicolon == nil || *c.SQLTrailingSemicolon,
+	}
+}
+
+// DefaultFilter returns the WHERE clause pinned for a "connection.table", or "" if none
+func (c *Config) DefaultFilter(tableKey string) string {
+	return c.DefaultFilters[tableKey]
+}
+
+// SetDefaultFilter pins a WHERE clause for a "connection.table"; an empty clause unpins it
+func (c *Config) SetDefaultFilter(tableKey, whereClause string) {
+	if whereClause == "" {
+		delete(c.DefaultFilters, tableKey)
+		return
+	}
+	if c.DefaultFilters == nil {
+		c.DefaultFilters = make(map[string]string)
+	}
+	c.DefaultFilters[tableKey] = whereClause
+}
+
+// AddRecentTable moves a "connection.table" to the front of the recent tables,
+// keeping at most maxRecentTables. It reports whether the list changed.
+func (c *Config) AddRecentTable(tableKey string) bool {
+	if len(c.RecentTables) > 0 && c.RecentTables[0] == tableKey {
+		return false
+	}
+
+	recent := []string{tableKey}
+	for _, key := range c.RecentTables {
+		if key != tableKey && len(recent) < maxRecentTables {
+			recent = append(recent, key)
+		}
+	}
+	c.RecentTables = recent
+	return true
+}
+
+// PageSize returns the number of rows per page for table tabs
+func (c *Config) PageSize() int {
+	if c.TablePageSize <= 0 {
+		return defaultPageSize
+	}
+	return c.TablePageSize
+}
+
+// LargeTableThreshold returns the estimated row count above which opening a
+// table asks for confirmation, or 0 when it never asks
+func (c *Config) LargeTableThreshold() int64 {
+	switch {
+	case c.LargeTableRows < 0:
+		return 0
+	case c.LargeTableRows == 0:
+		return defaultLargeTableRows
+	}
+	return c.LargeTableRows
+}
+
+// IdleTimeout returns how long a connection may go unused before it is closed,
+// or 0 when connections are never closed for being idle
+func (c *Config) IdleTimeout() time.Duration {
+	if c.IdleDisconnectMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(c.IdleDisconnectMinutes) * time.Minute
+}
+
+// ExactRowCounts returns whether the sidebar counts table rows with COUNT(*)
+// instead of showing the estimates of the table statistics
+func (c *Config) ExactRowCounts() bool {
+	return c.SidebarExactCounts
+}
+
+// CountLimitedResults returns whether the query editor counts the rows a query
+// ending in LIMIT matches without it
+func (c *Config) CountLimitedResults() bool {
+	return c.LimitedResultCount
+}
+
+// WarnUnindexedFilters returns whether filtering on an unindexed column shows a warning
+func (c *Config) WarnUnindexedFilters() bool {
+	return c.UnindexedFilterWarning == nil || *c.UnindexedFilterWarning
+}
+
+// ResolveForeignKeys returns whether opening a table looks up its foreign keys
+func (c *Config) ResolveForeignKeys() bool {
+	return c.ForeignKeyLookup == nil || *c.ForeignKeyLookup
+}
+
+// AutoIndent returns whether the query editor indents new lines
+func (c *Config) AutoIndent() bool {
+	return c.EditorAutoIndent == nil || *c.EditorAutoIndent
+}
+
+// FormatOptions returns the query formatter settings, wrapping at 80 columns
+// with two-space indents and simplifying unless configured otherwise
+func (c *Config) FormatOptions() FormatOptions {
+	opts := FormatOptions{
+		LineWidth: 80,
+		TabWidth:  2,
+		Simplify:  c.FormatSimplify == nil || *c.FormatSimplify,
+	}
+	if c.FormatLineWidth > 0 {
+		opts.LineWidth = c.FormatLineWidth
+	}
+	if c.FormatTabWidth > 0 {
+		opts.TabWidth = c.FormatTabWidth
+	}
+	return opts
+}
+
+// DateFormat returns the Go time layout date/time columns are shown in, or "" to show them as stored
+func (c *Config) DateFormat() string {
+	return c.DateLayout
+}
+
+// NumberFormat returns the thousands separator for numeric columns, or "" for none
+func (c *Config) NumberFormat() string {
+	return c.ThousandsSeparator
+}
+
+// MappedKey returns the built-in key a pressed key is remapped to, or the key itself
+func (c *Config) MappedKey(key string) string {
+	if mapped, ok := c.Keymap[key]; ok && mapped != "" {
+		return mapped
+	}
+	return key
+}
+
 // SetTheme updates the theme in config
 func (c *Config) SetTheme(themeName string) {
 	c.Theme = themeName
@@ -0,0 +1,34 @@
+package drivers
+
+import "fmt"
+
+// UniqueColumnNames returns names with repeated names suffixed "_2", "_3", ...
+// so every column of a result, such as a join of tables sharing column names,
+// can be told apart. A suffix that would clash with another column is skipped.
+func UniqueColumnNames(names []string) []string {
+	taken := make(map[string]bool, len(names))
+	for _, name := range names {
+		taken[name] = true
+	}
+
+	unique := make([]string, len(names))
+	seen := make(map[string]int, len(names))
+	for i, name := range names {
+		seen[name]++
+		if seen[name] == 1 {
+			unique[i] = name
+			continue
+		}
+
+		n := seen[name]
+		candidate := fmt.Sprintf("%s_%d", name, n)
+		for taken[candidate] {
+			n++
+			candidate = fmt.Sprintf("%s_%d", name, n)
+		}
+		seen[name] = n
+		taken[candidate] = true
+		unique[i] = candidate
+	}
+	return unique
+}
@@ -0,0 +1,41 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUniqueColumnNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{"none", nil, []string{}},
+		{"distinct", []string{"id", "name"}, []string{"id", "name"}},
+		{"duplicate", []string{"id", "id"}, []string{"id", "id_2"}},
+		{"triplicate", []string{"id", "name", "id", "id"}, []string{"id", "name", "id_2", "id_3"}},
+		{"suffix taken by a later column", []string{"id", "id", "id_2"}, []string{"id", "id_3", "id_2"}},
+		{"suffix taken by an earlier column", []string{"id", "id_2", "id"}, []string{"id", "id_2", "id_3"}},
+		{"several suffixes taken", []string{"a", "a", "a_2", "a_3", "a"}, []string{"a", "a_4", "a_2", "a_3", "a_5"}},
+		{"duplicate of a suffixed name", []string{"id_2", "id", "id", "id_2"}, []string{"id_2", "id", "id_3", "id_2_2"}},
+		{"empty names", []string{"", ""}, []string{"", "_2"}},
+		{"case differs", []string{"ID", "id"}, []string{"ID", "id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UniqueColumnNames(tt.names)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UniqueColumnNames(%q) = %q, want %q", tt.names, got, tt.want)
+			}
+
+			seen := make(map[string]bool, len(got))
+			for _, name := range got {
+				if seen[name] {
+					t.Errorf("UniqueColumnNames(%q) repeats %q", tt.names, name)
+				}
+				seen[name] = true
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package drivers
 
+import (
+	"context"
+	"strings"
+)
+
 // Deprecated: Use constants from types.go instead
 const (
 	DriverMySQL      string = DriverTypeMySQL
@@ -13,28 +18,66 @@ type Pagination struct {
 	PageSize   int
 	SortColumn string // Column name to sort by (empty = no sort)
 	SortOrder  string // "ASC" or "DESC"
+	// TieBreakers are ordered by after SortColumn, ascending, so that paging
+	// through rows that sort alike neither skips nor repeats any
+	TieBreakers []string
+	// EstimateTotal takes the total from table statistics instead of COUNT(*)
+	// when the driver has an estimate; filtered queries always count exactly
+	EstimateTotal bool
+}
+
+// orderByClause returns the ORDER BY of pagination, with a leading space, or an
+// empty string when it sets no order
+func orderByClause(pagination Pagination, quote func(string) string) string {
+	var terms []string
+	if pagination.SortColumn != "" {
+		sortOrder := pagination.SortOrder
+		if sortOrder != "DESC" {
+			sortOrder = "ASC"
+		}
+		terms = append(terms, quote(pagination.SortColumn)+" "+sortOrder)
+	}
+	for _, column := range pagination.TieBreakers {
+		if column != pagination.SortColumn {
+			terms = append(terms, quote(column))
+		}
+	}
+	if len(terms) == 0 {
+		return ""
+	}
+	return " ORDER BY " + strings.Join(terms, ", ")
 }
 
 // PaginatedResult represents paginated query results
 type PaginatedResult struct {
-	Data       [][]string
-	TotalRows  int
-	Page       int
-	PageSize   int
-	TotalPages int
+	Data        [][]string
+	TotalRows   int
+	Page        int
+	PageSize    int
+	TotalPages  int
+	TotalApprox bool // True when TotalRows is an estimate
 }
 
 type Driver interface {
 	Connect(urlstr string) error
+	Close() error
 	TestConnection(urlstr string) error
+	TestConnectionContext(ctx context.Context, urlstr string) error
+	ServerVersion(urlstr string) (string, error)
 	GetTables(database string) (map[string][]string, error)
+	GetViews(database string) ([]string, error)
 	GetTableColumns(database, table string) ([][]string, error)
+
+	// Unpaginated data methods, capped at 1000 rows. UI code should use the
+	// paginated variants below so results are never silently truncated.
 	GetTableData(database, table string) ([][]string, error)
 	GetTableDataWithFilter(database, table string, whereClause string) ([][]string, error)
 
 	// Paginated data methods
 	GetTableDataPaginated(database, table string, pagination Pagination) (*PaginatedResult, error)
 	GetTableDataWithFilterPaginated(database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error)
+	GetTableDataPaginatedContext(ctx context.Context, database, table string, pagination Pagination) (*PaginatedResult, error)
+	GetTableDataWithFilterPaginatedContext(ctx context.Context, database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error)
 
 	// Table structure methods
 	GetTableStructure(database, table string) (*TableStructure, error)
@@ -42,10 +85,91 @@ type Driver interface {
 	GetIndexInfo(database, table string) ([]IndexInfo, error)
 	GetRelationInfo(database, table string) ([]RelationInfo, error)
 	GetTriggerInfo(database, table string) ([]TriggerInfo, error)
+	GetDependencies(database, table string) ([]DependencyInfo, error)
+	GetTableInfo(database, table string) (*TableInfo, error)
+	// GetCreateStatements returns the statements that recreate a table: its
+	// CREATE TABLE, with keys and foreign keys, followed by its other indexes
+	GetCreateStatements(database, table string) ([]string, error)
+	// GetEstimatedRowCount returns the row count from table statistics without
+	// scanning the table, or -1 when no estimate is available
+	GetEstimatedRowCount(database, table string) (int64, error)
+	// GetRowCount returns the exact number of rows in a table
+	GetRowCount(database, table string) (int64, error)
+	// GetRowCountWithFilter returns the exact number of rows matching a WHERE clause,
+	// or of all rows when whereClause is empty
+	GetRowCountWithFilter(database, table, whereClause string) (int64, error)
 
-	// Query execution
+	// Query execution; the Context variants stop the running statement when ctx is cancelled
 	ExecuteQuery(query string) ([][]string, error)
+	ExecuteQueryContext(ctx context.Context, query string) ([][]string, error)
+	ExecuteScript(script string) ([]StatementResult, error)
+	ExecuteScriptContext(ctx context.Context, script string) ([]StatementResult, error)
+	// ExecuteInTransaction runs the statements in one transaction, rolled back if any fails
+	ExecuteInTransaction(statements ...string) error
+	// ExecuteStatement runs a statement returning no rows with args bound to its
+	// placeholders, so values are never spliced into the SQL, and returns the
+	// number of rows it affected
+	ExecuteStatement(query string, args ...any) (int64, error)
+	// Placeholder returns the placeholder of the nth argument of ExecuteStatement, from 1
+	Placeholder(n int) string
+
+	// TruncateStatement returns the statement that removes every row of a table
+	TruncateStatement(table string) string
 
 	// Identifier quoting
 	QuoteIdentifier(identifier string) string
+
+	// ContainsClause returns a WHERE clause matching rows whose column contains value
+	// literally, with LIKE wildcards in value escaped
+	ContainsClause(column, value string) string
+}
+
+// SchemaQualifier is implemented by drivers whose tables live in several schemas.
+// Their table arguments name tables outside DefaultSchema as "schema.table".
+type SchemaQualifier interface {
+	DefaultSchema() string
+	SplitTable(table string) (schema, name string)
+}
+
+// QualifiedTableName returns the name driver methods take for a table of schema:
+// the table alone for drivers without schemas and for the default schema
+func QualifiedTableName(driver Driver, schema, table string) string {
+	qualifier, ok := driver.(SchemaQualifier)
+	if !ok || schema == "" || schema == qualifier.DefaultSchema() {
+		return table
+	}
+	return schema + "." + table
+}
+
+// SplitTableName splits a table name as driver methods take it into its schema
+// and name. The schema is empty for tables of the default schema.
+func SplitTableName(driver Driver, table string) (schema, name string) {
+	qualifier, ok := driver.(SchemaQualifier)
+	if !ok {
+		return "", table
+	}
+	schema, name = qualifier.SplitTable(table)
+	if schema == qualifier.DefaultSchema() {
+		return "", name
+	}
+	return schema, name
+}
+
+// QuoteTableName quotes a table name as driver methods take it for use in SQL,
+// quoting the schema of a schema-qualified name separately
+func QuoteTableName(driver Driver, table string) string {
+	schema, name := SplitTableName(driver, table)
+	if schema == "" {
+		return driver.QuoteIdentifier(name)
+	}
+	return driver.QuoteIdentifier(schema) + "." + driver.QuoteIdentifier(name)
+}
+
+// likeEscaper escapes the LIKE wildcards and the escape character itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes % and _ in value so a LIKE pattern built from it, with
+// ESCAPE '\', matches them literally
+func EscapeLike(value string) string {
+	return likeEscaper.Replace(value)
 }
@@ -0,0 +1,89 @@
+package drivers
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain", "plain"},
+		{"50%_off", `50\%\_off`},
+		{"_", `\_`},
+		{`C:\temp`, `C:\\temp`},
+		{`\%`, `\\\%`},
+	}
+	for _, tt := range tests {
+		if got := EscapeLike(tt.value); got != tt.want {
+			t.Errorf("EscapeLike(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestContainsClause(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver Driver
+		value  string
+		want   string
+	}{
+		{"postgres", &PostgreSQL{}, "50%_off", `"name" LIKE '%50\%\_off%' ESCAPE '\'`},
+		{"postgres quote", &PostgreSQL{}, "it's", `"name" LIKE '%it''s%' ESCAPE '\'`},
+		{"sqlite", &SQLite{}, "_", `"name" LIKE '%\_%' ESCAPE '\'`},
+		{"sqlite backslash", &SQLite{}, `a\b`, `"name" LIKE '%a\\b%' ESCAPE '\'`},
+		{"mysql", &MySQL{}, "50%_off", "`name` LIKE '%50\\\\%\\\\_off%' ESCAPE '\\\\'"},
+		{"mysql backslash", &MySQL{}, `a\b`, "`name` LIKE '%a\\\\\\\\b%' ESCAPE '\\\\'"},
+		{"mssql", &MSSQL{}, "[50%]", `[name] LIKE N'%\[50\%]%' ESCAPE '\'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.driver.ContainsClause("name", tt.value); got != tt.want {
+				t.Errorf("ContainsClause(name, %q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsClauseMatchesLiterally(t *testing.T) {
+	db := &SQLite{}
+	if err := db.Connect("file:" + filepath.Join(t.TempDir(), "like.db")); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.ExecuteStatement(`CREATE TABLE t (name TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"50%_off", "50 off", "5000 offers", "a_b", "axb", `a\b`, "ab"} {
+		if _, err := db.ExecuteStatement(`INSERT INTO t (name) VALUES (?)`, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"50%_off", []string{"50%_off"}},
+		{"_", []string{"50%_off", "a_b"}},
+		{`\`, []string{`a\b`}},
+		{"%", []string{"50%_off"}},
+	}
+	for _, tt := range tests {
+		rows, err := db.ExecuteQuery("SELECT name FROM t WHERE " + db.ContainsClause("name", tt.value) + " ORDER BY rowid")
+		if err != nil {
+			t.Fatalf("%q: %v", tt.value, err)
+		}
+		var got []string
+		for _, row := range rows[1:] {
+			got = append(got, row[0])
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("contains %q matched %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package drivers
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/redis/go-redis/v9"
+)
+
+// MySQL error numbers for missing privileges
+const (
+	mysqlErrDBAccessDenied     = 1044 // ER_DBACCESS_DENIED_ERROR
+	mysqlErrTableAccessDenied  = 1142 // ER_TABLEACCESS_DENIED_ERROR
+	mysqlErrColumnAccessDenied = 1143 // ER_COLUMNACCESS_DENIED_ERROR
+)
+
+// pqInsufficientPrivilege is the PostgreSQL SQLSTATE for missing privileges
+const pqInsufficientPrivilege = "42501"
+
+// SQL Server error numbers for missing permissions
+const (
+	mssqlErrObjectPermission   = 229 // The %ls permission was denied on the object
+	mssqlErrColumnPermission   = 230 // The %ls permission was denied on the column
+	mssqlErrDatabasePermission = 262 // %ls permission denied in database
+)
+
+// IsPermissionDenied reports whether err means the user lacks the privileges
+// for the statement, e.g. SELECT on a table they can list but not read
+func IsPermissionDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case mysqlErrDBAccessDenied, mysqlErrTableAccessDenied, mysqlErrColumnAccessDenied:
+			return true
+		}
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqInsufficientPrivilege
+	}
+
+	var mssqlErr mssql.Error
+	if errors.As(err, &mssqlErr) {
+		switch mssqlErr.Number {
+		case mssqlErrObjectPermission, mssqlErrColumnPermission, mssqlErrDatabasePermission:
+			return true
+		}
+		return false
+	}
+
+	// Redis ACLs deny commands and keys with NOPERM errors
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		return strings.HasPrefix(redisErr.Error(), "NOPERM")
+	}
+
+	// Errors wrapped without %w only keep their message. Oracle errors are only
+	// read from it, e.g. ORA-01031: insufficient privileges, as the driver is left
+	// out of builds without cgo.
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "permission denied") || strings.Contains(msg, "command denied") ||
+		strings.Contains(msg, "permission was denied") || strings.Contains(msg, "insufficient privileges")
+}
@@ -0,0 +1,336 @@
+package drivers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// exportPageSize is the number of rows fetched per page by the generic CSV export
+const exportPageSize = 1000
+
+// CopyOuter is implemented by drivers that can stream a table as CSV faster than paging through it
+type CopyOuter interface {
+	CopyOut(table, whereClause string, w io.Writer) error
+}
+
+// ExportCSV writes a table's rows, optionally filtered, to w as CSV with a header row.
+// NULL values are written as empty fields. Drivers implementing CopyOuter stream the
+// data themselves; any other driver is paged through with GetTableDataWithFilterPaginated,
+// in the order of exportOrder.
+func ExportCSV(driver Driver, database, table, whereClause string, w io.Writer) error {
+	if copier, ok := driver.(CopyOuter); ok {
+		return copier.CopyOut(table, whereClause, w)
+	}
+
+	order, err := exportOrder(driver, database, table)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	for page := 1; ; page++ {
+		result, err := driver.GetTableDataWithFilterPaginated(database, table, whereClause, Pagination{
+			Page:        page,
+			PageSize:    exportPageSize,
+			TieBreakers: order,
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, record := range result.Data {
+			// Every page starts with the header row, only keep the first one
+			if i == 0 && page > 1 {
+				continue
+			}
+			if i > 0 {
+				record = csvRecord(record)
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+
+		if page >= result.TotalPages {
+			break
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// unorderableTypes are column types the databases can't sort by
+var unorderableTypes = map[string]bool{
+	"json": true, "xml": true, "clob": true, "nclob": true, "blob": true, "bfile": true,
+	"long": true, "ntext": true, "image": true, "geometry": true, "geography": true,
+}
+
+// exportOrder returns the columns that give the rows of a table a stable order to
+// page through: its primary key, or every sortable column of a table without one.
+// Without an order, LIMIT/OFFSET pages may skip or repeat rows.
+func exportOrder(driver Driver, database, table string) ([]string, error) {
+	columns, err := driver.GetColumnInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var key, sortable []string
+	for _, col := range columns {
+		if col.IsPrimaryKey {
+			key = append(key, col.Name)
+		}
+		dataType, _, _ := strings.Cut(strings.ToLower(col.DataType), "(")
+		if !unorderableTypes[strings.TrimSpace(dataType)] {
+			sortable = append(sortable, col.Name)
+		}
+	}
+	if len(key) > 0 {
+		return key, nil
+	}
+	return sortable, nil
+}
+
+// csvRecord converts a formatted row into a CSV record, writing NULLs as empty fields
+func csvRecord(row []string) []string {
+	record := make([]string, len(row))
+	for i, val := range row {
+		if val != "NULL" {
+			record[i] = val
+		}
+	}
+	return record
+}
+
+// ExportFormat is a file format rows are exported in
+type ExportFormat int
+
+const (
+	ExportFormatCSV ExportFormat = iota
+	ExportFormatTSV
+	ExportFormatJSON
+	ExportFormatInsert
+)
+
+// ExportFormats lists the export formats in the order they are offered
+var ExportFormats = []ExportFormat{ExportFormatCSV, ExportFormatTSV, ExportFormatJSON, ExportFormatInsert}
+
+// String returns the name of the format
+func (f ExportFormat) String() string {
+	switch f {
+	case ExportFormatTSV:
+		return "TSV"
+	case ExportFormatJSON:
+		return "JSON"
+	case ExportFormatInsert:
+		return "INSERT"
+	}
+	return "CSV"
+}
+
+// Extension returns the file extension of the format, without the dot
+func (f ExportFormat) Extension() string {
+	switch f {
+	case ExportFormatTSV:
+		return "tsv"
+	case ExportFormatJSON:
+		return "json"
+	case ExportFormatInsert:
+		return "sql"
+	}
+	return "csv"
+}
+
+// RowWriter writes exported rows one at a time. Close finishes the output, such
+// as the closing bracket of a JSON array; it leaves the underlying writer open.
+type RowWriter interface {
+	WriteRow(row []string) error
+	Close() error
+}
+
+// NewRowWriter returns a RowWriter for rows of columns in format, writing any
+// header right away. INSERT statements go into table, which must already be
+// quoted; quote quotes the column names.
+func NewRowWriter(format ExportFormat, w io.Writer, columns []string, table string, quote func(string) string) (RowWriter, error) {
+	switch format {
+	case ExportFormatJSON:
+		jw := &jsonRowWriter{w: w, keys: UniqueColumnNames(columns)}
+		_, err := io.WriteString(w, "[")
+		return jw, err
+	case ExportFormatInsert:
+		quoted := make([]string, len(columns))
+		for i, column := range columns {
+			quoted[i] = quote(column)
+		}
+		return &insertRowWriter{
+			w:      w,
+			prefix: "INSERT INTO " + table + " (" + strings.Join(quoted, ", ") + ") VALUES (",
+		}, nil
+	}
+
+	cw := csv.NewWriter(w)
+	if format == ExportFormatTSV {
+		cw.Comma = '\t'
+	}
+	return &delimitedRowWriter{cw: cw}, cw.Write(columns)
+}
+
+// delimitedRowWriter writes CSV or TSV records, NULLs as empty fields
+type delimitedRowWriter struct {
+	cw *csv.Writer
+}
+
+func (d *delimitedRowWriter) WriteRow(row []string) error {
+	return d.cw.Write(csvRecord(row))
+}
+
+func (d *delimitedRowWriter) Close() error {
+	d.cw.Flush()
+	return d.cw.Error()
+}
+
+// jsonRowWriter writes a JSON array with one object per row, keeping the column
+// order and writing NULLs as null
+type jsonRowWriter struct {
+	w    io.Writer
+	keys []string
+	rows int
+}
+
+func (j *jsonRowWriter) WriteRow(row []string) error {
+	var b strings.Builder
+	if j.rows > 0 {
+		b.WriteString(",")
+	}
+	b.WriteString("\n  {")
+	for i, key := range j.keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		var value any
+		if i < len(row) && row[i] != "NULL" {
+			value = row[i]
+		}
+		k, _ := json.Marshal(key)
+		v, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		b.Write(k)
+		b.WriteString(": ")
+		b.Write(v)
+	}
+	b.WriteString("}")
+	j.rows++
+	_, err := io.WriteString(j.w, b.String())
+	return err
+}
+
+func (j *jsonRowWriter) Close() error {
+	end := "\n]\n"
+	if j.rows == 0 {
+		end = "]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+// insertRowWriter writes one INSERT statement per row, NULLs unquoted
+type insertRowWriter struct {
+	w      io.Writer
+	prefix string
+}
+
+func (s *insertRowWriter) WriteRow(row []string) error {
+	values := make([]string, len(row))
+	for i, val := range row {
+		if val == "NULL" {
+			values[i] = "NULL"
+		} else {
+			values[i] = "'" + strings.ReplaceAll(val, "'", "''") + "'"
+		}
+	}
+	_, err := io.WriteString(s.w, s.prefix+strings.Join(values, ", ")+");\n")
+	return err
+}
+
+func (s *insertRowWriter) Close() error {
+	return nil
+}
+
+// ExportRows writes rows of columns held in memory, such as query results, to w
+// in format and returns how many rows were written
+func ExportRows(format ExportFormat, w io.Writer, columns []string, rows [][]string, table string, quote func(string) string) (int, error) {
+	rw, err := NewRowWriter(format, w, columns, table, quote)
+	if err != nil {
+		return 0, err
+	}
+	for i, row := range rows {
+		if err := rw.WriteRow(row); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), rw.Close()
+}
+
+// ExportTable writes every row of a table matching whereClause to w in format,
+// in the sort order of pagination, and returns how many rows were written.
+// Rows are fetched exportPageSize at a time, so only one page is held in memory;
+// rows that sort alike are ordered by exportOrder so no page overlaps another.
+// Unsorted CSV exports from drivers implementing CopyOuter are streamed by the
+// driver instead; the row count is -1 then, as it isn't known.
+func ExportTable(driver Driver, database, table, whereClause string, pagination Pagination, format ExportFormat, w io.Writer) (int, error) {
+	if format == ExportFormatCSV && pagination.SortColumn == "" {
+		if _, ok := driver.(CopyOuter); ok {
+			return -1, ExportCSV(driver, database, table, whereClause, w)
+		}
+	}
+
+	order, err := exportOrder(driver, database, table)
+	if err != nil {
+		return 0, err
+	}
+
+	var rw RowWriter
+	rows := 0
+	for page := 1; ; page++ {
+		result, err := driver.GetTableDataWithFilterPaginated(database, table, whereClause, Pagination{
+			Page:        page,
+			PageSize:    exportPageSize,
+			SortColumn:  pagination.SortColumn,
+			SortOrder:   pagination.SortOrder,
+			TieBreakers: order,
+		})
+		if err != nil {
+			return rows, err
+		}
+		if len(result.Data) == 0 {
+			break
+		}
+
+		// Every page starts with the header row
+		if rw == nil {
+			rw, err = NewRowWriter(format, w, result.Data[0], QuoteTableName(driver, table), driver.QuoteIdentifier)
+			if err != nil {
+				return rows, err
+			}
+		}
+		for _, row := range result.Data[1:] {
+			if err := rw.WriteRow(row); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+
+		if page >= result.TotalPages {
+			break
+		}
+	}
+
+	if rw == nil {
+		return rows, nil
+	}
+	return rows, rw.Close()
+}
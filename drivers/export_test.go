@@ -0,0 +1,104 @@
+package drivers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestOrderByClause(t *testing.T) {
+	quote := (&SQLite{}).QuoteIdentifier
+	tests := []struct {
+		name       string
+		pagination Pagination
+		want       string
+	}{
+		{"none", Pagination{}, ""},
+		{"sort", Pagination{SortColumn: "name", SortOrder: "DESC"}, ` ORDER BY "name" DESC`},
+		{"sort defaults to ascending", Pagination{SortColumn: "name"}, ` ORDER BY "name" ASC`},
+		{"tie breakers", Pagination{TieBreakers: []string{"a", "b"}}, ` ORDER BY "a", "b"`},
+		{"sort and tie breakers", Pagination{SortColumn: "name", SortOrder: "DESC", TieBreakers: []string{"id", "name"}}, ` ORDER BY "name" DESC, "id"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orderByClause(tt.pagination, quote); got != tt.want {
+				t.Errorf("orderByClause() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportTablePagesInKeyOrder(t *testing.T) {
+	db := &SQLite{}
+	if err := db.Connect("file:" + filepath.Join(t.TempDir(), "export.db")); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.ExecuteStatement(`CREATE TABLE items (id INTEGER PRIMARY KEY, kind TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+	// Rows are inserted out of key order and share their sort column, so only
+	// the key keeps the pages apart
+	total := 2*exportPageSize + 10
+	for i := total; i >= 1; i-- {
+		if _, err := db.ExecuteStatement(`INSERT INTO items (id, kind) VALUES (?, 'same')`, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	rows, err := ExportTable(db, "", "items", "", Pagination{SortColumn: "kind"}, ExportFormatCSV, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows != total {
+		t.Fatalf("exported %d rows, want %d", rows, total)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, record := range records[1:] {
+		if want := strconv.Itoa(i + 1); record[0] != want {
+			t.Fatalf("row %d has id %s, want %s", i+1, record[0], want)
+		}
+	}
+}
+
+func TestExportOrder(t *testing.T) {
+	db := &SQLite{}
+	if err := db.Connect("file:" + filepath.Join(t.TempDir(), "order.db")); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for _, statement := range []string{
+		`CREATE TABLE keyed (a TEXT, b TEXT, c TEXT, PRIMARY KEY (b, a))`,
+		`CREATE TABLE unkeyed (a TEXT, doc JSON, b INTEGER)`,
+	} {
+		if _, err := db.ExecuteStatement(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		table string
+		want  []string
+	}{
+		{"keyed", []string{"a", "b"}},
+		{"unkeyed", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		got, err := exportOrder(db, "", tt.table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(tt.want) || got[0] != tt.want[0] || got[1] != tt.want[1] {
+			t.Errorf("exportOrder(%s) = %q, want %q", tt.table, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package drivers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ReferencedColumns returns the columns, out of columns, that a WHERE clause
+// mentions, in the order of columns. String literals are skipped and quoted
+// identifiers are unquoted; names are compared case-insensitively.
+func ReferencedColumns(whereClause string, columns []string) []string {
+	words := make(map[string]bool)
+	for i := 0; i < len(whereClause); i++ {
+		c := whereClause[i]
+		switch {
+		case c == '\'':
+			// String literal, '' is an escaped quote inside it
+			for i++; i < len(whereClause); i++ {
+				if whereClause[i] == '\'' {
+					if i+1 < len(whereClause) && whereClause[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			end := strings.IndexByte(whereClause[i+1:], closing)
+			if end < 0 {
+				end = len(whereClause) - i - 1
+			}
+			words[strings.ToLower(whereClause[i+1:i+1+end])] = true
+			i += end + 1
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i+1 < len(whereClause) && isIdentifierByte(whereClause[i+1]) {
+				i++
+			}
+			words[strings.ToLower(whereClause[start:i+1])] = true
+		}
+	}
+
+	var referenced []string
+	for _, col := range columns {
+		if words[strings.ToLower(col)] {
+			referenced = append(referenced, col)
+		}
+	}
+	return referenced
+}
+
+// isIdentifierByte reports whether c can continue an unquoted identifier
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || unicode.IsLetter(rune(c)) || c >= 0x80
+}
+
+// UnindexedColumns returns the columns that lead no index. A column that is
+// only the second or later column of an index can't be looked up through it.
+func UnindexedColumns(columns []string, indexes []IndexInfo) []string {
+	leading := make(map[string]bool, len(indexes))
+	for _, index := range indexes {
+		if len(index.Columns) > 0 {
+			leading[strings.ToLower(index.Columns[0])] = true
+		}
+	}
+
+	var unindexed []string
+	for _, col := range columns {
+		if !leading[strings.ToLower(col)] {
+			unindexed = append(unindexed, col)
+		}
+	}
+	return unindexed
+}
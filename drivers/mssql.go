@@ -0,0 +1,1050 @@
+package drivers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/msdsn"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/xo/dburl"
+)
+
+type MSSQL struct {
+	Connection *sql.DB
+	Provider   string
+	Schema     string          // Default schema of the login, usually dbo
+	schemas    map[string]bool // Schemas listed by GetTables, for parsing schema.table names
+	sshTunnel
+	tlsSettings
+}
+
+func init() {
+	Register(DriverTypeMSSQL, func() Driver { return &MSSQL{} })
+}
+
+// mssqlDefaultSchema is the schema assumed when the login's can't be read
+const mssqlDefaultSchema = "dbo"
+
+// MSSQLDatabase returns the database a SQL Server URL connects to: its database
+// parameter, or the last part of its path as in sqlserver://host/database
+func MSSQLDatabase(urlstr string) string {
+	u, err := url.Parse(urlstr)
+	if err != nil {
+		return ""
+	}
+	if database := u.Query().Get("database"); database != "" {
+		return database
+	}
+	// sqlserver://host/instance/database names the instance before the database
+	path := strings.Trim(u.Path, "/")
+	if i := strings.LastIndex(path, "/"); i != -1 {
+		path = path[i+1:]
+	}
+	return path
+}
+
+// mssqlTunnelDialer makes go-mssqldb dial the SSH tunnel without resolving the
+// server's host first, as the host may only resolve on the SSH server's side
+type mssqlTunnelDialer struct {
+	*Tunnel
+}
+
+// HostName makes mssqlTunnelDialer an mssql.HostDialer
+func (d mssqlTunnelDialer) HostName() string {
+	return d.remote
+}
+
+// open opens a connection to urlstr with the SSL settings of db, through its
+// SSH tunnel if it has one, returning the tunnel to close along with the
+// connection. The tunnel is dialed instead of rewriting the URL's host, so the
+// server certificate is still checked against the server's name.
+func (db *MSSQL) open(ctx context.Context, urlstr string) (*sql.DB, *Tunnel, error) {
+	u, err := dburl.Parse(urlstr)
+	if err != nil {
+		return nil, nil, err
+	}
+	config, err := msdsn.Parse(u.DSN)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := mssqlTLSConfig(&config, db.tlsConfig); err != nil {
+		return nil, nil, err
+	}
+
+	tunnel, err := db.openTunnel(ctx, urlstr, DefaultPort(DriverTypeMSSQL))
+	if err != nil {
+		return nil, nil, err
+	}
+	connector := mssql.NewConnectorConfig(config)
+	if tunnel != nil {
+		connector.Dialer = mssqlTunnelDialer{tunnel}
+	}
+	return sql.OpenDB(connector), tunnel, nil
+}
+
+func (db *MSSQL) Connect(urlstr string) (err error) {
+	db.SetProvider(DriverTypeMSSQL)
+
+	db.Connection, db.tunnel, err = db.open(context.Background(), urlstr)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			db.closeTunnel()
+		}
+	}()
+
+	err = db.Connection.Ping()
+	if err != nil {
+		return err
+	}
+
+	// Unqualified names resolve to the login's default schema
+	var schema sql.NullString
+	if err := db.Connection.QueryRow("SELECT SCHEMA_NAME()").Scan(&schema); err != nil || schema.String == "" {
+		schema.String = mssqlDefaultSchema
+	}
+	db.Schema = schema.String
+
+	return nil
+}
+
+func (db *MSSQL) SetProvider(provider string) {
+	db.Provider = provider
+}
+
+func (db *MSSQL) TestConnection(urlstr string) error {
+	return db.TestConnectionContext(context.Background(), urlstr)
+}
+
+// TestConnectionContext connects and pings the database, giving up when ctx is cancelled
+func (db *MSSQL) TestConnectionContext(ctx context.Context, urlstr string) error {
+	conn, tunnel, err := db.open(ctx, urlstr)
+	if err != nil {
+		return err
+	}
+	defer tunnel.Close()
+	defer conn.Close()
+
+	return conn.PingContext(ctx)
+}
+
+// ServerVersion opens a short-lived connection and returns the server's version string
+func (db *MSSQL) ServerVersion(urlstr string) (string, error) {
+	conn, tunnel, err := db.open(context.Background(), urlstr)
+	if err != nil {
+		return "", err
+	}
+	defer tunnel.Close()
+	defer conn.Close()
+
+	var version, edition string
+	query := "SELECT CAST(SERVERPROPERTY('ProductVersion') AS nvarchar(128)), CAST(SERVERPROPERTY('Edition') AS nvarchar(128))"
+	if err := conn.QueryRow(query).Scan(&version, &edition); err != nil {
+		return "", err
+	}
+	return "SQL Server " + version + " (" + edition + ")", nil
+}
+
+// QuoteIdentifier quotes an identifier for SQL Server (uses square brackets)
+func (db *MSSQL) QuoteIdentifier(identifier string) string {
+	return "[" + strings.ReplaceAll(identifier, "]", "]]") + "]"
+}
+
+// ContainsClause returns a LIKE clause matching column values containing value
+func (db *MSSQL) ContainsClause(column, value string) string {
+	// Brackets start a character range in SQL Server patterns, so they are escaped too
+	pattern := strings.ReplaceAll(EscapeLike(value), "[", `\[`)
+	pattern = strings.ReplaceAll(pattern, "'", "''")
+	return fmt.Sprintf(`%s LIKE N'%%%s%%' ESCAPE '\'`, db.QuoteIdentifier(column), pattern)
+}
+
+// Close closes the database connection and its SSH tunnel
+func (db *MSSQL) Close() error {
+	defer db.closeTunnel()
+	if db.Connection == nil {
+		return nil
+	}
+	return db.Connection.Close()
+}
+
+// GetTables returns the tables and views of the database the connection opened,
+// organized by schema. SQL Server catalogs only describe the current database,
+// so database isn't used.
+func (db *MSSQL) GetTables(database string) (map[string][]string, error) {
+	query := `SELECT TABLE_NAME, TABLE_SCHEMA FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_TYPE IN ('BASE TABLE', 'VIEW')
+		AND TABLE_SCHEMA NOT IN ('sys', 'INFORMATION_SCHEMA')
+		ORDER BY TABLE_SCHEMA, TABLE_NAME`
+	rows, err := db.Connection.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make(map[string][]string)
+	db.schemas = make(map[string]bool)
+	for rows.Next() {
+		var tableName, tableSchema string
+		if err := rows.Scan(&tableName, &tableSchema); err != nil {
+			return nil, err
+		}
+		tables[tableSchema] = append(tables[tableSchema], tableName)
+		db.schemas[tableSchema] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// GetViews returns the names of all views in the database, each as schema.view
+// so a table of another schema with the same name isn't taken for one
+func (db *MSSQL) GetViews(database string) ([]string, error) {
+	query := `SELECT TABLE_NAME, TABLE_SCHEMA FROM INFORMATION_SCHEMA.VIEWS
+		WHERE TABLE_SCHEMA NOT IN ('sys', 'INFORMATION_SCHEMA')
+		ORDER BY TABLE_NAME`
+	rows, err := db.Connection.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []string
+	for rows.Next() {
+		var viewName, viewSchema string
+		if err := rows.Scan(&viewName, &viewSchema); err != nil {
+			return nil, err
+		}
+		views = append(views, viewSchema+"."+viewName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// DefaultSchema returns the schema unqualified table names refer to
+func (db *MSSQL) DefaultSchema() string {
+	return db.Schema
+}
+
+// SplitTable splits a schema-qualified "schema.table" name into its schema and
+// table. Names without a known schema prefix are tables of the default schema.
+func (db *MSSQL) SplitTable(table string) (schema, name string) {
+	if i := strings.Index(table, "."); i > 0 && db.schemas[table[:i]] {
+		return table[:i], table[i+1:]
+	}
+	return db.Schema, table
+}
+
+// qualify returns the quoted schema.table of a table name as driver methods take
+// it, as used in SQL and by OBJECT_ID
+func (db *MSSQL) qualify(table string) string {
+	schema, name := db.SplitTable(table)
+	return db.QuoteIdentifier(schema) + "." + db.QuoteIdentifier(name)
+}
+
+// mssqlColumnType is the SQL expression for the type of column c of sys.columns
+// as it is declared, with its length, precision or scale
+const mssqlColumnType = `TYPE_NAME(c.user_type_id) + CASE
+		WHEN TYPE_NAME(c.user_type_id) IN ('varchar', 'char', 'varbinary', 'binary')
+			THEN '(' + CASE WHEN c.max_length = -1 THEN 'max' ELSE CAST(c.max_length AS varchar(10)) END + ')'
+		WHEN TYPE_NAME(c.user_type_id) IN ('nvarchar', 'nchar')
+			THEN '(' + CASE WHEN c.max_length = -1 THEN 'max' ELSE CAST(c.max_length / 2 AS varchar(10)) END + ')'
+		WHEN TYPE_NAME(c.user_type_id) IN ('decimal', 'numeric')
+			THEN '(' + CAST(c.precision AS varchar(10)) + ', ' + CAST(c.scale AS varchar(10)) + ')'
+		WHEN TYPE_NAME(c.user_type_id) IN ('datetime2', 'datetimeoffset', 'time')
+			THEN '(' + CAST(c.scale AS varchar(10)) + ')'
+		ELSE ''
+	END`
+
+// GetTableColumns returns basic column information for a table
+func (db *MSSQL) GetTableColumns(database, table string) ([][]string, error) {
+	columns, err := db.GetColumnInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]string, len(columns))
+	for i, col := range columns {
+		nullable, key := "NO", ""
+		if col.Nullable {
+			nullable = "YES"
+		}
+		if col.IsPrimaryKey {
+			key = "PRI"
+		}
+		result[i] = []string{col.Name, col.DataType, nullable, key, col.DefaultValue, col.Extra}
+	}
+	return result, nil
+}
+
+// readMSSQLRows reads every row of rows into a header row followed by the data
+// rows, with NULL values as "NULL"
+func readMSSQLRows(rows *sql.Rows) ([][]string, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, len(columnTypes))
+	for i, ct := range columnTypes {
+		columns[i] = ct.Name()
+	}
+
+	var data [][]string
+	// Add header row
+	data = append(data, columns)
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		valuePtrs := make([]any, len(columns))
+		for i := range columns {
+			valuePtrs[i] = &values[i]
+		}
+
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, err
+		}
+
+		row := make([]string, len(columns))
+		for i, val := range values {
+			row[i] = formatColumnValue(val, columnTypes[i].DatabaseTypeName())
+		}
+		data = append(data, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// formatColumnValue is formatSQLValue for a value of a column of databaseType.
+// go-mssqldb returns UNIQUEIDENTIFIER values as their raw bytes, which are
+// formatted as the GUID SQL Server shows instead.
+func formatColumnValue(val any, databaseType string) string {
+	if b, ok := val.([]byte); ok && databaseType == "UNIQUEIDENTIFIER" {
+		var guid mssql.UniqueIdentifier
+		if err := guid.Scan(b); err == nil {
+			return guid.String()
+		}
+	}
+	return formatSQLValue(val)
+}
+
+// GetTableData returns all data from a table with a limit
+func (db *MSSQL) GetTableData(database, table string) ([][]string, error) {
+	return db.GetTableDataWithFilter(database, table, "")
+}
+
+// GetTableDataWithFilter returns filtered table data
+func (db *MSSQL) GetTableDataWithFilter(database, table string, whereClause string) ([][]string, error) {
+	query := "SELECT TOP 1000 * FROM " + db.qualify(table)
+
+	// Use raw WHERE clause if provided
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+
+	logger.Debug("Executing filtered query", map[string]any{
+		"query": query,
+	})
+
+	rows, err := db.Connection.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return readMSSQLRows(rows)
+}
+
+// GetTableDataPaginated returns paginated table data
+func (db *MSSQL) GetTableDataPaginated(database, table string, pagination Pagination) (*PaginatedResult, error) {
+	return db.GetTableDataPaginatedContext(context.Background(), database, table, pagination)
+}
+
+// GetTableDataPaginatedContext is like GetTableDataPaginated but stops when ctx is cancelled
+func (db *MSSQL) GetTableDataPaginatedContext(ctx context.Context, database, table string, pagination Pagination) (*PaginatedResult, error) {
+	return db.paginate(ctx, database, table, "", pagination)
+}
+
+// GetTableDataWithFilterPaginated returns paginated and filtered table data
+func (db *MSSQL) GetTableDataWithFilterPaginated(database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	return db.GetTableDataWithFilterPaginatedContext(context.Background(), database, table, whereClause, pagination)
+}
+
+// GetTableDataWithFilterPaginatedContext is like GetTableDataWithFilterPaginated but stops when ctx is cancelled
+func (db *MSSQL) GetTableDataWithFilterPaginatedContext(ctx context.Context, database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	return db.paginate(ctx, database, table, whereClause, pagination)
+}
+
+// paginate reads a page of a table's rows, optionally filtered, with OFFSET ...
+// FETCH. SQL Server only pages ordered rows, so unsorted pages are ordered by
+// (SELECT NULL), leaving the order to the server as LIMIT does elsewhere.
+func (db *MSSQL) paginate(ctx context.Context, database, table, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	from := " FROM " + db.qualify(table)
+	if whereClause != "" {
+		from += " WHERE " + whereClause
+	}
+
+	// Get total count, from table statistics when an estimate was asked for and
+	// the rows aren't filtered
+	totalRows, totalApprox := -1, false
+	if pagination.EstimateTotal && whereClause == "" {
+		if estimate, err := db.GetEstimatedRowCount(database, table); err == nil && estimate >= 0 {
+			totalRows, totalApprox = int(estimate), true
+		}
+	}
+	if !totalApprox {
+		if err := db.Connection.QueryRowContext(ctx, "SELECT COUNT(*)"+from).Scan(&totalRows); err != nil {
+			return nil, err
+		}
+	}
+
+	// Calculate offset
+	offset := max((pagination.Page-1)*pagination.PageSize, 0)
+
+	query := "SELECT *" + from
+	if orderBy := orderByClause(pagination, db.QuoteIdentifier); orderBy != "" {
+		query += orderBy
+	} else {
+		query += " ORDER BY (SELECT NULL)"
+	}
+	query += " OFFSET " + strconv.Itoa(offset) + " ROWS FETCH NEXT " + strconv.Itoa(pagination.PageSize) + " ROWS ONLY"
+
+	logger.Debug("Executing paginated query", map[string]any{
+		"query":    query,
+		"page":     pagination.Page,
+		"pageSize": pagination.PageSize,
+		"offset":   offset,
+	})
+
+	rows, err := db.Connection.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data, err := readMSSQLRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate total pages
+	totalPages := totalRows / pagination.PageSize
+	if totalRows%pagination.PageSize > 0 {
+		totalPages++
+	}
+
+	return &PaginatedResult{
+		Data:        data,
+		TotalRows:   totalRows,
+		Page:        pagination.Page,
+		PageSize:    pagination.PageSize,
+		TotalPages:  totalPages,
+		TotalApprox: totalApprox,
+	}, nil
+}
+
+// GetTableStructure returns complete table structure including columns, indexes, relations, and triggers
+func (db *MSSQL) GetTableStructure(database, table string) (*TableStructure, error) {
+	columns, err := db.GetColumnInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := db.GetIndexInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	relations, err := db.GetRelationInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers, err := db.GetTriggerInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TableStructure{
+		Columns:   columns,
+		Indexes:   indexes,
+		Relations: relations,
+		Triggers:  triggers,
+	}, nil
+}
+
+// GetColumnInfo returns detailed column information for a table
+func (db *MSSQL) GetColumnInfo(database, table string) ([]ColumnInfo, error) {
+	query := `
+		SELECT
+			c.name,
+			` + mssqlColumnType + `,
+			c.is_nullable,
+			COALESCE(dc.definition, ''),
+			CASE WHEN EXISTS (
+				SELECT 1 FROM sys.indexes i
+				JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+				WHERE i.object_id = c.object_id AND i.is_primary_key = 1 AND ic.column_id = c.column_id
+			) THEN 1 ELSE 0 END,
+			CASE
+				WHEN c.is_computed = 1 THEN 'COMPUTED'
+				WHEN c.is_identity = 1 THEN 'IDENTITY(' + CAST(idc.seed_value AS varchar(40)) + ', ' + CAST(idc.increment_value AS varchar(40)) + ')'
+				WHEN TYPE_NAME(c.user_type_id) IN ('timestamp', 'rowversion') THEN 'ROWVERSION'
+				ELSE ''
+			END,
+			COALESCE(CAST(ep.value AS nvarchar(4000)), '')
+		FROM sys.columns c
+		LEFT JOIN sys.default_constraints dc ON dc.object_id = c.default_object_id
+		LEFT JOIN sys.identity_columns idc ON idc.object_id = c.object_id AND idc.column_id = c.column_id
+		LEFT JOIN sys.extended_properties ep
+			ON ep.class = 1 AND ep.major_id = c.object_id AND ep.minor_id = c.column_id AND ep.name = 'MS_Description'
+		WHERE c.object_id = OBJECT_ID(@p1)
+		ORDER BY c.column_id
+	`
+
+	rows, err := db.Connection.Query(query, db.qualify(table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	for rows.Next() {
+		var col ColumnInfo
+		var isPrimaryKey int
+
+		if err := rows.Scan(&col.Name, &col.DataType, &col.Nullable, &col.DefaultValue, &isPrimaryKey, &col.Extra, &col.Comment); err != nil {
+			return nil, err
+		}
+
+		col.IsPrimaryKey = isPrimaryKey == 1
+		// Identity columns only take values with IDENTITY_INSERT on
+		col.Generated = col.Extra != ""
+
+		columns = append(columns, col)
+	}
+
+	return columns, rows.Err()
+}
+
+// GetIndexInfo returns index information for a table
+func (db *MSSQL) GetIndexInfo(database, table string) ([]IndexInfo, error) {
+	query := `
+		SELECT i.name, i.is_unique, i.is_primary_key, i.type_desc, c.name
+		FROM sys.indexes i
+		JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE i.object_id = OBJECT_ID(@p1) AND i.name IS NOT NULL AND ic.is_included_column = 0
+		ORDER BY i.name, ic.key_ordinal
+	`
+
+	rows, err := db.Connection.Query(query, db.qualify(table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []IndexInfo
+	for rows.Next() {
+		var idx IndexInfo
+		var column string
+
+		if err := rows.Scan(&idx.Name, &idx.IsUnique, &idx.IsPrimary, &idx.Type, &column); err != nil {
+			return nil, err
+		}
+
+		// One row per indexed column, in key order
+		if n := len(indexes); n > 0 && indexes[n-1].Name == idx.Name {
+			indexes[n-1].Columns = append(indexes[n-1].Columns, column)
+			continue
+		}
+		idx.Columns = []string{column}
+		indexes = append(indexes, idx)
+	}
+
+	return indexes, rows.Err()
+}
+
+// GetRelationInfo returns foreign key relationships for a table
+func (db *MSSQL) GetRelationInfo(database, table string) ([]RelationInfo, error) {
+	query := `
+		SELECT
+			fk.name,
+			pc.name,
+			SCHEMA_NAME(rt.schema_id),
+			rt.name,
+			rc.name,
+			REPLACE(fk.update_referential_action_desc, '_', ' '),
+			REPLACE(fk.delete_referential_action_desc, '_', ' ')
+		FROM sys.foreign_keys fk
+		JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+		JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+		JOIN sys.tables rt ON rt.object_id = fkc.referenced_object_id
+		JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+		WHERE fk.parent_object_id = OBJECT_ID(@p1)
+		ORDER BY fk.name, fkc.constraint_column_id
+	`
+
+	rows, err := db.Connection.Query(query, db.qualify(table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var relations []RelationInfo
+	for rows.Next() {
+		var rel RelationInfo
+		var referencedSchema string
+
+		if err := rows.Scan(&rel.Name, &rel.Column, &referencedSchema, &rel.ReferencedTable, &rel.ReferencedColumn, &rel.OnUpdate, &rel.OnDelete); err != nil {
+			return nil, err
+		}
+		// Tables outside the default schema are named as they are opened
+		if referencedSchema != db.Schema {
+			rel.ReferencedTable = referencedSchema + "." + rel.ReferencedTable
+		}
+
+		relations = append(relations, rel)
+	}
+
+	return relations, rows.Err()
+}
+
+// GetTriggerInfo returns trigger information for a table, one row per event
+func (db *MSSQL) GetTriggerInfo(database, table string) ([]TriggerInfo, error) {
+	query := `
+		SELECT
+			t.name,
+			te.type_desc,
+			CASE WHEN t.is_instead_of_trigger = 1 THEN 'INSTEAD OF' ELSE 'AFTER' END,
+			COALESCE(OBJECT_DEFINITION(t.object_id), ''),
+			OBJECT_NAME(t.parent_id)
+		FROM sys.triggers t
+		JOIN sys.trigger_events te ON te.object_id = t.object_id
+		WHERE t.parent_id = OBJECT_ID(@p1)
+		ORDER BY t.name
+	`
+
+	rows, err := db.Connection.Query(query, db.qualify(table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []TriggerInfo
+	for rows.Next() {
+		var trig TriggerInfo
+
+		if err := rows.Scan(&trig.Name, &trig.Event, &trig.Timing, &trig.Statement, &trig.Table); err != nil {
+			return nil, err
+		}
+
+		triggers = append(triggers, trig)
+	}
+
+	return triggers, rows.Err()
+}
+
+// GetDependencies returns the views, foreign keys and triggers that depend on a table
+func (db *MSSQL) GetDependencies(database, table string) ([]DependencyInfo, error) {
+	query := `
+		SELECT DISTINCT v.name, 'VIEW', v.name, ''
+		FROM sys.sql_expression_dependencies d
+		JOIN sys.views v ON v.object_id = d.referencing_id
+		WHERE d.referenced_id = OBJECT_ID(@p1)
+		UNION ALL
+		SELECT fk.name, 'FOREIGN KEY', OBJECT_NAME(fk.parent_object_id),
+			STUFF((
+				SELECT ', ' + c.name
+				FROM sys.foreign_key_columns fkc
+				JOIN sys.columns c ON c.object_id = fkc.parent_object_id AND c.column_id = fkc.parent_column_id
+				WHERE fkc.constraint_object_id = fk.object_id
+				ORDER BY fkc.constraint_column_id
+				FOR XML PATH('')
+			), 1, 2, '')
+		FROM sys.foreign_keys fk
+		WHERE fk.referenced_object_id = OBJECT_ID(@p1)
+		UNION ALL
+		SELECT t.name, 'TRIGGER', OBJECT_NAME(t.parent_id), ''
+		FROM sys.triggers t
+		WHERE t.parent_id = OBJECT_ID(@p1)
+		ORDER BY 2, 1
+	`
+
+	rows, err := db.Connection.Query(query, db.qualify(table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deps []DependencyInfo
+	for rows.Next() {
+		var dep DependencyInfo
+
+		if err := rows.Scan(&dep.Name, &dep.Type, &dep.Table, &dep.Detail); err != nil {
+			return nil, err
+		}
+
+		deps = append(deps, dep)
+	}
+
+	return deps, rows.Err()
+}
+
+// GetTableInfo returns row count, column count and total size for a table.
+// The row count comes from the partition statistics when the table has rows.
+func (db *MSSQL) GetTableInfo(database, table string) (*TableInfo, error) {
+	query := `
+		SELECT
+			(SELECT COALESCE(SUM(rows), 0) FROM sys.partitions
+				WHERE object_id = OBJECT_ID(@p1) AND index_id IN (0, 1)),
+			(SELECT COALESCE(SUM(a.total_pages), 0) * 8192 FROM sys.partitions p
+				JOIN sys.allocation_units a ON a.container_id = p.partition_id
+				WHERE p.object_id = OBJECT_ID(@p1))
+	`
+
+	info := &TableInfo{RowCountApprox: true}
+	if err := db.Connection.QueryRow(query, db.qualify(table)).Scan(&info.RowCount, &info.SizeBytes); err != nil {
+		return nil, err
+	}
+
+	// Views have no partitions to read a count from
+	if info.RowCount <= 0 {
+		count, err := db.GetRowCount(database, table)
+		if err != nil {
+			return nil, err
+		}
+		info.RowCount = count
+		info.RowCountApprox = false
+	}
+
+	columns, err := db.GetColumnInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+	info.ColumnCount = len(columns)
+
+	return info, nil
+}
+
+// GetCreateStatements builds a CREATE TABLE from the catalog, SQL Server has no
+// SHOW CREATE TABLE. Keys, checks and foreign keys are written inside the table;
+// indexes that don't back a key follow as CREATE INDEX statements.
+func (db *MSSQL) GetCreateStatements(database, table string) ([]string, error) {
+	qualified := db.qualify(table)
+
+	columnQuery := `
+		SELECT
+			c.name,
+			` + mssqlColumnType + `,
+			c.is_nullable,
+			COALESCE('IDENTITY(' + CAST(idc.seed_value AS varchar(40)) + ', ' + CAST(idc.increment_value AS varchar(40)) + ')', ''),
+			COALESCE(cc.definition, ''),
+			COALESCE(dc.definition, '')
+		FROM sys.columns c
+		LEFT JOIN sys.identity_columns idc ON idc.object_id = c.object_id AND idc.column_id = c.column_id
+		LEFT JOIN sys.computed_columns cc ON cc.object_id = c.object_id AND cc.column_id = c.column_id
+		LEFT JOIN sys.default_constraints dc ON dc.object_id = c.default_object_id
+		WHERE c.object_id = OBJECT_ID(@p1)
+		ORDER BY c.column_id
+	`
+	rows, err := db.Connection.Query(columnQuery, qualified)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []string
+	for rows.Next() {
+		var name, dataType, identity, computed, defaultValue string
+		var nullable bool
+		if err := rows.Scan(&name, &dataType, &nullable, &identity, &computed, &defaultValue); err != nil {
+			return nil, err
+		}
+		if computed != "" {
+			lines = append(lines, "  "+db.QuoteIdentifier(name)+" AS "+computed)
+			continue
+		}
+		line := "  " + db.QuoteIdentifier(name) + " " + dataType
+		if identity != "" {
+			line += " " + identity
+		}
+		if nullable {
+			line += " NULL"
+		} else {
+			line += " NOT NULL"
+		}
+		if defaultValue != "" {
+			line += " DEFAULT " + defaultValue
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Primary key first, then unique keys
+	keyQuery := `
+		SELECT kc.name, kc.type, i.type_desc, c.name, ic.is_descending_key
+		FROM sys.key_constraints kc
+		JOIN sys.indexes i ON i.object_id = kc.parent_object_id AND i.index_id = kc.unique_index_id
+		JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE kc.parent_object_id = OBJECT_ID(@p1)
+		ORDER BY kc.type, kc.name, ic.key_ordinal
+	`
+	keyRows, err := db.Connection.Query(keyQuery, qualified)
+	if err != nil {
+		return nil, err
+	}
+	defer keyRows.Close()
+
+	var keyName string
+	var keyColumns []string
+	var keyPrefix string
+	flushKey := func() {
+		if keyName != "" {
+			lines = append(lines, keyPrefix+" ("+strings.Join(keyColumns, ", ")+")")
+		}
+	}
+	for keyRows.Next() {
+		var name, keyType, indexType, column string
+		var descending bool
+		if err := keyRows.Scan(&name, &keyType, &indexType, &column, &descending); err != nil {
+			return nil, err
+		}
+		if name != keyName {
+			flushKey()
+			keyName, keyColumns = name, nil
+			kind := "UNIQUE"
+			if keyType == "PK" {
+				kind = "PRIMARY KEY"
+			}
+			keyPrefix = "  CONSTRAINT " + db.QuoteIdentifier(name) + " " + kind + " " + indexType
+		}
+		column = db.QuoteIdentifier(column)
+		if descending {
+			column += " DESC"
+		}
+		keyColumns = append(keyColumns, column)
+	}
+	if err := keyRows.Err(); err != nil {
+		return nil, err
+	}
+	flushKey()
+
+	checkQuery := `
+		SELECT name, definition FROM sys.check_constraints
+		WHERE parent_object_id = OBJECT_ID(@p1)
+		ORDER BY name
+	`
+	checkRows, err := db.Connection.Query(checkQuery, qualified)
+	if err != nil {
+		return nil, err
+	}
+	defer checkRows.Close()
+
+	for checkRows.Next() {
+		var name, definition string
+		if err := checkRows.Scan(&name, &definition); err != nil {
+			return nil, err
+		}
+		lines = append(lines, "  CONSTRAINT "+db.QuoteIdentifier(name)+" CHECK "+definition)
+	}
+	if err := checkRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Foreign keys last, one line per constraint over all its columns
+	relations, err := db.GetRelationInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(relations); {
+		rel := relations[i]
+		var columns, referenced []string
+		for ; i < len(relations) && relations[i].Name == rel.Name; i++ {
+			columns = append(columns, db.QuoteIdentifier(relations[i].Column))
+			referenced = append(referenced, db.QuoteIdentifier(relations[i].ReferencedColumn))
+		}
+		line := "  CONSTRAINT " + db.QuoteIdentifier(rel.Name) +
+			" FOREIGN KEY (" + strings.Join(columns, ", ") + ")" +
+			" REFERENCES " + db.qualify(rel.ReferencedTable) + " (" + strings.Join(referenced, ", ") + ")"
+		if rel.OnDelete != "NO ACTION" {
+			line += " ON DELETE " + rel.OnDelete
+		}
+		if rel.OnUpdate != "NO ACTION" {
+			line += " ON UPDATE " + rel.OnUpdate
+		}
+		lines = append(lines, line)
+	}
+
+	statements := []string{"CREATE TABLE " + qualified + " (\n" + strings.Join(lines, ",\n") + "\n)"}
+
+	// Rowstore indexes that don't back a key
+	indexQuery := `
+		SELECT i.name, i.is_unique, i.type_desc, COALESCE(i.filter_definition, ''),
+			c.name, ic.is_descending_key, ic.is_included_column
+		FROM sys.indexes i
+		JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+		JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+		WHERE i.object_id = OBJECT_ID(@p1) AND i.type IN (1, 2)
+			AND i.is_primary_key = 0 AND i.is_unique_constraint = 0
+		ORDER BY i.name, ic.is_included_column, ic.key_ordinal, ic.index_column_id
+	`
+	indexRows, err := db.Connection.Query(indexQuery, qualified)
+	if err != nil {
+		return nil, err
+	}
+	defer indexRows.Close()
+
+	type index struct {
+		name, definition, filter string
+		columns, included        []string
+	}
+	var indexes []*index
+	for indexRows.Next() {
+		var name, indexType, filter, column string
+		var unique, descending, included bool
+		if err := indexRows.Scan(&name, &unique, &indexType, &filter, &column, &descending, &included); err != nil {
+			return nil, err
+		}
+		if len(indexes) == 0 || indexes[len(indexes)-1].name != name {
+			definition := "CREATE "
+			if unique {
+				definition += "UNIQUE "
+			}
+			definition += indexType + " INDEX " + db.QuoteIdentifier(name) + " ON " + qualified
+			indexes = append(indexes, &index{name: name, definition: definition, filter: filter})
+		}
+		idx := indexes[len(indexes)-1]
+		column = db.QuoteIdentifier(column)
+		switch {
+		case included:
+			idx.included = append(idx.included, column)
+		case descending:
+			idx.columns = append(idx.columns, column+" DESC")
+		default:
+			idx.columns = append(idx.columns, column)
+		}
+	}
+	if err := indexRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, idx := range indexes {
+		statement := idx.definition + " (" + strings.Join(idx.columns, ", ") + ")"
+		if len(idx.included) > 0 {
+			statement += " INCLUDE (" + strings.Join(idx.included, ", ") + ")"
+		}
+		if idx.filter != "" {
+			statement += " WHERE " + idx.filter
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
+
+// GetEstimatedRowCount returns the row count SQL Server keeps for the table's
+// heap or clustered index, or -1 for views
+func (db *MSSQL) GetEstimatedRowCount(database, table string) (int64, error) {
+	query := `
+		SELECT SUM(rows) FROM sys.partitions
+		WHERE object_id = OBJECT_ID(@p1) AND index_id IN (0, 1)
+	`
+
+	var estimate sql.NullInt64
+	if err := db.Connection.QueryRow(query, db.qualify(table)).Scan(&estimate); err != nil {
+		return -1, err
+	}
+	if !estimate.Valid {
+		return -1, nil
+	}
+	return estimate.Int64, nil
+}
+
+// GetRowCount returns the exact number of rows in a table
+func (db *MSSQL) GetRowCount(database, table string) (int64, error) {
+	return db.GetRowCountWithFilter(database, table, "")
+}
+
+// GetRowCountWithFilter counts the rows matching whereClause with COUNT_BIG(*)
+func (db *MSSQL) GetRowCountWithFilter(database, table, whereClause string) (int64, error) {
+	query := "SELECT COUNT_BIG(*) FROM " + db.qualify(table)
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+	var count int64
+	if err := db.Connection.QueryRow(query).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ExecuteQuery executes a raw SQL query and returns the results
+func (db *MSSQL) ExecuteQuery(query string) ([][]string, error) {
+	return db.ExecuteQueryContext(context.Background(), query)
+}
+
+// ExecuteQueryContext executes a raw SQL query and returns the results, stopping when ctx is cancelled
+func (db *MSSQL) ExecuteQueryContext(ctx context.Context, query string) ([][]string, error) {
+	logger.Debug("Executing raw query", map[string]any{
+		"query": query,
+	})
+
+	rows, err := db.Connection.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return readMSSQLRows(rows)
+}
+
+// ExecuteScript executes each statement of a script and returns every statement's result
+func (db *MSSQL) ExecuteScript(script string) ([]StatementResult, error) {
+	return db.ExecuteScriptContext(context.Background(), script)
+}
+
+// ExecuteScriptContext is ExecuteScript stopping at the running statement when ctx is cancelled
+func (db *MSSQL) ExecuteScriptContext(ctx context.Context, script string) ([]StatementResult, error) {
+	return executeScript(ctx, db.Connection, script, scriptOptions{})
+}
+
+// ExecuteInTransaction runs the statements in one transaction
+func (db *MSSQL) ExecuteInTransaction(statements ...string) error {
+	return executeInTransaction(db.Connection, statements)
+}
+
+// ExecuteStatement runs a statement with args bound to its placeholders
+func (db *MSSQL) ExecuteStatement(query string, args ...any) (int64, error) {
+	return execWithArgs(db.Connection, query, args)
+}
+
+// Placeholder returns @pn, the name go-mssqldb gives positional arguments
+func (db *MSSQL) Placeholder(n int) string {
+	return "@p" + strconv.Itoa(n)
+}
+
+// TruncateStatement returns a TRUNCATE TABLE for the table
+func (db *MSSQL) TruncateStatement(table string) string {
+	return "TRUNCATE TABLE " + db.qualify(table)
+}
@@ -0,0 +1,891 @@
+//go:build cgo
+
+package drivers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/godror/godror"
+	"github.com/sheenazien8/sq/logger"
+)
+
+// Oracle connects through godror, which needs cgo and the Oracle Instant Client
+// libraries at run time. Builds without cgo leave the driver out.
+type Oracle struct {
+	Connection *sql.DB
+	Provider   string
+	Schema     string          // Schema of the session, the user's own unless changed
+	schemas    map[string]bool // Schemas listed by GetTables, for parsing schema.table names
+	sshTunnel
+}
+
+func init() {
+	Register(DriverTypeOracle, func() Driver { return &Oracle{} })
+}
+
+// open opens a connection to urlstr, through the SSH tunnel of db if it has one,
+// returning the tunnel to close along with the connection. The Oracle client
+// dials the server itself, so the URL's host is rewritten to the tunnel's end.
+func (db *Oracle) open(ctx context.Context, urlstr string) (*sql.DB, *Tunnel, error) {
+	urlstr, tunnel, err := db.tunnelURL(ctx, urlstr, DefaultPort(DriverTypeOracle))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// godror only reads URLs of the oracle scheme, not the aliases the URL may use
+	u, err := url.Parse(urlstr)
+	if err != nil {
+		tunnel.Close()
+		return nil, nil, err
+	}
+	u.Scheme = "oracle"
+	params, err := godror.ParseDSN(u.String())
+	if err != nil {
+		tunnel.Close()
+		return nil, nil, err
+	}
+	return sql.OpenDB(godror.NewConnector(params)), tunnel, nil
+}
+
+func (db *Oracle) Connect(urlstr string) (err error) {
+	db.SetProvider(DriverTypeOracle)
+
+	db.Connection, db.tunnel, err = db.open(context.Background(), urlstr)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			db.closeTunnel()
+		}
+	}()
+
+	err = db.Connection.Ping()
+	if err != nil {
+		return err
+	}
+
+	// Unqualified names resolve to the session's current schema
+	if err := db.Connection.QueryRow("SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL").Scan(&db.Schema); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *Oracle) SetProvider(provider string) {
+	db.Provider = provider
+}
+
+func (db *Oracle) TestConnection(urlstr string) error {
+	return db.TestConnectionContext(context.Background(), urlstr)
+}
+
+// TestConnectionContext connects and pings the database, giving up when ctx is cancelled
+func (db *Oracle) TestConnectionContext(ctx context.Context, urlstr string) error {
+	conn, tunnel, err := db.open(ctx, urlstr)
+	if err != nil {
+		return err
+	}
+	defer tunnel.Close()
+	defer conn.Close()
+
+	return conn.PingContext(ctx)
+}
+
+// ServerVersion opens a short-lived connection and returns the server's banner
+func (db *Oracle) ServerVersion(urlstr string) (string, error) {
+	ctx := context.Background()
+	conn, tunnel, err := db.open(ctx, urlstr)
+	if err != nil {
+		return "", err
+	}
+	defer tunnel.Close()
+	defer conn.Close()
+
+	version, err := godror.ServerVersion(ctx, conn)
+	if err != nil {
+		return "", err
+	}
+	if version.ServerRelease != "" {
+		return version.ServerRelease, nil
+	}
+	return "Oracle " + version.String(), nil
+}
+
+// QuoteIdentifier quotes an identifier for Oracle (uses double quotes). Quoted
+// names are case sensitive, and the catalog keeps unquoted names upper-cased.
+func (db *Oracle) QuoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// ContainsClause returns a LIKE clause matching column values containing value
+func (db *Oracle) ContainsClause(column, value string) string {
+	pattern := strings.ReplaceAll(EscapeLike(value), "'", "''")
+	return fmt.Sprintf(`%s LIKE '%%%s%%' ESCAPE '\'`, db.QuoteIdentifier(column), pattern)
+}
+
+// Close closes the database connection and its SSH tunnel
+func (db *Oracle) Close() error {
+	defer db.closeTunnel()
+	if db.Connection == nil {
+		return nil
+	}
+	return db.Connection.Close()
+}
+
+// oracleSchemas is the SQL condition keeping the objects of OWNER to those of
+// users, leaving out the schemas Oracle maintains unless the session uses one
+const oracleSchemas = `(OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')
+		OR OWNER IN (SELECT USERNAME FROM ALL_USERS WHERE ORACLE_MAINTAINED = 'N'))`
+
+// GetTables returns the tables and views the user can see, organized by schema.
+// An Oracle service is a single database, so database isn't used.
+func (db *Oracle) GetTables(database string) (map[string][]string, error) {
+	query := `SELECT OWNER, TABLE_NAME FROM ALL_TABLES
+			WHERE ` + oracleSchemas + ` AND NESTED = 'NO' AND SECONDARY = 'N' AND DROPPED = 'NO'
+				AND (IOT_TYPE IS NULL OR IOT_TYPE = 'IOT')
+		UNION ALL
+		SELECT OWNER, VIEW_NAME FROM ALL_VIEWS WHERE ` + oracleSchemas + `
+		ORDER BY 1, 2`
+	rows, err := db.Connection.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make(map[string][]string)
+	db.schemas = make(map[string]bool)
+	for rows.Next() {
+		var owner, tableName string
+		if err := rows.Scan(&owner, &tableName); err != nil {
+			return nil, err
+		}
+		tables[owner] = append(tables[owner], tableName)
+		db.schemas[owner] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// GetViews returns the names of all views the user can see, each as schema.view
+// so a table of another schema with the same name isn't taken for one
+func (db *Oracle) GetViews(database string) ([]string, error) {
+	query := `SELECT OWNER, VIEW_NAME FROM ALL_VIEWS WHERE ` + oracleSchemas + ` ORDER BY VIEW_NAME`
+	rows, err := db.Connection.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []string
+	for rows.Next() {
+		var owner, viewName string
+		if err := rows.Scan(&owner, &viewName); err != nil {
+			return nil, err
+		}
+		views = append(views, owner+"."+viewName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// DefaultSchema returns the schema unqualified table names refer to
+func (db *Oracle) DefaultSchema() string {
+	return db.Schema
+}
+
+// SplitTable splits a schema-qualified "schema.table" name into its schema and
+// table. Names without a known schema prefix are tables of the default schema.
+func (db *Oracle) SplitTable(table string) (schema, name string) {
+	if i := strings.Index(table, "."); i > 0 && db.schemas[table[:i]] {
+		return table[:i], table[i+1:]
+	}
+	return db.Schema, table
+}
+
+// tableName returns the name driver methods take for table of owner
+func (db *Oracle) tableName(owner, table string) string {
+	if owner != db.Schema {
+		return owner + "." + table
+	}
+	return table
+}
+
+// qualify returns the quoted schema.table of a table name as driver methods take it
+func (db *Oracle) qualify(table string) string {
+	schema, name := db.SplitTable(table)
+	return db.QuoteIdentifier(schema) + "." + db.QuoteIdentifier(name)
+}
+
+// tableArgs returns the owner and name of a table as the :owner and :name binds
+// of the catalog queries
+func (db *Oracle) tableArgs(table string) []any {
+	schema, name := db.SplitTable(table)
+	return []any{sql.Named("owner", schema), sql.Named("name", name)}
+}
+
+// oracleColumnType is the SQL expression for the type of column c of
+// ALL_TAB_COLS as it is declared, with its length, precision or scale
+const oracleColumnType = `CASE
+			WHEN c.DATA_TYPE IN ('VARCHAR2', 'CHAR') AND c.CHAR_USED = 'C'
+				THEN c.DATA_TYPE || '(' || c.CHAR_LENGTH || ' CHAR)'
+			WHEN c.DATA_TYPE IN ('VARCHAR2', 'CHAR', 'NVARCHAR2', 'NCHAR')
+				THEN c.DATA_TYPE || '(' || c.CHAR_LENGTH || ')'
+			WHEN c.DATA_TYPE = 'RAW'
+				THEN 'RAW(' || c.DATA_LENGTH || ')'
+			WHEN c.DATA_TYPE = 'NUMBER' AND c.DATA_PRECISION IS NOT NULL
+				THEN 'NUMBER(' || c.DATA_PRECISION || CASE WHEN c.DATA_SCALE > 0 THEN ', ' || c.DATA_SCALE END || ')'
+			WHEN c.DATA_TYPE = 'NUMBER' AND c.DATA_SCALE = 0
+				THEN 'INTEGER'
+			WHEN c.DATA_TYPE = 'FLOAT'
+				THEN 'FLOAT(' || c.DATA_PRECISION || ')'
+			ELSE c.DATA_TYPE
+		END`
+
+// GetTableColumns returns basic column information for a table
+func (db *Oracle) GetTableColumns(database, table string) ([][]string, error) {
+	columns, err := db.GetColumnInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]string, len(columns))
+	for i, col := range columns {
+		nullable, key := "NO", ""
+		if col.Nullable {
+			nullable = "YES"
+		}
+		if col.IsPrimaryKey {
+			key = "PRI"
+		}
+		result[i] = []string{col.Name, col.DataType, nullable, key, col.DefaultValue, col.Extra}
+	}
+	return result, nil
+}
+
+// readOracleRows reads every row of rows into a header row followed by the data
+// rows, with NULL values as "NULL"
+func readOracleRows(rows *sql.Rows) ([][]string, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var data [][]string
+	// Add header row
+	data = append(data, columns)
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		valuePtrs := make([]any, len(columns))
+		for i := range columns {
+			valuePtrs[i] = &values[i]
+		}
+
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, err
+		}
+
+		row := make([]string, len(columns))
+		for i, val := range values {
+			row[i] = formatSQLValue(val)
+		}
+		data = append(data, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// GetTableData returns all data from a table with a limit
+func (db *Oracle) GetTableData(database, table string) ([][]string, error) {
+	return db.GetTableDataWithFilter(database, table, "")
+}
+
+// GetTableDataWithFilter returns filtered table data
+func (db *Oracle) GetTableDataWithFilter(database, table string, whereClause string) ([][]string, error) {
+	query := "SELECT * FROM " + db.qualify(table)
+
+	// Use raw WHERE clause if provided
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+	query += " FETCH FIRST 1000 ROWS ONLY"
+
+	logger.Debug("Executing filtered query", map[string]any{
+		"query": query,
+	})
+
+	rows, err := db.Connection.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return readOracleRows(rows)
+}
+
+// GetTableDataPaginated returns paginated table data
+func (db *Oracle) GetTableDataPaginated(database, table string, pagination Pagination) (*PaginatedResult, error) {
+	return db.GetTableDataPaginatedContext(context.Background(), database, table, pagination)
+}
+
+// GetTableDataPaginatedContext is like GetTableDataPaginated but stops when ctx is cancelled
+func (db *Oracle) GetTableDataPaginatedContext(ctx context.Context, database, table string, pagination Pagination) (*PaginatedResult, error) {
+	return db.paginate(ctx, database, table, "", pagination)
+}
+
+// GetTableDataWithFilterPaginated returns paginated and filtered table data
+func (db *Oracle) GetTableDataWithFilterPaginated(database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	return db.GetTableDataWithFilterPaginatedContext(context.Background(), database, table, whereClause, pagination)
+}
+
+// GetTableDataWithFilterPaginatedContext is like GetTableDataWithFilterPaginated but stops when ctx is cancelled
+func (db *Oracle) GetTableDataWithFilterPaginatedContext(ctx context.Context, database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	return db.paginate(ctx, database, table, whereClause, pagination)
+}
+
+// paginate reads a page of a table's rows, optionally filtered, with OFFSET ...
+// FETCH, which Oracle has had since 12c
+func (db *Oracle) paginate(ctx context.Context, database, table, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	from := " FROM " + db.qualify(table)
+	if whereClause != "" {
+		from += " WHERE " + whereClause
+	}
+
+	// Get total count, from table statistics when an estimate was asked for and
+	// the rows aren't filtered
+	totalRows, totalApprox := -1, false
+	if pagination.EstimateTotal && whereClause == "" {
+		if estimate, err := db.GetEstimatedRowCount(database, table); err == nil && estimate >= 0 {
+			totalRows, totalApprox = int(estimate), true
+		}
+	}
+	if !totalApprox {
+		if err := db.Connection.QueryRowContext(ctx, "SELECT COUNT(*)"+from).Scan(&totalRows); err != nil {
+			return nil, err
+		}
+	}
+
+	// Calculate offset
+	offset := max((pagination.Page-1)*pagination.PageSize, 0)
+
+	query := "SELECT *" + from + orderByClause(pagination, db.QuoteIdentifier)
+	query += " OFFSET " + strconv.Itoa(offset) + " ROWS FETCH NEXT " + strconv.Itoa(pagination.PageSize) + " ROWS ONLY"
+
+	logger.Debug("Executing paginated query", map[string]any{
+		"query":    query,
+		"page":     pagination.Page,
+		"pageSize": pagination.PageSize,
+		"offset":   offset,
+	})
+
+	rows, err := db.Connection.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data, err := readOracleRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate total pages
+	totalPages := totalRows / pagination.PageSize
+	if totalRows%pagination.PageSize > 0 {
+		totalPages++
+	}
+
+	return &PaginatedResult{
+		Data:        data,
+		TotalRows:   totalRows,
+		Page:        pagination.Page,
+		PageSize:    pagination.PageSize,
+		TotalPages:  totalPages,
+		TotalApprox: totalApprox,
+	}, nil
+}
+
+// GetTableStructure returns complete table structure including columns, indexes, relations, and triggers
+func (db *Oracle) GetTableStructure(database, table string) (*TableStructure, error) {
+	columns, err := db.GetColumnInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := db.GetIndexInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	relations, err := db.GetRelationInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers, err := db.GetTriggerInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TableStructure{
+		Columns:   columns,
+		Indexes:   indexes,
+		Relations: relations,
+		Triggers:  triggers,
+	}, nil
+}
+
+// GetColumnInfo returns detailed column information for a table. Columns are
+// read from ALL_TAB_COLS, the ALL_TAB_COLUMNS view with virtual columns flagged,
+// and primary keys from ALL_CONSTRAINTS.
+func (db *Oracle) GetColumnInfo(database, table string) ([]ColumnInfo, error) {
+	query := `
+		SELECT
+			c.COLUMN_NAME,
+			` + oracleColumnType + `,
+			c.NULLABLE,
+			c.DATA_DEFAULT,
+			CASE WHEN EXISTS (
+				SELECT 1 FROM ALL_CONSTRAINTS k
+				JOIN ALL_CONS_COLUMNS kc ON kc.OWNER = k.OWNER AND kc.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+				WHERE k.OWNER = c.OWNER AND k.TABLE_NAME = c.TABLE_NAME
+					AND k.CONSTRAINT_TYPE = 'P' AND kc.COLUMN_NAME = c.COLUMN_NAME
+			) THEN 1 ELSE 0 END,
+			CASE
+				WHEN ic.GENERATION_TYPE IS NOT NULL THEN 'GENERATED ' || ic.GENERATION_TYPE || ' AS IDENTITY'
+				WHEN c.VIRTUAL_COLUMN = 'YES' THEN 'VIRTUAL'
+			END,
+			cm.COMMENTS
+		FROM ALL_TAB_COLS c
+		LEFT JOIN ALL_TAB_IDENTITY_COLS ic
+			ON ic.OWNER = c.OWNER AND ic.TABLE_NAME = c.TABLE_NAME AND ic.COLUMN_NAME = c.COLUMN_NAME
+		LEFT JOIN ALL_COL_COMMENTS cm
+			ON cm.OWNER = c.OWNER AND cm.TABLE_NAME = c.TABLE_NAME AND cm.COLUMN_NAME = c.COLUMN_NAME
+		WHERE c.OWNER = :owner AND c.TABLE_NAME = :name AND c.HIDDEN_COLUMN = 'NO'
+		ORDER BY c.COLUMN_ID
+	`
+
+	rows, err := db.Connection.Query(query, db.tableArgs(table)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []ColumnInfo
+	for rows.Next() {
+		var col ColumnInfo
+		var nullable string
+		var defaultValue, extra, comment sql.NullString
+		var isPrimaryKey int
+
+		if err := rows.Scan(&col.Name, &col.DataType, &nullable, &defaultValue, &isPrimaryKey, &extra, &comment); err != nil {
+			return nil, err
+		}
+
+		col.Nullable = nullable == "Y"
+		// Defaults are kept as written, often with a trailing newline
+		col.DefaultValue = strings.TrimSpace(defaultValue.String)
+		col.IsPrimaryKey = isPrimaryKey == 1
+		col.Extra = extra.String
+		col.Comment = comment.String
+		// Identities generated BY DEFAULT take the values they are given
+		col.Generated = col.Extra == "VIRTUAL" || col.Extra == "GENERATED ALWAYS AS IDENTITY"
+
+		columns = append(columns, col)
+	}
+
+	return columns, rows.Err()
+}
+
+// GetIndexInfo returns index information for a table
+func (db *Oracle) GetIndexInfo(database, table string) ([]IndexInfo, error) {
+	query := `
+		SELECT
+			i.INDEX_NAME,
+			i.UNIQUENESS,
+			CASE WHEN EXISTS (
+				SELECT 1 FROM ALL_CONSTRAINTS k
+				WHERE k.OWNER = i.TABLE_OWNER AND k.TABLE_NAME = i.TABLE_NAME
+					AND k.CONSTRAINT_TYPE = 'P' AND k.INDEX_NAME = i.INDEX_NAME
+			) THEN 1 ELSE 0 END,
+			i.INDEX_TYPE,
+			ic.COLUMN_NAME
+		FROM ALL_INDEXES i
+		JOIN ALL_IND_COLUMNS ic ON ic.INDEX_OWNER = i.OWNER AND ic.INDEX_NAME = i.INDEX_NAME
+		WHERE i.TABLE_OWNER = :owner AND i.TABLE_NAME = :name AND i.INDEX_TYPE <> 'LOB'
+		ORDER BY i.INDEX_NAME, ic.COLUMN_POSITION
+	`
+
+	rows, err := db.Connection.Query(query, db.tableArgs(table)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []IndexInfo
+	for rows.Next() {
+		var idx IndexInfo
+		var uniqueness, column string
+		var isPrimary int
+
+		if err := rows.Scan(&idx.Name, &uniqueness, &isPrimary, &idx.Type, &column); err != nil {
+			return nil, err
+		}
+
+		// One row per indexed column, in key order
+		if n := len(indexes); n > 0 && indexes[n-1].Name == idx.Name {
+			indexes[n-1].Columns = append(indexes[n-1].Columns, column)
+			continue
+		}
+		idx.IsUnique = uniqueness == "UNIQUE"
+		idx.IsPrimary = isPrimary == 1
+		idx.Columns = []string{column}
+		indexes = append(indexes, idx)
+	}
+
+	return indexes, rows.Err()
+}
+
+// GetRelationInfo returns foreign key relationships for a table. Oracle foreign
+// keys have no ON UPDATE action, so it is always NO ACTION.
+func (db *Oracle) GetRelationInfo(database, table string) ([]RelationInfo, error) {
+	query := `
+		SELECT
+			c.CONSTRAINT_NAME,
+			cc.COLUMN_NAME,
+			r.OWNER,
+			r.TABLE_NAME,
+			rc.COLUMN_NAME,
+			c.DELETE_RULE
+		FROM ALL_CONSTRAINTS c
+		JOIN ALL_CONS_COLUMNS cc ON cc.OWNER = c.OWNER AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+		JOIN ALL_CONSTRAINTS r ON r.OWNER = c.R_OWNER AND r.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME
+		JOIN ALL_CONS_COLUMNS rc
+			ON rc.OWNER = r.OWNER AND rc.CONSTRAINT_NAME = r.CONSTRAINT_NAME AND rc.POSITION = cc.POSITION
+		WHERE c.CONSTRAINT_TYPE = 'R' AND c.OWNER = :owner AND c.TABLE_NAME = :name
+		ORDER BY c.CONSTRAINT_NAME, cc.POSITION
+	`
+
+	rows, err := db.Connection.Query(query, db.tableArgs(table)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var relations []RelationInfo
+	for rows.Next() {
+		var rel RelationInfo
+		var referencedOwner string
+
+		if err := rows.Scan(&rel.Name, &rel.Column, &referencedOwner, &rel.ReferencedTable, &rel.ReferencedColumn, &rel.OnDelete); err != nil {
+			return nil, err
+		}
+		// Tables outside the default schema are named as they are opened
+		rel.ReferencedTable = db.tableName(referencedOwner, rel.ReferencedTable)
+		rel.OnUpdate = "NO ACTION"
+
+		relations = append(relations, rel)
+	}
+
+	return relations, rows.Err()
+}
+
+// GetTriggerInfo returns trigger information for a table. Triggers firing on
+// several events list them all, e.g. INSERT OR UPDATE.
+func (db *Oracle) GetTriggerInfo(database, table string) ([]TriggerInfo, error) {
+	query := `
+		SELECT
+			TRIGGER_NAME,
+			TRIGGERING_EVENT,
+			CASE
+				WHEN TRIGGER_TYPE LIKE 'BEFORE%' THEN 'BEFORE'
+				WHEN TRIGGER_TYPE LIKE 'AFTER%' THEN 'AFTER'
+				ELSE TRIGGER_TYPE
+			END,
+			TRIGGER_BODY,
+			TABLE_NAME
+		FROM ALL_TRIGGERS
+		WHERE TABLE_OWNER = :owner AND TABLE_NAME = :name
+		ORDER BY TRIGGER_NAME
+	`
+
+	rows, err := db.Connection.Query(query, db.tableArgs(table)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var triggers []TriggerInfo
+	for rows.Next() {
+		var trig TriggerInfo
+		var body sql.NullString
+
+		if err := rows.Scan(&trig.Name, &trig.Event, &trig.Timing, &body, &trig.Table); err != nil {
+			return nil, err
+		}
+		trig.Statement = strings.TrimSpace(body.String)
+
+		triggers = append(triggers, trig)
+	}
+
+	return triggers, rows.Err()
+}
+
+// GetDependencies returns the views, foreign keys and triggers that depend on a table
+func (db *Oracle) GetDependencies(database, table string) ([]DependencyInfo, error) {
+	query := `
+		SELECT NAME, 'VIEW', OWNER, NAME, CAST(NULL AS VARCHAR2(4000))
+		FROM ALL_DEPENDENCIES
+		WHERE REFERENCED_OWNER = :owner AND REFERENCED_NAME = :name AND TYPE = 'VIEW'
+		UNION ALL
+		SELECT c.CONSTRAINT_NAME, 'FOREIGN KEY', c.OWNER, c.TABLE_NAME,
+			LISTAGG(cc.COLUMN_NAME, ', ') WITHIN GROUP (ORDER BY cc.POSITION)
+		FROM ALL_CONSTRAINTS c
+		JOIN ALL_CONSTRAINTS r ON r.OWNER = c.R_OWNER AND r.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME
+		JOIN ALL_CONS_COLUMNS cc ON cc.OWNER = c.OWNER AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+		WHERE c.CONSTRAINT_TYPE = 'R' AND r.OWNER = :owner AND r.TABLE_NAME = :name
+		GROUP BY c.CONSTRAINT_NAME, c.OWNER, c.TABLE_NAME
+		UNION ALL
+		SELECT TRIGGER_NAME, 'TRIGGER', TABLE_OWNER, TABLE_NAME, CAST(NULL AS VARCHAR2(4000))
+		FROM ALL_TRIGGERS
+		WHERE TABLE_OWNER = :owner AND TABLE_NAME = :name
+		ORDER BY 2, 1
+	`
+
+	rows, err := db.Connection.Query(query, db.tableArgs(table)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deps []DependencyInfo
+	for rows.Next() {
+		var dep DependencyInfo
+		var owner string
+		var detail sql.NullString
+
+		if err := rows.Scan(&dep.Name, &dep.Type, &owner, &dep.Table, &detail); err != nil {
+			return nil, err
+		}
+		dep.Table = db.tableName(owner, dep.Table)
+		dep.Detail = detail.String
+
+		deps = append(deps, dep)
+	}
+
+	return deps, rows.Err()
+}
+
+// GetTableInfo returns row count and column count for a table. The row count
+// comes from the optimizer statistics when the table has been analyzed; the
+// size isn't read, as the segments are only visible to their owner and DBAs.
+func (db *Oracle) GetTableInfo(database, table string) (*TableInfo, error) {
+	info := &TableInfo{SizeBytes: -1, RowCountApprox: true}
+
+	estimate, err := db.GetEstimatedRowCount(database, table)
+	if err != nil {
+		return nil, err
+	}
+	info.RowCount = estimate
+
+	// Views and tables without statistics have no count to read
+	if info.RowCount < 0 {
+		count, err := db.GetRowCount(database, table)
+		if err != nil {
+			return nil, err
+		}
+		info.RowCount = count
+		info.RowCountApprox = false
+	}
+
+	columns, err := db.GetColumnInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+	info.ColumnCount = len(columns)
+
+	return info, nil
+}
+
+// GetCreateStatements returns the DDL DBMS_METADATA generates for the table, or
+// the view, followed by its indexes that don't back a constraint. Storage and
+// tablespace clauses are left out, as they rarely apply to another database.
+func (db *Oracle) GetCreateStatements(database, table string) ([]string, error) {
+	ctx := context.Background()
+	schema, name := db.SplitTable(table)
+
+	// Transform parameters are set per session, so everything runs on one connection
+	conn, err := db.Connection.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	transforms := `BEGIN
+		DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'STORAGE', FALSE);
+		DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'SEGMENT_ATTRIBUTES', FALSE);
+		DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'SQLTERMINATOR', FALSE);
+	END;`
+	if _, err := conn.ExecContext(ctx, transforms); err != nil {
+		return nil, err
+	}
+
+	var objectType string
+	typeQuery := `SELECT OBJECT_TYPE FROM ALL_OBJECTS
+		WHERE OWNER = :owner AND OBJECT_NAME = :name AND OBJECT_TYPE IN ('TABLE', 'VIEW')`
+	if err := conn.QueryRowContext(ctx, typeQuery, sql.Named("owner", schema), sql.Named("name", name)).Scan(&objectType); err != nil {
+		return nil, err
+	}
+
+	var ddl string
+	ddlQuery := "SELECT DBMS_METADATA.GET_DDL(:type, :name, :owner) FROM DUAL"
+	if err := conn.QueryRowContext(ctx, ddlQuery, sql.Named("type", objectType), sql.Named("name", name), sql.Named("owner", schema)).Scan(&ddl); err != nil {
+		return nil, err
+	}
+	statements := []string{strings.TrimSpace(ddl)}
+
+	indexQuery := `
+		SELECT i.OWNER, i.INDEX_NAME FROM ALL_INDEXES i
+		WHERE i.TABLE_OWNER = :owner AND i.TABLE_NAME = :name AND i.INDEX_TYPE <> 'LOB'
+			AND NOT EXISTS (
+				SELECT 1 FROM ALL_CONSTRAINTS k
+				WHERE k.OWNER = i.TABLE_OWNER AND k.TABLE_NAME = i.TABLE_NAME AND k.INDEX_NAME = i.INDEX_NAME
+			)
+		ORDER BY i.INDEX_NAME
+	`
+	rows, err := conn.QueryContext(ctx, indexQuery, sql.Named("owner", schema), sql.Named("name", name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type index struct{ owner, name string }
+	var indexes []index
+	for rows.Next() {
+		var idx index
+		if err := rows.Scan(&idx.owner, &idx.name); err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, idx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, idx := range indexes {
+		if err := conn.QueryRowContext(ctx, ddlQuery, sql.Named("type", "INDEX"), sql.Named("name", idx.name), sql.Named("owner", idx.owner)).Scan(&ddl); err != nil {
+			return nil, err
+		}
+		statements = append(statements, strings.TrimSpace(ddl))
+	}
+
+	return statements, nil
+}
+
+// GetEstimatedRowCount returns the row count of the table's optimizer
+// statistics, or -1 for views and tables that were never analyzed
+func (db *Oracle) GetEstimatedRowCount(database, table string) (int64, error) {
+	query := "SELECT NUM_ROWS FROM ALL_TABLES WHERE OWNER = :owner AND TABLE_NAME = :name"
+
+	var estimate sql.NullInt64
+	err := db.Connection.QueryRow(query, db.tableArgs(table)...).Scan(&estimate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	if !estimate.Valid {
+		return -1, nil
+	}
+	return estimate.Int64, nil
+}
+
+// GetRowCount returns the exact number of rows in a table
+func (db *Oracle) GetRowCount(database, table string) (int64, error) {
+	return db.GetRowCountWithFilter(database, table, "")
+}
+
+// GetRowCountWithFilter counts the rows matching whereClause with COUNT(*)
+func (db *Oracle) GetRowCountWithFilter(database, table, whereClause string) (int64, error) {
+	query := "SELECT COUNT(*) FROM " + db.qualify(table)
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+	var count int64
+	if err := db.Connection.QueryRow(query).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ExecuteQuery executes a raw SQL query and returns the results
+func (db *Oracle) ExecuteQuery(query string) ([][]string, error) {
+	return db.ExecuteQueryContext(context.Background(), query)
+}
+
+// ExecuteQueryContext executes a raw SQL query and returns the results, stopping when ctx is cancelled
+func (db *Oracle) ExecuteQueryContext(ctx context.Context, query string) ([][]string, error) {
+	logger.Debug("Executing raw query", map[string]any{
+		"query": query,
+	})
+
+	rows, err := db.Connection.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return readOracleRows(rows)
+}
+
+// ExecuteScript executes each statement of a script and returns every statement's result
+func (db *Oracle) ExecuteScript(script string) ([]StatementResult, error) {
+	return db.ExecuteScriptContext(context.Background(), script)
+}
+
+// ExecuteScriptContext is ExecuteScript stopping at the running statement when ctx is cancelled
+func (db *Oracle) ExecuteScriptContext(ctx context.Context, script string) ([]StatementResult, error) {
+	return executeScript(ctx, db.Connection, script, scriptOptions{})
+}
+
+// ExecuteInTransaction runs the statements in one transaction
+func (db *Oracle) ExecuteInTransaction(statements ...string) error {
+	return executeInTransaction(db.Connection, statements)
+}
+
+// ExecuteStatement runs a statement with args bound to its placeholders
+func (db *Oracle) ExecuteStatement(query string, args ...any) (int64, error) {
+	return execWithArgs(db.Connection, query, args)
+}
+
+// Placeholder returns :n
+func (db *Oracle) Placeholder(n int) string {
+	return ":" + strconv.Itoa(n)
+}
+
+// TruncateStatement returns a TRUNCATE TABLE for the table
+func (db *Oracle) TruncateStatement(table string) string {
+	return "TRUNCATE TABLE " + db.qualify(table)
+}
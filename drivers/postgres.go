@@ -1,12 +1,15 @@
 package drivers
 
 import (
+	"context"
 	"database/sql"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/sheenazien8/sq/logger"
 	"github.com/xo/dburl"
 )
@@ -14,18 +17,63 @@ import (
 type PostgreSQL struct {
 	Connection       *sql.DB
 	Provider         string
-	Schema           string // Current schema (for backward compatibility)
-	CurrentDatabase  string // Current database name
-	PreviousDatabase string // Previous database name for reverting
+	Schema           string          // Current schema (for backward compatibility)
+	schemas          map[string]bool // Schemas listed by GetTables, for parsing schema.table names
+	CurrentDatabase  string          // Current database name
+	PreviousDatabase string          // Previous database name for reverting
+	sshTunnel
+	tlsSettings
+}
+
+func init() {
+	Register(DriverTypePostgreSQL, func() Driver { return &PostgreSQL{} })
+}
+
+// open opens a connection to urlstr with the SSL settings of db, through its
+// SSH tunnel if it has one, returning the tunnel to close along with the connection.
+// The tunnel is dialed instead of rewriting the URL's host, so verify-full still
+// checks the certificate against the server's name.
+func (db *PostgreSQL) open(ctx context.Context, urlstr string) (*sql.DB, *Tunnel, error) {
+	urlstr, err := postgresTLSURL(urlstr, db.tlsConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tunnel, err := db.openTunnel(ctx, urlstr, DefaultPort(DriverTypePostgreSQL))
+	if err != nil {
+		return nil, nil, err
+	}
+	if tunnel == nil {
+		conn, err := dburl.Open(urlstr)
+		return conn, nil, err
+	}
+
+	u, err := dburl.Parse(urlstr)
+	if err != nil {
+		tunnel.Close()
+		return nil, nil, err
+	}
+	connector, err := pq.NewConnector(u.DSN)
+	if err != nil {
+		tunnel.Close()
+		return nil, nil, err
+	}
+	connector.Dialer(tunnel)
+	return sql.OpenDB(connector), tunnel, nil
 }
 
 func (db *PostgreSQL) Connect(urlstr string) (err error) {
 	db.SetProvider(DriverPostgreSQL)
 
-	db.Connection, err = dburl.Open(urlstr)
+	db.Connection, db.tunnel, err = db.open(context.Background(), urlstr)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			db.closeTunnel()
+		}
+	}()
 
 	err = db.Connection.Ping()
 	if err != nil {
@@ -91,13 +139,35 @@ func (db *PostgreSQL) detectSchema() error {
 }
 
 func (db *PostgreSQL) TestConnection(urlstr string) error {
-	conn, err := dburl.Open(urlstr)
+	return db.TestConnectionContext(context.Background(), urlstr)
+}
+
+// TestConnectionContext connects and pings the database, giving up when ctx is cancelled
+func (db *PostgreSQL) TestConnectionContext(ctx context.Context, urlstr string) error {
+	conn, tunnel, err := db.open(ctx, urlstr)
 	if err != nil {
 		return err
 	}
+	defer tunnel.Close()
+	defer conn.Close()
+
+	return conn.PingContext(ctx)
+}
+
+// ServerVersion opens a short-lived connection and returns the server's version string
+func (db *PostgreSQL) ServerVersion(urlstr string) (string, error) {
+	conn, tunnel, err := db.open(context.Background(), urlstr)
+	if err != nil {
+		return "", err
+	}
+	defer tunnel.Close()
 	defer conn.Close()
 
-	return conn.Ping()
+	var version string
+	if err := conn.QueryRow("SHOW server_version").Scan(&version); err != nil {
+		return "", err
+	}
+	return "PostgreSQL " + version, nil
 }
 
 // QuoteIdentifier quotes an identifier for PostgreSQL (uses double quotes)
@@ -105,6 +175,21 @@ func (db *PostgreSQL) QuoteIdentifier(identifier string) string {
 	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
 }
 
+// ContainsClause returns a LIKE clause matching column values containing value
+func (db *PostgreSQL) ContainsClause(column, value string) string {
+	pattern := strings.ReplaceAll(EscapeLike(value), "'", "''")
+	return fmt.Sprintf(`%s LIKE '%%%s%%' ESCAPE '\'`, db.QuoteIdentifier(column), pattern)
+}
+
+// Close closes the database connection and its SSH tunnel
+func (db *PostgreSQL) Close() error {
+	defer db.closeTunnel()
+	if db.Connection == nil {
+		return nil
+	}
+	return db.Connection.Close()
+}
+
 // GetTables returns all tables for a given database, organized by schema
 func (db *PostgreSQL) GetTables(database string) (map[string][]string, error) {
 	if database == "" {
@@ -115,9 +200,9 @@ func (db *PostgreSQL) GetTables(database string) (map[string][]string, error) {
 	db.PreviousDatabase = db.CurrentDatabase
 	db.CurrentDatabase = database
 
-	// Query all tables from all schemas in the current database, excluding system schemas
+	// Query all tables and views from all schemas in the current database, excluding system schemas
 	query := `SELECT table_name, table_schema FROM information_schema.tables
-		WHERE table_catalog = $1 AND table_type = 'BASE TABLE'
+		WHERE table_catalog = $1 AND table_type IN ('BASE TABLE', 'VIEW')
 		AND table_schema NOT IN ('pg_catalog', 'information_schema', 'pg_toast', 'pg_temp_1')
 		ORDER BY table_schema, table_name`
 	rows, err := db.Connection.Query(query, database)
@@ -127,6 +212,7 @@ func (db *PostgreSQL) GetTables(database string) (map[string][]string, error) {
 	defer rows.Close()
 
 	tables := make(map[string][]string)
+	db.schemas = make(map[string]bool)
 	for rows.Next() {
 		var tableName, tableSchema string
 		if err := rows.Scan(&tableName, &tableSchema); err != nil {
@@ -134,6 +220,7 @@ func (db *PostgreSQL) GetTables(database string) (map[string][]string, error) {
 		}
 		// Organize tables by schema
 		tables[tableSchema] = append(tables[tableSchema], tableName)
+		db.schemas[tableSchema] = true
 	}
 
 	if err := rows.Err(); err != nil {
@@ -154,8 +241,53 @@ func (db *PostgreSQL) GetTables(database string) (map[string][]string, error) {
 	return tables, nil
 }
 
+// GetViews returns the names of all views in the database, excluding system schemas.
+// Each is named schema.view so a table of another schema with the same name isn't
+// taken for one.
+func (db *PostgreSQL) GetViews(database string) ([]string, error) {
+	query := `SELECT table_name, table_schema FROM information_schema.views
+		WHERE table_catalog = $1
+		AND table_schema NOT IN ('pg_catalog', 'information_schema')
+		ORDER BY table_name`
+	rows, err := db.Connection.Query(query, database)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []string
+	for rows.Next() {
+		var viewName, viewSchema string
+		if err := rows.Scan(&viewName, &viewSchema); err != nil {
+			return nil, err
+		}
+		views = append(views, viewSchema+"."+viewName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+// DefaultSchema returns the schema unqualified table names refer to
+func (db *PostgreSQL) DefaultSchema() string {
+	return db.Schema
+}
+
+// SplitTable splits a schema-qualified "schema.table" name into its schema and
+// table. Names without a known schema prefix are tables of the current schema.
+func (db *PostgreSQL) SplitTable(table string) (schema, name string) {
+	if i := strings.Index(table, "."); i > 0 && db.schemas[table[:i]] {
+		return table[:i], table[i+1:]
+	}
+	return db.Schema, table
+}
+
 // GetTableColumns returns basic column information for a table
 func (db *PostgreSQL) GetTableColumns(database, table string) ([][]string, error) {
+	schema, table := db.SplitTable(table)
 	query := `
 		SELECT
 			column_name,
@@ -167,7 +299,7 @@ func (db *PostgreSQL) GetTableColumns(database, table string) ([][]string, error
 		WHERE table_schema = $1 AND table_name = $2
 		ORDER BY ordinal_position
 	`
-	rows, err := db.Connection.Query(query, db.Schema, table)
+	rows, err := db.Connection.Query(query, schema, table)
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +335,8 @@ func (db *PostgreSQL) GetTableColumns(database, table string) ([][]string, error
 
 // GetTableData returns all data from a table with a limit
 func (db *PostgreSQL) GetTableData(database, table string) ([][]string, error) {
-	query := `SELECT * FROM "` + db.Schema + `"."` + table + `" LIMIT 1000`
+	schema, table := db.SplitTable(table)
+	query := `SELECT * FROM "` + schema + `"."` + table + `" LIMIT 1000`
 	rows, err := db.Connection.Query(query)
 	if err != nil {
 		return nil, err
@@ -250,7 +383,8 @@ func (db *PostgreSQL) GetTableData(database, table string) ([][]string, error) {
 
 // GetTableDataWithFilter returns filtered table data
 func (db *PostgreSQL) GetTableDataWithFilter(database, table string, whereClause string) ([][]string, error) {
-	query := `SELECT * FROM "` + db.Schema + `"."` + table + `"`
+	schema, table := db.SplitTable(table)
+	query := `SELECT * FROM "` + schema + `"."` + table + `"`
 
 	// Use raw WHERE clause if provided
 	if whereClause != "" {
@@ -310,27 +444,34 @@ func (db *PostgreSQL) GetTableDataWithFilter(database, table string, whereClause
 
 // GetTableDataPaginated returns paginated table data
 func (db *PostgreSQL) GetTableDataPaginated(database, table string, pagination Pagination) (*PaginatedResult, error) {
-	// Get total count
-	countQuery := `SELECT COUNT(*) FROM "` + db.Schema + `"."` + table + `"`
-	var totalRows int
-	if err := db.Connection.QueryRow(countQuery).Scan(&totalRows); err != nil {
-		return nil, err
+	return db.GetTableDataPaginatedContext(context.Background(), database, table, pagination)
+}
+
+// GetTableDataPaginatedContext is like GetTableDataPaginated but stops when ctx is cancelled
+func (db *PostgreSQL) GetTableDataPaginatedContext(ctx context.Context, database, table string, pagination Pagination) (*PaginatedResult, error) {
+	schema, name := db.SplitTable(table)
+	// Get total count, from table statistics when an estimate was asked for
+	totalRows, totalApprox := -1, false
+	if pagination.EstimateTotal {
+		if estimate, err := db.GetEstimatedRowCount(database, table); err == nil && estimate >= 0 {
+			totalRows, totalApprox = int(estimate), true
+		}
+	}
+	if !totalApprox {
+		countQuery := `SELECT COUNT(*) FROM "` + schema + `"."` + name + `"`
+		if err := db.Connection.QueryRowContext(ctx, countQuery).Scan(&totalRows); err != nil {
+			return nil, err
+		}
 	}
 
 	// Calculate offset
 	offset := max((pagination.Page-1)*pagination.PageSize, 0)
 
 	// Get paginated data
-	query := `SELECT * FROM "` + db.Schema + `"."` + table + `"`
+	query := `SELECT * FROM "` + schema + `"."` + name + `"`
 
-	// Add ORDER BY if sort column is specified
-	if pagination.SortColumn != "" {
-		sortOrder := pagination.SortOrder
-		if sortOrder != "DESC" {
-			sortOrder = "ASC"
-		}
-		query += ` ORDER BY "` + pagination.SortColumn + `" ` + sortOrder
-	}
+	// Add ORDER BY if a sort order is specified
+	query += orderByClause(pagination, db.QuoteIdentifier)
 
 	query += " LIMIT " + strconv.Itoa(pagination.PageSize) + " OFFSET " + strconv.Itoa(offset)
 
@@ -341,7 +482,7 @@ func (db *PostgreSQL) GetTableDataPaginated(database, table string, pagination P
 		"offset":   offset,
 	})
 
-	rows, err := db.Connection.Query(query)
+	rows, err := db.Connection.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -389,18 +530,25 @@ func (db *PostgreSQL) GetTableDataPaginated(database, table string, pagination P
 	}
 
 	return &PaginatedResult{
-		Data:       data,
-		TotalRows:  totalRows,
-		Page:       pagination.Page,
-		PageSize:   pagination.PageSize,
-		TotalPages: totalPages,
+		Data:        data,
+		TotalRows:   totalRows,
+		Page:        pagination.Page,
+		PageSize:    pagination.PageSize,
+		TotalPages:  totalPages,
+		TotalApprox: totalApprox,
 	}, nil
 }
 
 // GetTableDataWithFilterPaginated returns paginated and filtered table data
 func (db *PostgreSQL) GetTableDataWithFilterPaginated(database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
-	baseQuery := `SELECT * FROM "` + db.Schema + `"."` + table + `"`
-	countQuery := `SELECT COUNT(*) FROM "` + db.Schema + `"."` + table + `"`
+	return db.GetTableDataWithFilterPaginatedContext(context.Background(), database, table, whereClause, pagination)
+}
+
+// GetTableDataWithFilterPaginatedContext is like GetTableDataWithFilterPaginated but stops when ctx is cancelled
+func (db *PostgreSQL) GetTableDataWithFilterPaginatedContext(ctx context.Context, database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	schema, table := db.SplitTable(table)
+	baseQuery := `SELECT * FROM "` + schema + `"."` + table + `"`
+	countQuery := `SELECT COUNT(*) FROM "` + schema + `"."` + table + `"`
 
 	// Use raw WHERE clause if provided
 	if whereClause != "" {
@@ -410,7 +558,7 @@ func (db *PostgreSQL) GetTableDataWithFilterPaginated(database, table string, wh
 
 	// Get total count with filters
 	var totalRows int
-	if err := db.Connection.QueryRow(countQuery).Scan(&totalRows); err != nil {
+	if err := db.Connection.QueryRowContext(ctx, countQuery).Scan(&totalRows); err != nil {
 		return nil, err
 	}
 
@@ -420,14 +568,8 @@ func (db *PostgreSQL) GetTableDataWithFilterPaginated(database, table string, wh
 	// Build final query with pagination
 	query := baseQuery
 
-	// Add ORDER BY if sort column is specified
-	if pagination.SortColumn != "" {
-		sortOrder := pagination.SortOrder
-		if sortOrder != "DESC" {
-			sortOrder = "ASC"
-		}
-		query += ` ORDER BY "` + pagination.SortColumn + `" ` + sortOrder
-	}
+	// Add ORDER BY if a sort order is specified
+	query += orderByClause(pagination, db.QuoteIdentifier)
 
 	query += " LIMIT " + strconv.Itoa(pagination.PageSize) + " OFFSET " + strconv.Itoa(offset)
 
@@ -438,7 +580,7 @@ func (db *PostgreSQL) GetTableDataWithFilterPaginated(database, table string, wh
 		"offset":   offset,
 	})
 
-	rows, err := db.Connection.Query(query)
+	rows, err := db.Connection.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -494,8 +636,100 @@ func (db *PostgreSQL) GetTableDataWithFilterPaginated(database, table string, wh
 	}, nil
 }
 
+// copyOutFetchSize is the number of rows fetched from the export cursor at a time
+const copyOutFetchSize = 5000
+
+// CopyOut streams a table's rows, optionally filtered, to w as CSV with a header row.
+// lib/pq can't read COPY ... TO STDOUT, so the rows are streamed through a server-side
+// cursor instead, which avoids re-running the query with a growing OFFSET per page.
+func (db *PostgreSQL) CopyOut(table, whereClause string, w io.Writer) error {
+	schema, table := db.SplitTable(table)
+	query := `SELECT * FROM "` + schema + `"."` + table + `"`
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+
+	logger.Debug("Exporting table through cursor", map[string]any{
+		"query": query,
+	})
+
+	// Cursors only live inside a transaction; nothing is written, so it is always rolled back
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DECLARE sq_copy_out NO SCROLL CURSOR FOR " + query); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	wroteHeader := false
+	for {
+		n, err := db.copyOutBatch(tx, cw, !wroteHeader)
+		if err != nil {
+			return err
+		}
+		wroteHeader = true
+		if n == 0 {
+			break
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// copyOutBatch writes the next batch of rows from the export cursor and returns how many were written
+func (db *PostgreSQL) copyOutBatch(tx *sql.Tx, cw *csv.Writer, writeHeader bool) (int, error) {
+	rows, err := tx.Query("FETCH FORWARD " + strconv.Itoa(copyOutFetchSize) + " FROM sq_copy_out")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	if writeHeader {
+		if err := cw.Write(columns); err != nil {
+			return 0, err
+		}
+	}
+
+	n := 0
+	for rows.Next() {
+		values := make([]any, len(columns))
+		valuePtrs := make([]any, len(columns))
+		for i := range columns {
+			valuePtrs[i] = &values[i]
+		}
+
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return n, err
+		}
+
+		record := make([]string, len(columns))
+		for i, val := range values {
+			if val != nil {
+				record[i] = formatSQLValue(val)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, rows.Err()
+}
+
 // GetTableStructure returns complete table structure including columns, indexes, relations, and triggers
 func (db *PostgreSQL) GetTableStructure(database, table string) (*TableStructure, error) {
+	schema, name := db.SplitTable(table)
 	columns, err := db.GetColumnInfo(database, table)
 	if err != nil {
 		return nil, err
@@ -519,7 +753,7 @@ func (db *PostgreSQL) GetTableStructure(database, table string) (*TableStructure
 		ORDER BY kcu.ordinal_position
 	`
 
-	rows, err := db.Connection.Query(query, db.Schema, table)
+	rows, err := db.Connection.Query(query, schema, name)
 	if err == nil {
 		defer rows.Close()
 		for rows.Next() {
@@ -557,6 +791,7 @@ func (db *PostgreSQL) GetTableStructure(database, table string) (*TableStructure
 
 // GetColumnInfo returns detailed column information for a table
 func (db *PostgreSQL) GetColumnInfo(database, table string) ([]ColumnInfo, error) {
+	schema, table := db.SplitTable(table)
 	query := `
 		SELECT
 			c.column_name,
@@ -564,14 +799,18 @@ func (db *PostgreSQL) GetColumnInfo(database, table string) ([]ColumnInfo, error
 			CASE WHEN c.is_nullable = 'YES' THEN true ELSE false END as is_nullable,
 			c.column_default,
 			false as is_primary_key,
-			''::text as extra,
+			CASE
+				WHEN c.is_generated = 'ALWAYS' THEN 'GENERATED ALWAYS'
+				WHEN c.is_identity = 'YES' THEN 'GENERATED ' || c.identity_generation || ' AS IDENTITY'
+				ELSE ''
+			END as extra,
 			''::text as comment
 		FROM information_schema.columns c
 		WHERE c.table_schema = $1 AND c.table_name = $2
 		ORDER BY c.ordinal_position
 	`
 
-	rows, err := db.Connection.Query(query, db.Schema, table)
+	rows, err := db.Connection.Query(query, schema, table)
 	if err != nil {
 		return nil, err
 	}
@@ -592,6 +831,8 @@ func (db *PostgreSQL) GetColumnInfo(database, table string) ([]ColumnInfo, error
 		col.IsPrimaryKey = isPrimaryKey
 		col.DefaultValue = defaultValue.String
 		col.Comment = comment.String
+		// Identity columns generated BY DEFAULT can still be written
+		col.Generated = col.Extra == "GENERATED ALWAYS" || col.Extra == "GENERATED ALWAYS AS IDENTITY"
 
 		columns = append(columns, col)
 	}
@@ -601,6 +842,7 @@ func (db *PostgreSQL) GetColumnInfo(database, table string) ([]ColumnInfo, error
 
 // GetIndexInfo returns index information for a table
 func (db *PostgreSQL) GetIndexInfo(database, table string) ([]IndexInfo, error) {
+	schema, table := db.SplitTable(table)
 	query := `
 		SELECT
 			indexname,
@@ -612,7 +854,7 @@ func (db *PostgreSQL) GetIndexInfo(database, table string) ([]IndexInfo, error)
 		ORDER BY indexname
 	`
 
-	rows, err := db.Connection.Query(query, db.Schema, table)
+	rows, err := db.Connection.Query(query, schema, table)
 	if err != nil {
 		return nil, err
 	}
@@ -652,10 +894,12 @@ func (db *PostgreSQL) GetIndexInfo(database, table string) ([]IndexInfo, error)
 
 // GetRelationInfo returns foreign key relationships for a table
 func (db *PostgreSQL) GetRelationInfo(database, table string) ([]RelationInfo, error) {
+	schema, table := db.SplitTable(table)
 	query := `
 		SELECT
 			constraint_name,
 			column_name,
+			foreign_table_schema,
 			foreign_table_name,
 			foreign_column_name,
 			update_rule,
@@ -664,6 +908,7 @@ func (db *PostgreSQL) GetRelationInfo(database, table string) ([]RelationInfo, e
 			SELECT
 				tc.constraint_name,
 				kcu.column_name,
+				ccu.table_schema AS foreign_table_schema,
 				ccu.table_name AS foreign_table_name,
 				ccu.column_name AS foreign_column_name,
 				rc.update_rule,
@@ -685,7 +930,7 @@ func (db *PostgreSQL) GetRelationInfo(database, table string) ([]RelationInfo, e
 		ORDER BY constraint_name, column_name
 	`
 
-	rows, err := db.Connection.Query(query, db.Schema, table)
+	rows, err := db.Connection.Query(query, schema, table)
 	if err != nil {
 		return nil, err
 	}
@@ -694,10 +939,15 @@ func (db *PostgreSQL) GetRelationInfo(database, table string) ([]RelationInfo, e
 	var relations []RelationInfo
 	for rows.Next() {
 		var rel RelationInfo
+		var referencedSchema string
 
-		if err := rows.Scan(&rel.Name, &rel.Column, &rel.ReferencedTable, &rel.ReferencedColumn, &rel.OnUpdate, &rel.OnDelete); err != nil {
+		if err := rows.Scan(&rel.Name, &rel.Column, &referencedSchema, &rel.ReferencedTable, &rel.ReferencedColumn, &rel.OnUpdate, &rel.OnDelete); err != nil {
 			return nil, err
 		}
+		// Tables outside the current schema are named as they are opened
+		if referencedSchema != db.Schema {
+			rel.ReferencedTable = referencedSchema + "." + rel.ReferencedTable
+		}
 
 		relations = append(relations, rel)
 	}
@@ -707,6 +957,7 @@ func (db *PostgreSQL) GetRelationInfo(database, table string) ([]RelationInfo, e
 
 // GetTriggerInfo returns trigger information for a table
 func (db *PostgreSQL) GetTriggerInfo(database, table string) ([]TriggerInfo, error) {
+	schema, table := db.SplitTable(table)
 	query := `
 		SELECT
 			trigger_name,
@@ -719,7 +970,7 @@ func (db *PostgreSQL) GetTriggerInfo(database, table string) ([]TriggerInfo, err
 		ORDER BY trigger_name
 	`
 
-	rows, err := db.Connection.Query(query, db.Schema, table)
+	rows, err := db.Connection.Query(query, schema, table)
 	if err != nil {
 		return nil, err
 	}
@@ -739,13 +990,240 @@ func (db *PostgreSQL) GetTriggerInfo(database, table string) ([]TriggerInfo, err
 	return triggers, rows.Err()
 }
 
+// GetDependencies returns the views, foreign keys and triggers that depend on a table
+func (db *PostgreSQL) GetDependencies(database, table string) ([]DependencyInfo, error) {
+	schema, table := db.SplitTable(table)
+	query := `
+		SELECT DISTINCT v.relname, 'VIEW', v.relname, ''
+		FROM pg_depend d
+		JOIN pg_rewrite r ON r.oid = d.objid
+		JOIN pg_class v ON v.oid = r.ev_class
+		JOIN pg_class t ON t.oid = d.refobjid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE d.classid = 'pg_rewrite'::regclass
+			AND d.refclassid = 'pg_class'::regclass
+			AND v.oid <> t.oid
+			AND n.nspname = $1
+			AND t.relname = $2
+		UNION ALL
+		SELECT c.conname, 'FOREIGN KEY', src.relname,
+			array_to_string(ARRAY(
+				SELECT a.attname FROM pg_attribute a
+				WHERE a.attrelid = c.conrelid AND a.attnum = ANY(c.conkey)
+			), ', ')
+		FROM pg_constraint c
+		JOIN pg_class t ON t.oid = c.confrelid
+		JOIN pg_class src ON src.oid = c.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE c.contype = 'f'
+			AND n.nspname = $1
+			AND t.relname = $2
+		UNION ALL
+		SELECT tg.tgname, 'TRIGGER', t.relname, p.proname
+		FROM pg_trigger tg
+		JOIN pg_class t ON t.oid = tg.tgrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_proc p ON p.oid = tg.tgfoid
+		WHERE NOT tg.tgisinternal
+			AND n.nspname = $1
+			AND t.relname = $2
+		ORDER BY 2, 1
+	`
+
+	rows, err := db.Connection.Query(query, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deps []DependencyInfo
+	for rows.Next() {
+		var dep DependencyInfo
+
+		if err := rows.Scan(&dep.Name, &dep.Type, &dep.Table, &dep.Detail); err != nil {
+			return nil, err
+		}
+
+		deps = append(deps, dep)
+	}
+
+	return deps, rows.Err()
+}
+
+// GetTableInfo returns row count, column count and total size for a table.
+// The row count is the planner estimate, unknown until the table has been analyzed;
+// counting a large table here would scan all of it.
+func (db *PostgreSQL) GetTableInfo(database, table string) (*TableInfo, error) {
+	schema, name := db.SplitTable(table)
+	query := `
+		SELECT c.reltuples::bigint, pg_total_relation_size(c.oid)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2
+	`
+
+	info := &TableInfo{RowCountApprox: true}
+	if err := db.Connection.QueryRow(query, schema, name).Scan(&info.RowCount, &info.SizeBytes); err != nil {
+		return nil, err
+	}
+
+	// Tables that were never analyzed (and views) have no estimate
+	if info.RowCount < 0 {
+		info.RowCount = -1
+	}
+
+	columns, err := db.GetTableColumns(database, table)
+	if err != nil {
+		return nil, err
+	}
+	info.ColumnCount = len(columns)
+
+	return info, nil
+}
+
+// GetCreateStatements builds a CREATE TABLE from the catalog, PostgreSQL has no
+// SHOW CREATE TABLE. Constraints are written inside the table; indexes that don't
+// back a constraint follow as CREATE INDEX statements.
+func (db *PostgreSQL) GetCreateStatements(database, table string) ([]string, error) {
+	schema, table := db.SplitTable(table)
+	qualified := db.QuoteIdentifier(schema) + "." + db.QuoteIdentifier(table)
+
+	columnQuery := `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull,
+			COALESCE(pg_get_expr(d.adbin, d.adrelid), '')
+		FROM pg_attribute a
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum
+	`
+	rows, err := db.Connection.Query(columnQuery, qualified)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []string
+	for rows.Next() {
+		var name, dataType, defaultValue string
+		var notNull bool
+		if err := rows.Scan(&name, &dataType, &notNull, &defaultValue); err != nil {
+			return nil, err
+		}
+		line := "  " + db.QuoteIdentifier(name) + " " + dataType
+		if notNull {
+			line += " NOT NULL"
+		}
+		if defaultValue != "" {
+			line += " DEFAULT " + defaultValue
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Primary key, unique and check constraints first, foreign keys last
+	constraintQuery := `
+		SELECT conname, pg_get_constraintdef(oid)
+		FROM pg_constraint
+		WHERE conrelid = $1::regclass
+		ORDER BY contype = 'f', conname
+	`
+	constraintRows, err := db.Connection.Query(constraintQuery, qualified)
+	if err != nil {
+		return nil, err
+	}
+	defer constraintRows.Close()
+
+	for constraintRows.Next() {
+		var name, definition string
+		if err := constraintRows.Scan(&name, &definition); err != nil {
+			return nil, err
+		}
+		lines = append(lines, "  CONSTRAINT "+db.QuoteIdentifier(name)+" "+definition)
+	}
+	if err := constraintRows.Err(); err != nil {
+		return nil, err
+	}
+
+	statements := []string{"CREATE TABLE " + qualified + " (\n" + strings.Join(lines, ",\n") + "\n)"}
+
+	indexQuery := `
+		SELECT indexdef FROM pg_indexes
+		WHERE schemaname = $1 AND tablename = $2
+			AND indexname NOT IN (SELECT conname FROM pg_constraint WHERE conrelid = $3::regclass)
+		ORDER BY indexname
+	`
+	indexRows, err := db.Connection.Query(indexQuery, schema, table, qualified)
+	if err != nil {
+		return nil, err
+	}
+	defer indexRows.Close()
+
+	for indexRows.Next() {
+		var definition string
+		if err := indexRows.Scan(&definition); err != nil {
+			return nil, err
+		}
+		statements = append(statements, definition)
+	}
+
+	return statements, indexRows.Err()
+}
+
+// GetEstimatedRowCount returns the planner's row estimate (pg_class.reltuples).
+// Tables that were never analyzed report -1.
+func (db *PostgreSQL) GetEstimatedRowCount(database, table string) (int64, error) {
+	schema, table := db.SplitTable(table)
+	query := `
+		SELECT c.reltuples::bigint
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2
+	`
+
+	var estimate int64
+	if err := db.Connection.QueryRow(query, schema, table).Scan(&estimate); err != nil {
+		return -1, err
+	}
+	// Before PostgreSQL 14 an unanalyzed table reports 0 rather than -1
+	if estimate <= 0 {
+		return -1, nil
+	}
+	return estimate, nil
+}
+
+// GetRowCount returns the exact number of rows in a table of the current schema
+func (db *PostgreSQL) GetRowCount(database, table string) (int64, error) {
+	return db.GetRowCountWithFilter(database, table, "")
+}
+
+// GetRowCountWithFilter counts the rows matching whereClause with COUNT(*)
+func (db *PostgreSQL) GetRowCountWithFilter(database, table, whereClause string) (int64, error) {
+	schema, table := db.SplitTable(table)
+	query := "SELECT COUNT(*) FROM " + db.QuoteIdentifier(schema) + "." + db.QuoteIdentifier(table)
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+	var count int64
+	if err := db.Connection.QueryRow(query).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // ExecuteQuery executes a raw SQL query and returns the results
 func (db *PostgreSQL) ExecuteQuery(query string) ([][]string, error) {
+	return db.ExecuteQueryContext(context.Background(), query)
+}
+
+// ExecuteQueryContext executes a raw SQL query and returns the results, stopping when ctx is cancelled
+func (db *PostgreSQL) ExecuteQueryContext(ctx context.Context, query string) ([][]string, error) {
 	logger.Debug("Executing raw query", map[string]any{
 		"query": query,
 	})
 
-	rows, err := db.Connection.Query(query)
+	rows, err := db.Connection.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -788,3 +1266,34 @@ func (db *PostgreSQL) ExecuteQuery(query string) ([][]string, error) {
 
 	return data, nil
 }
+
+// ExecuteScript executes each statement of a script and returns every statement's result
+func (db *PostgreSQL) ExecuteScript(script string) ([]StatementResult, error) {
+	return db.ExecuteScriptContext(context.Background(), script)
+}
+
+// ExecuteScriptContext is ExecuteScript stopping at the running statement when ctx is cancelled
+func (db *PostgreSQL) ExecuteScriptContext(ctx context.Context, script string) ([]StatementResult, error) {
+	return executeScript(ctx, db.Connection, script, scriptOptions{})
+}
+
+// ExecuteInTransaction runs the statements in one transaction
+func (db *PostgreSQL) ExecuteInTransaction(statements ...string) error {
+	return executeInTransaction(db.Connection, statements)
+}
+
+// ExecuteStatement runs a statement with args bound to its placeholders
+func (db *PostgreSQL) ExecuteStatement(query string, args ...any) (int64, error) {
+	return execWithArgs(db.Connection, query, args)
+}
+
+// Placeholder returns $n
+func (db *PostgreSQL) Placeholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// TruncateStatement returns a TRUNCATE TABLE for the table in the current schema
+func (db *PostgreSQL) TruncateStatement(table string) string {
+	schema, table := db.SplitTable(table)
+	return "TRUNCATE TABLE " + db.QuoteIdentifier(schema) + "." + db.QuoteIdentifier(table)
+}
@@ -0,0 +1,968 @@
+package drivers
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sheenazien8/sq/logger"
+)
+
+// Redis browses the keys of a Redis database. Key patterns take the place of
+// tables: "*" lists every key and "prefix:*" the keys sharing a prefix, each
+// key a row of its value, type, TTL and size. Queries are Redis commands, one
+// per line.
+type Redis struct {
+	Client   *redis.Client
+	Provider string
+	DB       int      // Database number the client selected
+	patterns []string // Key patterns listed by GetTables
+	sshTunnel
+	tlsSettings
+}
+
+func init() {
+	Register(DriverTypeRedis, func() Driver { return &Redis{} })
+	// go-redis logs to stderr, which would draw over the TUI
+	redis.SetLogger(redisLogger{})
+}
+
+const (
+	redisAllKeys      = "*"
+	redisKeySeparator = ":"
+	// redisScanCount is the COUNT hint of each SCAN
+	redisScanCount = 1000
+	// redisPatternScanLimit is how many keys are sampled for the key patterns
+	redisPatternScanLimit = 10000
+	// redisKeyLimit is how many keys of a pattern are paged through and counted
+	redisKeyLimit = 100000
+	// redisValueLimit is how many elements of a list, set, sorted set, hash or
+	// stream the value column shows
+	redisValueLimit = 100
+)
+
+// redisColumns are the columns of the rows keys are shown as
+var redisColumns = []string{"key", "value", "type", "ttl", "size"}
+
+// redisLogger sends go-redis's log lines to the debug log
+type redisLogger struct{}
+
+func (redisLogger) Printf(ctx context.Context, format string, v ...any) {
+	logger.Debug("Redis client: "+fmt.Sprintf(format, v...), nil)
+}
+
+// RedisURL returns the URL of a Redis database; username is only set for ACL users
+func RedisURL(username, password, host, port, database string) string {
+	u := url.URL{Scheme: "redis", Host: net.JoinHostPort(host, port), Path: "/" + database}
+	if password != "" {
+		u.User = url.UserPassword(username, password)
+	} else if username != "" {
+		u.User = url.User(username)
+	}
+	return u.String()
+}
+
+// RedisDatabase returns the database number a Redis URL selects, "0" when it names none
+func RedisDatabase(urlstr string) string {
+	u, err := url.Parse(urlstr)
+	if err != nil {
+		return ""
+	}
+	if database := strings.Trim(u.Path, "/"); database != "" {
+		return database
+	}
+	return "0"
+}
+
+// ParseRedisURL returns the parts of a Redis URL the connection form edits
+func ParseRedisURL(urlstr string) (host, port, username, password, database string, err error) {
+	u, err := url.Parse(urlstr)
+	if err != nil {
+		return "", "", "", "", "", err
+	}
+	host, port = u.Hostname(), u.Port()
+	if port == "" {
+		port = DefaultPort(DriverTypeRedis)
+	}
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	return host, port, username, password, RedisDatabase(urlstr), nil
+}
+
+// open creates a client for urlstr with the SSL settings of db, dialing through
+// its SSH tunnel if it has one, and returns the tunnel to close along with the
+// client. The client connects on its first command.
+func (db *Redis) open(ctx context.Context, urlstr string) (*redis.Client, *Tunnel, error) {
+	opts, err := redis.ParseURL(urlstr)
+	if err != nil {
+		return nil, nil, err
+	}
+	// RESP2 replies are plain strings, integers and arrays, and older servers only speak it
+	opts.Protocol = 2
+	// Cancelling a query's context stops waiting for its reply
+	opts.ContextTimeoutEnabled = true
+
+	if db.tlsConfig.Configured() {
+		opts.TLSConfig = nil
+		if db.tlsConfig.Encrypted() {
+			host, _, err := net.SplitHostPort(opts.Addr)
+			if err != nil {
+				return nil, nil, err
+			}
+			if opts.TLSConfig, err = db.tlsConfig.clientConfig(host); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	tunnel, err := db.openTunnel(ctx, urlstr, DefaultPort(DriverTypeRedis))
+	if err != nil {
+		return nil, nil, err
+	}
+	if tunnel != nil {
+		// A custom dialer replaces the one doing the TLS handshake
+		tlsConfig := opts.TLSConfig
+		opts.Dialer = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := tunnel.DialContext(ctx, network, addr)
+			if err != nil || tlsConfig == nil {
+				return conn, err
+			}
+			return tls.Client(conn, tlsConfig), nil
+		}
+	}
+	return redis.NewClient(opts), tunnel, nil
+}
+
+func (db *Redis) Connect(urlstr string) (err error) {
+	db.SetProvider(DriverTypeRedis)
+
+	db.Client, db.tunnel, err = db.open(context.Background(), urlstr)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			db.closeTunnel()
+		}
+	}()
+
+	if err = db.Client.Ping(context.Background()).Err(); err != nil {
+		return err
+	}
+	db.DB = db.Client.Options().DB
+	return nil
+}
+
+func (db *Redis) SetProvider(provider string) {
+	db.Provider = provider
+}
+
+func (db *Redis) TestConnection(urlstr string) error {
+	return db.TestConnectionContext(context.Background(), urlstr)
+}
+
+// TestConnectionContext connects and pings the server, giving up when ctx is cancelled
+func (db *Redis) TestConnectionContext(ctx context.Context, urlstr string) error {
+	client, tunnel, err := db.open(ctx, urlstr)
+	if err != nil {
+		return err
+	}
+	defer tunnel.Close()
+	defer client.Close()
+
+	return client.Ping(ctx).Err()
+}
+
+// ServerVersion opens a short-lived connection and returns the server's version and mode
+func (db *Redis) ServerVersion(urlstr string) (string, error) {
+	ctx := context.Background()
+	client, tunnel, err := db.open(ctx, urlstr)
+	if err != nil {
+		return "", err
+	}
+	defer tunnel.Close()
+	defer client.Close()
+
+	info, err := client.Info(ctx, "server").Result()
+	if err != nil {
+		return "", err
+	}
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		if name, value, ok := strings.Cut(strings.TrimSpace(line), ":"); ok {
+			fields[name] = value
+		}
+	}
+	version := "Redis " + fields["redis_version"]
+	if mode := fields["redis_mode"]; mode != "" {
+		version += " (" + mode + ")"
+	}
+	return version, nil
+}
+
+// QuoteIdentifier quotes a key or pattern as a command argument, leaving it as
+// is when it needs no quotes
+func (db *Redis) QuoteIdentifier(identifier string) string {
+	return redisQuote(identifier)
+}
+
+// globEscaper escapes the special characters of Redis glob patterns
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// ContainsClause returns the filter of keys containing value. Filters are glob
+// patterns matched against the keys, whatever the column.
+func (db *Redis) ContainsClause(column, value string) string {
+	return "*" + globEscaper.Replace(value) + "*"
+}
+
+// Close closes the client and its SSH tunnel
+func (db *Redis) Close() error {
+	defer db.closeTunnel()
+	if db.Client == nil {
+		return nil
+	}
+	return db.Client.Close()
+}
+
+// GetTables returns the key patterns of the selected database: "*" followed by
+// a "prefix:*" pattern for each prefix, up to the first ":", found in a sample
+// of the keys. database isn't used; the client stays on the URL's database.
+func (db *Redis) GetTables(database string) (map[string][]string, error) {
+	ctx := context.Background()
+	keys, _, err := db.scanKeys(ctx, redisAllKeys, "", redisPatternScanLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make(map[string]bool)
+	for _, key := range keys {
+		if prefix, _, ok := strings.Cut(key, redisKeySeparator); ok {
+			prefixes[prefix] = true
+		}
+	}
+	patterns := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		patterns = append(patterns, globEscaper.Replace(prefix)+redisKeySeparator+"*")
+	}
+	sort.Strings(patterns)
+	db.patterns = append([]string{redisAllKeys}, patterns...)
+
+	return map[string][]string{"db" + strconv.Itoa(db.DB): db.patterns}, nil
+}
+
+// GetViews returns every key pattern listed by GetTables: keys are browsed,
+// not edited, so their tabs open read-only like those of views
+func (db *Redis) GetViews(database string) ([]string, error) {
+	return db.patterns, nil
+}
+
+// GetTableColumns returns the columns of the rows keys are shown as
+func (db *Redis) GetTableColumns(database, table string) ([][]string, error) {
+	columns, err := db.GetColumnInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]string, len(columns))
+	for i, col := range columns {
+		nullable, key := "NO", ""
+		if col.Nullable {
+			nullable = "YES"
+		}
+		if col.IsPrimaryKey {
+			key = "PRI"
+		}
+		result[i] = []string{col.Name, col.DataType, nullable, key, col.DefaultValue, col.Extra}
+	}
+	return result, nil
+}
+
+// scanKeys returns the keys matching pattern, and filter when it isn't empty,
+// in the order SCAN returns them. It stops at limit keys, reporting whether it
+// saw every matching key.
+func (db *Redis) scanKeys(ctx context.Context, pattern, filter string, limit int) ([]string, bool, error) {
+	var keys []string
+	// SCAN may return a key more than once
+	seen := make(map[string]bool)
+	var cursor uint64
+	for {
+		batch, next, err := db.Client.Scan(ctx, cursor, pattern, redisScanCount).Result()
+		if err != nil {
+			return nil, false, err
+		}
+		for _, key := range batch {
+			if seen[key] || (filter != "" && !redisMatch(filter, key)) {
+				continue
+			}
+			if len(keys) == limit {
+				return keys, false, nil
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		if cursor = next; cursor == 0 {
+			return keys, true, nil
+		}
+	}
+}
+
+// GetTableData returns the first 1000 keys of a pattern, sorted by name
+func (db *Redis) GetTableData(database, table string) ([][]string, error) {
+	return db.GetTableDataWithFilter(database, table, "")
+}
+
+// GetTableDataWithFilter returns the first 1000 keys of a pattern matching the
+// filter pattern, sorted by name
+func (db *Redis) GetTableDataWithFilter(database, table string, whereClause string) ([][]string, error) {
+	result, err := db.paginate(context.Background(), table, whereClause, Pagination{Page: 1, PageSize: 1000})
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// GetTableDataPaginated returns a page of the keys of a pattern
+func (db *Redis) GetTableDataPaginated(database, table string, pagination Pagination) (*PaginatedResult, error) {
+	return db.GetTableDataPaginatedContext(context.Background(), database, table, pagination)
+}
+
+// GetTableDataPaginatedContext is like GetTableDataPaginated but stops when ctx is cancelled
+func (db *Redis) GetTableDataPaginatedContext(ctx context.Context, database, table string, pagination Pagination) (*PaginatedResult, error) {
+	return db.paginate(ctx, table, "", pagination)
+}
+
+// GetTableDataWithFilterPaginated returns a page of the keys of a pattern matching the filter pattern
+func (db *Redis) GetTableDataWithFilterPaginated(database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	return db.GetTableDataWithFilterPaginatedContext(context.Background(), database, table, whereClause, pagination)
+}
+
+// GetTableDataWithFilterPaginatedContext is like GetTableDataWithFilterPaginated but stops when ctx is cancelled
+func (db *Redis) GetTableDataWithFilterPaginatedContext(ctx context.Context, database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	return db.paginate(ctx, table, whereClause, pagination)
+}
+
+// paginate reads a page of the keys matching pattern, and the glob pattern
+// filter when set. Redis has no ordered scan, so up to redisKeyLimit keys are
+// read and sorted by name, descending when sorted so by the key column; other
+// columns don't change the order. Totals past the limit are approximate.
+func (db *Redis) paginate(ctx context.Context, pattern, filter string, pagination Pagination) (*PaginatedResult, error) {
+	keys, complete, err := db.scanKeys(ctx, pattern, strings.TrimSpace(filter), redisKeyLimit)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	if pagination.SortColumn == "key" && pagination.SortOrder == "DESC" {
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+
+	totalRows := len(keys)
+	offset := min(max((pagination.Page-1)*pagination.PageSize, 0), totalRows)
+	end := min(offset+pagination.PageSize, totalRows)
+
+	logger.Debug("Reading Redis keys", map[string]any{
+		"pattern":  pattern,
+		"filter":   filter,
+		"keys":     totalRows,
+		"page":     pagination.Page,
+		"pageSize": pagination.PageSize,
+	})
+
+	data, err := db.readKeys(ctx, keys[offset:end])
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate total pages
+	totalPages := totalRows / pagination.PageSize
+	if totalRows%pagination.PageSize > 0 {
+		totalPages++
+	}
+
+	return &PaginatedResult{
+		Data:        data,
+		TotalRows:   totalRows,
+		Page:        pagination.Page,
+		PageSize:    pagination.PageSize,
+		TotalPages:  totalPages,
+		TotalApprox: !complete,
+	}, nil
+}
+
+// readKeys returns a header row followed by a row for each key: its value, type,
+// TTL in seconds and size, the length of a string or the elements of anything
+// else. Two pipelines read them, the types and TTLs first, then the values the
+// types call for. Keys removed meanwhile are left with NULL values.
+func (db *Redis) readKeys(ctx context.Context, keys []string) ([][]string, error) {
+	data := [][]string{redisColumns}
+	if len(keys) == 0 {
+		return data, nil
+	}
+
+	types := make([]*redis.StatusCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	_, err := db.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			types[i] = pipe.Type(ctx, key)
+			ttls[i] = pipe.TTL(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]redis.Cmder, len(keys))
+	sizes := make([]*redis.IntCmd, len(keys))
+	_, err = db.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			switch types[i].Val() {
+			case "string":
+				values[i], sizes[i] = pipe.Get(ctx, key), pipe.StrLen(ctx, key)
+			case "list":
+				values[i], sizes[i] = pipe.LRange(ctx, key, 0, redisValueLimit-1), pipe.LLen(ctx, key)
+			case "set":
+				values[i], sizes[i] = pipe.SScan(ctx, key, 0, "", redisValueLimit), pipe.SCard(ctx, key)
+			case "zset":
+				values[i], sizes[i] = pipe.ZRangeWithScores(ctx, key, 0, redisValueLimit-1), pipe.ZCard(ctx, key)
+			case "hash":
+				values[i], sizes[i] = pipe.HScan(ctx, key, 0, "", redisValueLimit), pipe.HLen(ctx, key)
+			case "stream":
+				values[i], sizes[i] = pipe.XRangeN(ctx, key, "-", "+", redisValueLimit), pipe.XLen(ctx, key)
+			}
+		}
+		return nil
+	})
+	// Each failed command, e.g. the GET of a key removed meanwhile, is checked on its own
+	if err != nil && !errors.Is(err, redis.Nil) && !isRedisReplyError(err) {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		value, keyType, ttl, size := "NULL", types[i].Val(), "NULL", "NULL"
+		if keyType == "none" {
+			keyType = "NULL"
+		}
+		if d := ttls[i].Val(); d >= 0 {
+			ttl = strconv.FormatInt(int64(d/time.Second), 10)
+		}
+		if values[i] != nil && values[i].Err() == nil {
+			value = formatRedisKeyValue(values[i])
+		}
+		if sizes[i] != nil && sizes[i].Err() == nil {
+			size = strconv.FormatInt(sizes[i].Val(), 10)
+		}
+		data = append(data, []string{key, value, keyType, ttl, size})
+	}
+	return data, nil
+}
+
+// isRedisReplyError reports whether err is an error reply of the server, which
+// only fails its own command, rather than a failure of the connection
+func isRedisReplyError(err error) bool {
+	var redisErr redis.Error
+	return errors.As(err, &redisErr)
+}
+
+// formatRedisKeyValue formats the value read for a key: strings as they are,
+// lists and sets as JSON arrays, hashes as JSON objects, sorted sets as arrays
+// of members and scores, and streams as arrays of entries
+func formatRedisKeyValue(cmd redis.Cmder) string {
+	var value any
+	switch cmd := cmd.(type) {
+	case *redis.StringCmd:
+		return cmd.Val()
+	case *redis.StringSliceCmd:
+		value = cmd.Val()
+	case *redis.ScanCmd:
+		elements, _ := cmd.Val()
+		if cmd.Args()[0] != "hscan" {
+			sort.Strings(elements)
+			value = elements
+			break
+		}
+		// HSCAN returns fields and values in turn
+		fields := make(map[string]string, len(elements)/2)
+		for i := 0; i+1 < len(elements); i += 2 {
+			fields[elements[i]] = elements[i+1]
+		}
+		value = fields
+	case *redis.ZSliceCmd:
+		type member struct {
+			Member any     `json:"member"`
+			Score  float64 `json:"score"`
+		}
+		members := make([]member, len(cmd.Val()))
+		for i, z := range cmd.Val() {
+			members[i] = member{Member: z.Member, Score: z.Score}
+		}
+		value = members
+	case *redis.XMessageSliceCmd:
+		type entry struct {
+			ID     string         `json:"id"`
+			Values map[string]any `json:"values"`
+		}
+		entries := make([]entry, len(cmd.Val()))
+		for i, msg := range cmd.Val() {
+			entries[i] = entry{ID: msg.ID, Values: msg.Values}
+		}
+		value = entries
+	default:
+		return fmt.Sprint(cmd)
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// GetTableStructure returns the columns keys are shown with; keys have no
+// indexes, relations or triggers
+func (db *Redis) GetTableStructure(database, table string) (*TableStructure, error) {
+	columns, err := db.GetColumnInfo(database, table)
+	if err != nil {
+		return nil, err
+	}
+	return &TableStructure{Columns: columns}, nil
+}
+
+// GetColumnInfo returns the columns keys are shown with, the same for every pattern
+func (db *Redis) GetColumnInfo(database, table string) ([]ColumnInfo, error) {
+	return []ColumnInfo{
+		{Name: "key", DataType: "string", IsPrimaryKey: true},
+		{Name: "value", DataType: "string", Nullable: true,
+			Comment: fmt.Sprintf("Strings as they are, the first %d elements of anything else as JSON", redisValueLimit)},
+		{Name: "type", DataType: "string", Nullable: true},
+		{Name: "ttl", DataType: "integer", Nullable: true, Comment: "Seconds until the key expires, NULL when it doesn't"},
+		{Name: "size", DataType: "integer", Nullable: true, Comment: "Length of a string, or number of elements"},
+	}, nil
+}
+
+// GetIndexInfo returns no indexes, Redis keys have none
+func (db *Redis) GetIndexInfo(database, table string) ([]IndexInfo, error) {
+	return nil, nil
+}
+
+// GetRelationInfo returns no relations, Redis keys have none
+func (db *Redis) GetRelationInfo(database, table string) ([]RelationInfo, error) {
+	return nil, nil
+}
+
+// GetTriggerInfo returns no triggers, Redis keys have none
+func (db *Redis) GetTriggerInfo(database, table string) ([]TriggerInfo, error) {
+	return nil, nil
+}
+
+// GetDependencies returns no dependencies, nothing refers to a key pattern
+func (db *Redis) GetDependencies(database, table string) ([]DependencyInfo, error) {
+	return nil, nil
+}
+
+// GetTableInfo returns the number of keys matching a pattern. Redis doesn't
+// track sizes or changes of key sets.
+func (db *Redis) GetTableInfo(database, table string) (*TableInfo, error) {
+	count, approx, err := db.countKeys(context.Background(), table, "")
+	if err != nil {
+		return nil, err
+	}
+	return &TableInfo{
+		RowCount:       count,
+		RowCountApprox: approx,
+		ColumnCount:    len(redisColumns),
+		SizeBytes:      -1,
+	}, nil
+}
+
+// GetCreateStatements fails, key patterns have no schema to recreate
+func (db *Redis) GetCreateStatements(database, table string) ([]string, error) {
+	return nil, fmt.Errorf("redis keys have no schema to export")
+}
+
+// countKeys returns the number of keys matching pattern and filter, from DBSIZE
+// for all keys. Counts stopped at redisKeyLimit are reported as approximate.
+func (db *Redis) countKeys(ctx context.Context, pattern, filter string) (int64, bool, error) {
+	filter = strings.TrimSpace(filter)
+	if pattern == redisAllKeys && filter == "" {
+		count, err := db.Client.DBSize(ctx).Result()
+		return count, false, err
+	}
+	keys, complete, err := db.scanKeys(ctx, pattern, filter, redisKeyLimit)
+	if err != nil {
+		return 0, false, err
+	}
+	return int64(len(keys)), !complete, nil
+}
+
+// GetEstimatedRowCount returns the number of keys of the database for "*", and
+// -1 for other patterns, which take a scan to count
+func (db *Redis) GetEstimatedRowCount(database, table string) (int64, error) {
+	if table != redisAllKeys {
+		return -1, nil
+	}
+	return db.Client.DBSize(context.Background()).Result()
+}
+
+// GetRowCount returns the number of keys matching a pattern, counting at most
+// redisKeyLimit of them
+func (db *Redis) GetRowCount(database, table string) (int64, error) {
+	return db.GetRowCountWithFilter(database, table, "")
+}
+
+// GetRowCountWithFilter returns the number of keys matching a pattern and the
+// filter pattern, counting at most redisKeyLimit of them
+func (db *Redis) GetRowCountWithFilter(database, table, whereClause string) (int64, error) {
+	count, _, err := db.countKeys(context.Background(), table, whereClause)
+	return count, err
+}
+
+// ExecuteQuery runs a single command, e.g. HGETALL user:1
+func (db *Redis) ExecuteQuery(query string) ([][]string, error) {
+	return db.ExecuteQueryContext(context.Background(), query)
+}
+
+// ExecuteQueryContext is like ExecuteQuery but stops waiting for the reply when ctx is cancelled
+func (db *Redis) ExecuteQueryContext(ctx context.Context, query string) ([][]string, error) {
+	result, err := db.executeCommand(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// ExecuteScript runs one command per line, skipping empty lines and # comments
+func (db *Redis) ExecuteScript(script string) ([]StatementResult, error) {
+	return db.ExecuteScriptContext(context.Background(), script)
+}
+
+// ExecuteScriptContext is like ExecuteScript but stops when ctx is cancelled.
+// The results of the commands that ran before a failure are returned with the error.
+func (db *Redis) ExecuteScriptContext(ctx context.Context, script string) ([]StatementResult, error) {
+	commands := redisCommands(script)
+	logger.Debug("Executing Redis commands", map[string]any{
+		"commands": len(commands),
+	})
+
+	results := make([]StatementResult, 0, len(commands))
+	for i, command := range commands {
+		result, err := db.executeCommand(ctx, command)
+		if err != nil {
+			if len(commands) > 1 {
+				err = fmt.Errorf("command %d: %w", i+1, err)
+			}
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// redisCommands returns the lines of script holding a command
+func redisCommands(script string) []string {
+	var commands []string
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		commands = append(commands, line)
+	}
+	return commands
+}
+
+// executeCommand runs a command line and returns its reply as a result set:
+// arrays as a row per element, anything else as a single value
+func (db *Redis) executeCommand(ctx context.Context, command string) (StatementResult, error) {
+	result := StatementResult{Statement: command}
+	args, err := redisArgs(command)
+	if err != nil {
+		return result, err
+	}
+	if len(args) == 0 {
+		return result, fmt.Errorf("empty command")
+	}
+
+	reply, err := db.Client.Do(ctx, args...).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return result, err
+	}
+
+	if elements, ok := reply.([]any); ok {
+		result.Data = [][]string{{"#", "value"}}
+		for i, element := range elements {
+			result.Data = append(result.Data, []string{strconv.Itoa(i + 1), formatRedisReply(element)})
+		}
+	} else {
+		result.Data = [][]string{{"value"}, {formatRedisReply(reply)}}
+	}
+	result.TotalRows = int64(len(result.Data) - 1)
+	return result, nil
+}
+
+// formatRedisReply formats a reply, or an element of one: nil as NULL and
+// nested arrays as JSON
+func formatRedisReply(reply any) string {
+	switch reply := reply.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return reply
+	case []any:
+		b, err := json.Marshal(redisJSONValue(reply))
+		if err != nil {
+			return fmt.Sprint(reply)
+		}
+		return string(b)
+	case error:
+		return "(error) " + reply.Error()
+	}
+	return fmt.Sprint(reply)
+}
+
+// redisJSONValue converts a reply for JSON encoding, with errors as their message
+func redisJSONValue(reply any) any {
+	switch reply := reply.(type) {
+	case []any:
+		values := make([]any, len(reply))
+		for i, element := range reply {
+			values[i] = redisJSONValue(element)
+		}
+		return values
+	case error:
+		return reply.Error()
+	}
+	return reply
+}
+
+// ExecuteInTransaction runs the commands in a MULTI/EXEC transaction. Redis
+// doesn't roll back: commands that can't be parsed abort it before it runs, but
+// once it runs a failing command doesn't stop the others.
+func (db *Redis) ExecuteInTransaction(statements ...string) error {
+	ctx := context.Background()
+	commands := make([][]any, len(statements))
+	for i, stmt := range statements {
+		args, err := redisArgs(stmt)
+		if err != nil {
+			return err
+		}
+		commands[i] = args
+	}
+
+	_, err := db.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, args := range commands {
+			pipe.Do(ctx, args...)
+		}
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
+
+// ExecuteStatement runs a command with args sent after its own arguments as they
+// are, unquoted, and returns its integer reply, e.g. the number of keys DEL removed
+func (db *Redis) ExecuteStatement(query string, args ...any) (int64, error) {
+	command, err := redisArgs(query)
+	if err != nil {
+		return 0, err
+	}
+	command = append(command, args...)
+	if len(command) == 0 {
+		return 0, fmt.Errorf("empty command")
+	}
+
+	reply, err := db.Client.Do(context.Background(), command...).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+	n, _ := reply.(int64)
+	return n, nil
+}
+
+// Placeholder returns "", Redis commands take their arguments after the command
+// instead of in placeholders
+func (db *Redis) Placeholder(_ int) string {
+	return ""
+}
+
+// redisDeleteScript deletes the keys matching ARGV[1], in batches small enough
+// for Lua's unpack
+const redisDeleteScript = `local keys = redis.call('KEYS', ARGV[1]) ` +
+	`for i = 1, #keys, 1000 do redis.call('DEL', unpack(keys, i, math.min(i + 999, #keys))) end ` +
+	`return #keys`
+
+// TruncateStatement returns the command deleting every key of a pattern:
+// FLUSHDB for "*", a script deleting the matching keys otherwise
+func (db *Redis) TruncateStatement(table string) string {
+	if table == redisAllKeys {
+		return "FLUSHDB"
+	}
+	return "EVAL " + redisQuote(redisDeleteScript) + " 0 " + redisQuote(table)
+}
+
+// redisQuote quotes a command argument in double quotes when it is empty or
+// has spaces, quotes or backslashes, as redisArgs reads it back
+func redisQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\r\n\"'\\") {
+		return arg
+	}
+	quoted := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(arg)
+	return `"` + quoted + `"`
+}
+
+// redisArgs splits a command line into its arguments the way redis-cli does:
+// on spaces, with "double quoted" arguments taking \n, \r, \t, \" and \xHH
+// escapes and 'single quoted' ones only \'
+func redisArgs(line string) ([]any, error) {
+	var args []any
+	i := 0
+	for {
+		for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+			i++
+		}
+		if i == len(line) {
+			return args, nil
+		}
+
+		var arg strings.Builder
+		switch quote := line[i]; quote {
+		case '"', '\'':
+			i++
+			for {
+				if i == len(line) {
+					return nil, fmt.Errorf("unbalanced quotes in command")
+				}
+				c := line[i]
+				if c == quote {
+					i++
+					break
+				}
+				if c == '\\' && i+1 < len(line) {
+					next := line[i+1]
+					switch {
+					case quote == '\'':
+						if next == '\'' {
+							c = next
+							i++
+						}
+					case next == 'x' && i+3 < len(line):
+						if b, err := strconv.ParseUint(line[i+2:i+4], 16, 8); err == nil {
+							c = byte(b)
+							i += 3
+						} else {
+							c = next
+							i++
+						}
+					default:
+						c = map[byte]byte{'n': '\n', 'r': '\r', 't': '\t', 'b': '\b', 'a': '\a'}[next]
+						if c == 0 {
+							c = next
+						}
+						i++
+					}
+				}
+				arg.WriteByte(c)
+				i++
+			}
+			// A closing quote must end the argument
+			if i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				return nil, fmt.Errorf("closing quote must be followed by a space")
+			}
+		default:
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				arg.WriteByte(line[i])
+				i++
+			}
+		}
+		args = append(args, arg.String())
+	}
+}
+
+// redisMatch reports whether key matches a glob pattern as SCAN MATCH does:
+// * and ? wildcards, [abc], [^abc] and [a-z] classes, and \ escaping the next
+// character
+func redisMatch(pattern, key string) bool {
+	for pattern != "" {
+		switch pattern[0] {
+		case '*':
+			for pattern != "" && pattern[0] == '*' {
+				pattern = pattern[1:]
+			}
+			if pattern == "" {
+				return true
+			}
+			for i := range len(key) + 1 {
+				if redisMatch(pattern, key[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if key == "" {
+				return false
+			}
+			pattern, key = pattern[1:], key[1:]
+		case '[':
+			if key == "" {
+				return false
+			}
+			n, ok := redisMatchClass(pattern, key[0])
+			if !ok {
+				return false
+			}
+			pattern, key = pattern[n:], key[1:]
+		default:
+			if pattern[0] == '\\' && len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			if key == "" || key[0] != pattern[0] {
+				return false
+			}
+			pattern, key = pattern[1:], key[1:]
+		}
+	}
+	return key == ""
+}
+
+// redisMatchClass matches c against the [...] class pattern starts with,
+// returning the length of the class
+func redisMatchClass(pattern string, c byte) (int, bool) {
+	i := 1
+	negate := i < len(pattern) && pattern[i] == '^'
+	if negate {
+		i++
+	}
+	match := false
+	for ; i < len(pattern) && pattern[i] != ']'; i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			match = match || pattern[i] == c
+		case i+2 < len(pattern) && pattern[i+1] == '-' && pattern[i+2] != ']':
+			lo, hi := pattern[i], pattern[i+2]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			match = match || (c >= lo && c <= hi)
+			i += 2
+		default:
+			match = match || pattern[i] == c
+		}
+	}
+	// An unterminated class ends with the pattern
+	return min(i+1, len(pattern)), match != negate
+}
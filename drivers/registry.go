@@ -0,0 +1,96 @@
+package drivers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Factory creates a new, unconnected driver instance
+type Factory func() Driver
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a driver available under typeName. Drivers register
+// themselves from init; registering the same name twice panics.
+func Register(typeName string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		panic("drivers: Register factory is nil for " + typeName)
+	}
+	if _, dup := registry[typeName]; dup {
+		panic("drivers: Register called twice for " + typeName)
+	}
+	registry[typeName] = factory
+}
+
+// New creates a driver instance for typeName
+func New(typeName string) (Driver, error) {
+	registryMu.RLock()
+	factory, ok := registry[typeName]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported driver: %s", typeName)
+	}
+	return factory(), nil
+}
+
+// ConnectionOptions are the settings of a connection kept apart from its URL
+type ConnectionOptions struct {
+	SSH SSHTunnel
+	TLS TLSConfig
+}
+
+// NewWithOptions creates a driver instance for typeName that connects with opts,
+// failing when the driver can't use them
+func NewWithOptions(typeName string, opts ConnectionOptions) (Driver, error) {
+	driver, err := New(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.SSH.Enabled() {
+		tunneler, ok := driver.(SSHTunneler)
+		if !ok {
+			return nil, fmt.Errorf("%s connections can't use an SSH tunnel", typeName)
+		}
+		tunneler.SetSSHTunnel(opts.SSH)
+	}
+
+	if opts.TLS.Configured() {
+		configurer, ok := driver.(TLSConfigurer)
+		if !ok {
+			return nil, fmt.Errorf("%s connections can't use SSL", typeName)
+		}
+		configurer.SetTLSConfig(opts.TLS)
+	}
+	return driver, nil
+}
+
+// Registered returns the sorted names of all registered drivers
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsRegistered reports whether a driver is registered under typeName
+func IsRegistered(typeName string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	_, ok := registry[typeName]
+	return ok
+}
@@ -0,0 +1,129 @@
+package drivers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ExportSchema writes the CREATE statements of every table in database to w,
+// tables referenced by foreign keys before the tables referencing them. Tables
+// in a foreign key cycle can't be ordered and are written last, by name.
+// Views are left out. It returns the number of tables written.
+func ExportSchema(driver Driver, database string, w io.Writer) (int, error) {
+	tables, err := schemaTables(driver, database)
+	if err != nil {
+		return 0, err
+	}
+
+	// References to other tables of the export, self references don't constrain the order
+	references := make(map[string][]string, len(tables))
+	exported := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		exported[table] = true
+	}
+	for _, table := range tables {
+		relations, err := driver.GetRelationInfo(database, table)
+		if err != nil {
+			return 0, fmt.Errorf("foreign keys of %s: %w", table, err)
+		}
+		for _, rel := range relations {
+			if rel.ReferencedTable != table && exported[rel.ReferencedTable] {
+				references[table] = append(references[table], rel.ReferencedTable)
+			}
+		}
+	}
+
+	ordered, cyclic := dependencyOrder(tables, references)
+
+	if _, err := fmt.Fprintf(w, "-- Schema of %s, %d tables\n\n", database, len(tables)); err != nil {
+		return 0, err
+	}
+	for i, table := range append(ordered, cyclic...) {
+		if i == len(ordered) {
+			if _, err := fmt.Fprintf(w, "-- Tables with circular foreign keys, in name order\n\n"); err != nil {
+				return i, err
+			}
+		}
+
+		statements, err := driver.GetCreateStatements(database, table)
+		if err != nil {
+			return i, fmt.Errorf("create statement of %s: %w", table, err)
+		}
+		for _, statement := range statements {
+			if _, err := fmt.Fprintf(w, "%s;\n\n", strings.TrimRight(statement, "; \n")); err != nil {
+				return i, err
+			}
+		}
+	}
+
+	return len(tables), nil
+}
+
+// schemaTables returns the tables of database by name, without views
+func schemaTables(driver Driver, database string) ([]string, error) {
+	bySchema, err := driver.GetTables(database)
+	if err != nil {
+		return nil, err
+	}
+	views, err := driver.GetViews(database)
+	if err != nil {
+		return nil, err
+	}
+	isView := make(map[string]bool, len(views))
+	for _, view := range views {
+		isView[view] = true
+	}
+
+	seen := make(map[string]bool)
+	var tables []string
+	for schema, names := range bySchema {
+		for _, name := range names {
+			name = QualifiedTableName(driver, schema, name)
+			if !isView[name] && !seen[name] {
+				seen[name] = true
+				tables = append(tables, name)
+			}
+		}
+	}
+	sort.Strings(tables)
+	return tables, nil
+}
+
+// dependencyOrder sorts tables so each comes after the tables it references,
+// keeping name order otherwise. Tables left over by a reference cycle are
+// returned separately.
+func dependencyOrder(tables []string, references map[string][]string) (ordered, cyclic []string) {
+	done := make(map[string]bool, len(tables))
+	for len(ordered) < len(tables) {
+		progressed := false
+		for _, table := range tables {
+			if done[table] {
+				continue
+			}
+			ready := true
+			for _, ref := range references[table] {
+				if !done[ref] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				done[table] = true
+				ordered = append(ordered, table)
+				progressed = true
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+
+	for _, table := range tables {
+		if !done[table] {
+			cyclic = append(cyclic, table)
+		}
+	}
+	return ordered, cyclic
+}
@@ -0,0 +1,460 @@
+package drivers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sheenazien8/sq/logger"
+)
+
+// StatementResult is the outcome of one statement of a script
+type StatementResult struct {
+	Statement    string
+	Data         [][]string // Header row followed by data rows, nil if the statement returned no result set
+	RowsAffected int64      // Rows changed by statements without a result set
+	Warnings     []string   // Warnings the server raised for a statement without a result set
+	// TotalRows is how many rows the statement returns, or -1 when it is Limited
+	TotalRows int64
+	// Limited is set when the statement ends in a LIMIT its rows reached, so more
+	// rows may match; CountWithoutLimitQuery counts them
+	Limited bool
+}
+
+// trailingLimit matches a LIMIT clause ending a statement: LIMIT n, LIMIT m, n and
+// LIMIT n OFFSET m, capturing the first number and, in the LIMIT m, n form, the row count
+var trailingLimit = regexp.MustCompile(`(?is)\s+LIMIT\s+(\d+)(?:\s*,\s*(\d+)|\s+OFFSET\s+\d+)?\s*;?\s*$`)
+
+// warningsFunc reads the warnings of the last statement run on a connection
+type warningsFunc func(ctx context.Context, conn *sql.Conn) ([]string, error)
+
+// scriptOptions describe how a driver's scripts are split and run
+type scriptOptions struct {
+	// backslashEscapes makes a backslash escape the next character in strings, as in MySQL
+	backslashEscapes bool
+	// warnings, if not nil, collects the warnings of statements without a result set
+	warnings warningsFunc
+}
+
+// rowReturningKeywords are the leading keywords of statements that produce a result set
+var rowReturningKeywords = map[string]bool{
+	"SELECT":   true,
+	"WITH":     true,
+	"SHOW":     true,
+	"DESCRIBE": true,
+	"DESC":     true,
+	"EXPLAIN":  true,
+	"PRAGMA":   true,
+	"VALUES":   true,
+	"TABLE":    true,
+	"EXEC":     true, // SQL Server procedures, e.g. EXEC sp_help
+	"EXECUTE":  true,
+}
+
+// transactionKeywords are the words that may follow a BEGIN starting a transaction
+// rather than a block; "" stands for the end of the statement
+var transactionKeywords = map[string]bool{
+	"":            true,
+	"TRANSACTION": true,
+	"TRAN":        true,
+	"WORK":        true,
+	"DEFERRED":    true,
+	"IMMEDIATE":   true,
+	"EXCLUSIVE":   true,
+	"ISOLATION":   true,
+	"READ":        true,
+	"DISTRIBUTED": true,
+}
+
+// SplitStatements splits a script into its statements on semicolons, ignoring
+// semicolons inside quotes, comments, PostgreSQL dollar-quoted bodies and the
+// BEGIN … END and CASE … END blocks of trigger and procedure bodies.
+// backslashEscapes makes a backslash escape the next character in strings, as
+// in MySQL; PostgreSQL E'…' strings always take backslash escapes.
+func SplitStatements(script string, backslashEscapes bool) []string {
+	var statements []string
+	var current strings.Builder
+	depth := 0 // BEGIN and CASE blocks open at the current position
+	words := 0 // Words of the current statement so far
+
+	flush := func() {
+		if stmt := strings.TrimSpace(current.String()); stmt != "" {
+			statements = append(statements, stmt)
+		}
+		current.Reset()
+		depth, words = 0, 0
+	}
+
+	afterEnd := false
+	for i := 0; i < len(script); {
+		if end := skipLiteral(script, i, backslashEscapes); end > i {
+			current.WriteString(script[i:end])
+			i = end
+			continue
+		}
+
+		c := script[i]
+		if !isIdentifierByte(c) {
+			if c == ';' && depth == 0 {
+				flush()
+			} else {
+				current.WriteByte(c)
+			}
+			i++
+			continue
+		}
+
+		end := wordEnd(script, i)
+		word := strings.ToUpper(script[i:end])
+		current.WriteString(script[i:end])
+		i = end
+
+		switch word {
+		case "BEGIN":
+			// A statement starting with BEGIN starts a transaction, unless a
+			// block follows as in an Oracle anonymous block
+			if words > 0 || !transactionKeywords[nextWord(script, i)] {
+				depth++
+			}
+		case "CASE":
+			// The CASE of END CASE closes the block its END did
+			if !afterEnd {
+				depth++
+			}
+		case "END":
+			switch nextWord(script, i) {
+			case "IF", "LOOP", "WHILE", "REPEAT", "FOR":
+				// Closes a control statement, which opened no block
+			default:
+				depth = max(depth-1, 0)
+			}
+		}
+		afterEnd = word == "END"
+		words++
+	}
+	flush()
+
+	return statements
+}
+
+// skipLiteral returns the end of the quoted string or identifier, comment or
+// dollar-quoted body starting at i, or i when none starts there
+func skipLiteral(script string, i int, backslashEscapes bool) int {
+	c := script[i]
+	switch {
+	case c == '\'' || c == '"':
+		return quoteEnd(script, i, backslashEscapes)
+	case c == '`':
+		return quoteEnd(script, i, false)
+	case (c == 'E' || c == 'e') && i+1 < len(script) && script[i+1] == '\'' && (i == 0 || !isIdentifierByte(script[i-1])):
+		// PostgreSQL escape string
+		return quoteEnd(script, i+1, true)
+	case strings.HasPrefix(script[i:], "--"):
+		if end := strings.IndexByte(script[i:], '\n'); end != -1 {
+			return i + end
+		}
+		return len(script)
+	case strings.HasPrefix(script[i:], "/*"):
+		if end := strings.Index(script[i+2:], "*/"); end != -1 {
+			return i + end + 4
+		}
+		return len(script)
+	case c == '$':
+		tag := dollarQuoteTag(script[i:])
+		if tag == "" {
+			return i
+		}
+		if end := strings.Index(script[i+len(tag):], tag); end != -1 {
+			return i + end + 2*len(tag)
+		}
+		return len(script)
+	}
+	return i
+}
+
+// quoteEnd returns the end of the string or identifier whose opening quote is at i.
+// Doubled quotes stay inside it, and so do backslash-escaped ones when backslashEscapes is set.
+func quoteEnd(script string, i int, backslashEscapes bool) int {
+	quote := script[i]
+	for end := i + 1; end < len(script); end++ {
+		switch {
+		case script[end] == '\\' && backslashEscapes:
+			end++
+		case script[end] == quote:
+			if end+1 < len(script) && script[end+1] == quote {
+				end++
+				continue
+			}
+			return end + 1
+		}
+	}
+	return len(script)
+}
+
+// wordEnd returns the end of the word starting at i
+func wordEnd(s string, i int) int {
+	for i < len(s) && isIdentifierByte(s[i]) {
+		i++
+	}
+	return i
+}
+
+// nextWord returns the upper-cased word following i past whitespace and
+// comments, or "" when something other than a word follows
+func nextWord(s string, i int) string {
+	for i < len(s) {
+		switch {
+		case s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r':
+			i++
+		case strings.HasPrefix(s[i:], "--") || strings.HasPrefix(s[i:], "/*"):
+			i = skipLiteral(s, i, false)
+		default:
+			return strings.ToUpper(s[i:wordEnd(s, i)])
+		}
+	}
+	return ""
+}
+
+// dollarQuoteTag returns the dollar-quote opening tag ($$ or $name$) at the start of s, if any
+func dollarQuoteTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		if c == '$' {
+			return s[:i+1]
+		}
+		if !(c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 1 && c >= '0' && c <= '9')) {
+			return ""
+		}
+	}
+	return ""
+}
+
+// StatementKeyword returns the upper-cased first keyword of a statement, skipping leading comments
+func StatementKeyword(statement string) string {
+	s := strings.TrimSpace(statement)
+	for {
+		switch {
+		case strings.HasPrefix(s, "--"):
+			if end := strings.IndexByte(s, '\n'); end != -1 {
+				s = strings.TrimSpace(s[end+1:])
+				continue
+			}
+			return ""
+		case strings.HasPrefix(s, "/*"):
+			if end := strings.Index(s, "*/"); end != -1 {
+				s = strings.TrimSpace(s[end+2:])
+				continue
+			}
+			return ""
+		}
+		break
+	}
+
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '('
+	})
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// returnsRows guesses whether a statement produces a result set: it starts with
+// a row-returning keyword or has a RETURNING clause
+func returnsRows(statement string, backslashEscapes bool) bool {
+	if rowReturningKeywords[StatementKeyword(statement)] {
+		return true
+	}
+	for i := 0; i < len(statement); {
+		if end := skipLiteral(statement, i, backslashEscapes); end > i {
+			i = end
+			continue
+		}
+		if !isIdentifierByte(statement[i]) {
+			i++
+			continue
+		}
+		end := wordEnd(statement, i)
+		if strings.EqualFold(statement[i:end], "RETURNING") {
+			return true
+		}
+		i = end
+	}
+	return false
+}
+
+// executeScript runs each statement of a script in order, stopping at the first failure.
+// The results of the statements that ran before the failure are returned with the error.
+// All statements share one connection, so session state and warnings carry across them.
+// Cancelling ctx stops the running statement and fails the script with ctx's error.
+func executeScript(ctx context.Context, db *sql.DB, script string, opts scriptOptions) ([]StatementResult, error) {
+	statements := SplitStatements(script, opts.backslashEscapes)
+	logger.Debug("Executing script", map[string]any{
+		"statements": len(statements),
+	})
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	results := make([]StatementResult, 0, len(statements))
+	for i, stmt := range statements {
+		result, err := executeStatement(ctx, conn, stmt, opts.backslashEscapes)
+		if err != nil {
+			if len(statements) > 1 {
+				err = fmt.Errorf("statement %d: %w", i+1, err)
+			}
+			return results, err
+		}
+		if result.Data != nil {
+			result.TotalRows, result.Limited = limitedTotal(result)
+		}
+		if opts.warnings != nil && result.Data == nil {
+			// Failing to read warnings must not fail a statement that succeeded
+			if result.Warnings, err = opts.warnings(ctx, conn); err != nil {
+				logger.Warn("Failed to read statement warnings", map[string]any{"error": err.Error()})
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// limitedTotal returns the rows a statement returned as its total, or -1 and
+// true when it ends in a LIMIT the rows reached. Nothing is counted: the rows
+// matching without the LIMIT may take a full scan to count.
+func limitedTotal(result StatementResult) (int64, bool) {
+	loaded := int64(len(result.Data) - 1)
+
+	keyword := StatementKeyword(result.Statement)
+	if keyword != "SELECT" && keyword != "WITH" {
+		return loaded, false
+	}
+	match := trailingLimit.FindStringSubmatch(result.Statement)
+	if match == nil {
+		return loaded, false
+	}
+	limit := match[1]
+	if match[2] != "" {
+		// MySQL's LIMIT offset, count
+		limit = match[2]
+	}
+	if n, err := strconv.ParseInt(limit, 10, 64); err == nil && loaded < n {
+		return loaded, false
+	}
+	return -1, true
+}
+
+// CountWithoutLimitQuery returns the query counting the rows a SELECT ending in
+// LIMIT matches without its LIMIT, or false for other statements
+func CountWithoutLimitQuery(statement string) (string, bool) {
+	keyword := StatementKeyword(statement)
+	if keyword != "SELECT" && keyword != "WITH" {
+		return "", false
+	}
+	loc := trailingLimit.FindStringIndex(statement)
+	if loc == nil {
+		return "", false
+	}
+	return "SELECT COUNT(*) FROM (" + statement[:loc[0]] + ") sq_count", true
+}
+
+// executeStatement runs a single statement, collecting its rows or its affected row count
+func executeStatement(ctx context.Context, conn *sql.Conn, stmt string, backslashEscapes bool) (StatementResult, error) {
+	result := StatementResult{Statement: stmt}
+
+	if !returnsRows(stmt, backslashEscapes) {
+		res, err := conn.ExecContext(ctx, stmt)
+		if err != nil {
+			return result, err
+		}
+		// Not every driver reports affected rows for every statement
+		if affected, err := res.RowsAffected(); err == nil {
+			result.RowsAffected = affected
+		}
+		return result, nil
+	}
+
+	rows, err := conn.QueryContext(ctx, stmt)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return result, err
+	}
+	if len(columnTypes) == 0 {
+		return result, rows.Err()
+	}
+	columns := make([]string, len(columnTypes))
+	for i, ct := range columnTypes {
+		columns[i] = ct.Name()
+	}
+
+	data := [][]string{columns}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		valuePtrs := make([]interface{}, len(columns))
+		for i := range columns {
+			valuePtrs[i] = &values[i]
+		}
+
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return result, err
+		}
+
+		row := make([]string, len(columns))
+		for i, val := range values {
+			row[i] = formatColumnValue(val, columnTypes[i].DatabaseTypeName())
+		}
+		data = append(data, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	result.Data = data
+	return result, nil
+}
+
+// execWithArgs runs a statement with args bound to its placeholders and
+// returns the number of rows it affected
+func execWithArgs(db *sql.DB, query string, args []any) (int64, error) {
+	logger.Debug("Executing statement", map[string]any{
+		"query": query,
+		"args":  len(args),
+	})
+
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// executeInTransaction runs statements in a single transaction, rolling it back
+// at the first failure
+func executeInTransaction(db *sql.DB, statements []string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				logger.Warn("Failed to roll back transaction", map[string]any{"error": rbErr.Error()})
+			}
+			return err
+		}
+	}
+	return tx.Commit()
+}
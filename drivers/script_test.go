@@ -0,0 +1,108 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name             string
+		script           string
+		backslashEscapes bool
+		want             []string
+	}{
+		{"single", "SELECT 1", false, []string{"SELECT 1"}},
+		{"several", "SELECT 1; SELECT 2;\n", false, []string{"SELECT 1", "SELECT 2"}},
+		{"empty statements", ";; SELECT 1 ;;", false, []string{"SELECT 1"}},
+		{"semicolon in string", "SELECT 'a;b'; SELECT 2", false, []string{"SELECT 'a;b'", "SELECT 2"}},
+		{"doubled quote", "SELECT 'it''s; here'; SELECT 2", false, []string{"SELECT 'it''s; here'", "SELECT 2"}},
+		{"semicolon in identifiers", "SELECT \"a;b\", `c;d`; SELECT 2", false, []string{"SELECT \"a;b\", `c;d`", "SELECT 2"}},
+		{"backslash escapes a quote", `SELECT 'a\';b'; SELECT 2`, true, []string{`SELECT 'a\';b'`, "SELECT 2"}},
+		{"backslash is literal", `SELECT 'C:\'; SELECT 2`, false, []string{`SELECT 'C:\'`, "SELECT 2"}},
+		{"backslash literal in backticks", "SELECT `a\\`; SELECT 2", true, []string{"SELECT `a\\`", "SELECT 2"}},
+		{"escape string", `SELECT E'a\';b'; SELECT 2`, false, []string{`SELECT E'a\';b'`, "SELECT 2"}},
+		{"line comment", "SELECT 1 -- one; two\n; SELECT 2", false, []string{"SELECT 1 -- one; two", "SELECT 2"}},
+		{"block comment", "SELECT /* ; */ 1; SELECT 2", false, []string{"SELECT /* ; */ 1", "SELECT 2"}},
+		{"unterminated string", "SELECT 'a;b", false, []string{"SELECT 'a;b"}},
+		{"dollar quotes", "CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql; SELECT 2", false,
+			[]string{"CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql", "SELECT 2"}},
+		{"tagged dollar quotes", "SELECT $body$ a; $$ b $body$; SELECT $1", false, []string{"SELECT $body$ a; $$ b $body$", "SELECT $1"}},
+		{"trigger body",
+			"CREATE TRIGGER t AFTER INSERT ON a BEGIN UPDATE b SET n = n + 1; DELETE FROM c; END; SELECT 2", false,
+			[]string{"CREATE TRIGGER t AFTER INSERT ON a BEGIN UPDATE b SET n = n + 1; DELETE FROM c; END", "SELECT 2"}},
+		{"procedure with control statements",
+			"CREATE PROCEDURE p() BEGIN IF x THEN SET y = 1; END IF; WHILE y < 3 DO SET y = y + 1; END WHILE; END; CALL p()", true,
+			[]string{"CREATE PROCEDURE p() BEGIN IF x THEN SET y = 1; END IF; WHILE y < 3 DO SET y = y + 1; END WHILE; END", "CALL p()"}},
+		{"nested blocks",
+			"CREATE PROCEDURE p() BEGIN BEGIN SELECT 1; END; SELECT 2; END; SELECT 3", true,
+			[]string{"CREATE PROCEDURE p() BEGIN BEGIN SELECT 1; END; SELECT 2; END", "SELECT 3"}},
+		{"case statement",
+			"CREATE PROCEDURE p() BEGIN CASE x WHEN 1 THEN SELECT 1; ELSE SELECT 2; END CASE; END; SELECT 3", true,
+			[]string{"CREATE PROCEDURE p() BEGIN CASE x WHEN 1 THEN SELECT 1; ELSE SELECT 2; END CASE; END", "SELECT 3"}},
+		{"case expression", "SELECT CASE WHEN a THEN 1 END FROM t; SELECT 2", false,
+			[]string{"SELECT CASE WHEN a THEN 1 END FROM t", "SELECT 2"}},
+		{"transaction", "BEGIN; INSERT INTO t VALUES (1); COMMIT", false, []string{"BEGIN", "INSERT INTO t VALUES (1)", "COMMIT"}},
+		{"named transaction", "BEGIN TRANSACTION; END;", false, []string{"BEGIN TRANSACTION", "END"}},
+		{"anonymous block", "BEGIN\n  UPDATE t SET a = 1;\nEND;\nSELECT 2", false, []string{"BEGIN\n  UPDATE t SET a = 1;\nEND", "SELECT 2"}},
+		{"keywords in strings and identifiers", "SELECT 'begin', begin_at, \"case\" FROM t; SELECT 2", false,
+			[]string{"SELECT 'begin', begin_at, \"case\" FROM t", "SELECT 2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitStatements(tt.script, tt.backslashEscapes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitStatements(%q) = %q, want %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatementKeyword(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      string
+	}{
+		{"select 1", "SELECT"},
+		{"  \n\tInsert INTO t VALUES (1)", "INSERT"},
+		{"(SELECT 1) UNION (SELECT 2)", "SELECT"},
+		{"-- comment\nUPDATE t SET a = 1", "UPDATE"},
+		{"/* a */ /* b */ DELETE FROM t", "DELETE"},
+		{"-- only a comment", ""},
+		{"/* unterminated", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := StatementKeyword(tt.statement); got != tt.want {
+			t.Errorf("StatementKeyword(%q) = %q, want %q", tt.statement, got, tt.want)
+		}
+	}
+}
+
+func TestReturnsRows(t *testing.T) {
+	tests := []struct {
+		statement        string
+		backslashEscapes bool
+		want             bool
+	}{
+		{"SELECT 1", false, true},
+		{"with x AS (SELECT 1) SELECT * FROM x", false, true},
+		{"-- read\nSHOW TABLES", false, true},
+		{"UPDATE t SET a = 1", false, false},
+		{"INSERT INTO t VALUES (1) RETURNING id", false, true},
+		{"DELETE FROM t\nreturning *", false, true},
+		{"INSERT INTO t VALUES ('RETURNING')", false, false},
+		{"INSERT INTO t VALUES ('it''s RETURNING')", false, false},
+		{`INSERT INTO t VALUES ('it\'s RETURNING')`, true, false},
+		{`INSERT INTO t VALUES (E'it\'s RETURNING')`, false, false},
+		{"UPDATE t SET a = 1 -- RETURNING a", false, false},
+		{"UPDATE t SET a = 1 /* RETURNING a */", false, false},
+		{`UPDATE t SET "returning" = 1`, false, false},
+		{"UPDATE t SET returning_at = 1", false, false},
+		{"UPDATE t SET a = $$RETURNING$$", false, false},
+	}
+	for _, tt := range tests {
+		if got := returnsRows(tt.statement, tt.backslashEscapes); got != tt.want {
+			t.Errorf("returnsRows(%q) = %v, want %v", tt.statement, got, tt.want)
+		}
+	}
+}
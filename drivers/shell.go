@@ -0,0 +1,198 @@
+package drivers
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ShellCommand returns the native command line client of a driver type connected
+// with the credentials of urlstr: mysql, psql, sqlcmd, redis-cli, sqlplus or
+// sqlite3. Passwords are passed in the environment rather than on the command
+// line; sqlplus, which reads none from it, asks for the password instead.
+// tlsConfig, when configured, is passed on as the client's SSL options.
+func ShellCommand(driverType, urlstr string, tlsConfig TLSConfig) (*exec.Cmd, error) {
+	var name string
+	var args, env []string
+
+	switch driverType {
+	case DriverTypeMySQL:
+		u, err := url.Parse(urlstr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MySQL URL: %w", err)
+		}
+		name = "mysql"
+		if host := u.Hostname(); host != "" {
+			args = append(args, "--host="+host)
+		}
+		if port := u.Port(); port != "" {
+			args = append(args, "--port="+port)
+		}
+		if u.User != nil {
+			args = append(args, "--user="+u.User.Username())
+			if password, ok := u.User.Password(); ok {
+				env = append(env, "MYSQL_PWD="+password)
+			}
+		}
+		args = append(args, mysqlSSLArgs(tlsConfig)...)
+		if database := strings.TrimPrefix(u.Path, "/"); database != "" {
+			args = append(args, database)
+		}
+
+	case DriverTypePostgreSQL:
+		urlstr, err := postgresTLSURL(urlstr, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		u, err := url.Parse(urlstr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PostgreSQL URL: %w", err)
+		}
+		name = "psql"
+		// psql only knows the postgresql scheme, not the aliases the URL may use
+		u.Scheme = "postgresql"
+		if u.User != nil {
+			if password, ok := u.User.Password(); ok {
+				env = append(env, "PGPASSWORD="+password)
+			}
+			u.User = url.User(u.User.Username())
+		}
+		args = append(args, u.String())
+
+	case DriverTypeMSSQL:
+		u, err := url.Parse(urlstr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SQL Server URL: %w", err)
+		}
+		name = "sqlcmd"
+		// sqlcmd takes the port after a comma
+		server := u.Hostname()
+		if port := u.Port(); port != "" {
+			server += "," + port
+		}
+		args = append(args, "-S", server)
+		if u.User != nil {
+			args = append(args, "-U", u.User.Username())
+			if password, ok := u.User.Password(); ok {
+				env = append(env, "SQLCMDPASSWORD="+password)
+			}
+		}
+		args = append(args, mssqlSSLArgs(tlsConfig)...)
+		if database := MSSQLDatabase(urlstr); database != "" {
+			args = append(args, "-d", database)
+		}
+
+	case DriverTypeRedis:
+		u, err := url.Parse(urlstr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Redis URL: %w", err)
+		}
+		name = "redis-cli"
+		args = append(args, "-h", u.Hostname())
+		if port := u.Port(); port != "" {
+			args = append(args, "-p", port)
+		}
+		if u.User != nil {
+			if username := u.User.Username(); username != "" {
+				args = append(args, "--user", username)
+			}
+			if password, ok := u.User.Password(); ok {
+				env = append(env, "REDISCLI_AUTH="+password)
+			}
+		}
+		if tlsConfig.Configured() {
+			args = append(args, redisSSLArgs(tlsConfig)...)
+		} else if u.Scheme == "rediss" {
+			args = append(args, "--tls")
+		}
+		args = append(args, "-n", RedisDatabase(urlstr))
+
+	case DriverTypeOracle:
+		u, err := url.Parse(urlstr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Oracle URL: %w", err)
+		}
+		name = "sqlplus"
+		// Easy Connect: user@//host:port/service, the password is prompted for
+		connect := "//" + u.Host + u.Path
+		if u.User != nil {
+			connect = u.User.Username() + "@" + connect
+		}
+		args = append(args, "-L", connect)
+
+	case DriverTypeSQLite:
+		filePath, err := SQLiteFilePath(urlstr)
+		if err != nil {
+			return nil, err
+		}
+		name = "sqlite3"
+		args = append(args, filePath)
+
+	default:
+		return nil, fmt.Errorf("no shell for driver %s", driverType)
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s not found in PATH", name)
+	}
+	cmd := exec.Command(path, args...)
+	cmd.Env = append(os.Environ(), env...)
+	return cmd, nil
+}
+
+// mysqlSSLArgs returns the mysql client options for tlsConfig
+func mysqlSSLArgs(tlsConfig TLSConfig) []string {
+	if !tlsConfig.Configured() {
+		return nil
+	}
+	modes := map[string]string{
+		TLSModeDisable:    "DISABLED",
+		TLSModeRequire:    "REQUIRED",
+		TLSModeVerifyCA:   "VERIFY_CA",
+		TLSModeVerifyFull: "VERIFY_IDENTITY",
+	}
+	args := []string{"--ssl-mode=" + modes[tlsConfig.Mode]}
+	if !tlsConfig.Encrypted() {
+		return args
+	}
+	if tlsConfig.CAPath != "" {
+		args = append(args, "--ssl-ca="+expandHomePath(tlsConfig.CAPath))
+	}
+	if tlsConfig.CertPath != "" {
+		args = append(args, "--ssl-cert="+expandHomePath(tlsConfig.CertPath), "--ssl-key="+expandHomePath(tlsConfig.KeyPath))
+	}
+	return args
+}
+
+// mssqlSSLArgs returns the sqlcmd options for tlsConfig. sqlcmd checks the
+// server certificate against the system CAs, so require trusts it instead.
+func mssqlSSLArgs(tlsConfig TLSConfig) []string {
+	if !tlsConfig.Encrypted() {
+		return nil
+	}
+	if tlsConfig.Mode == TLSModeRequire {
+		return []string{"-N", "-C"}
+	}
+	return []string{"-N"}
+}
+
+// redisSSLArgs returns the redis-cli options for tlsConfig. redis-cli checks the
+// server certificate unless told not to, so require without a CA skips the check.
+func redisSSLArgs(tlsConfig TLSConfig) []string {
+	if !tlsConfig.Encrypted() {
+		return nil
+	}
+	args := []string{"--tls"}
+	if tlsConfig.CAPath != "" {
+		args = append(args, "--cacert", expandHomePath(tlsConfig.CAPath))
+	} else if tlsConfig.Mode == TLSModeRequire {
+		args = append(args, "--insecure")
+	}
+	if tlsConfig.CertPath != "" {
+		args = append(args, "--cert", expandHomePath(tlsConfig.CertPath), "--key", expandHomePath(tlsConfig.KeyPath))
+	}
+	return args
+}
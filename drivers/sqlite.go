@@ -1,8 +1,10 @@
 package drivers
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -16,23 +18,22 @@ type SQLite struct {
 	FilePath   string // Path to SQLite database file
 }
 
+func init() {
+	Register(DriverTypeSQLite, func() Driver { return &SQLite{} })
+}
+
 func (db *SQLite) Connect(urlstr string) error {
 	db.SetProvider(DriverSQLite)
 
 	// SQLite URL format: sqlite:///path/to/database.db or file:path/to/database.db
-	// We need to extract the file path from the URL
-	filePath := strings.TrimPrefix(urlstr, "sqlite://")
-	filePath = strings.TrimPrefix(filePath, "file:")
-	filePath = strings.TrimPrefix(filePath, "//")
-
-	if filePath == "" {
-		return fmt.Errorf("SQLite database file path is required")
+	filePath, err := SQLiteFilePath(urlstr)
+	if err != nil {
+		return err
 	}
 
 	db.FilePath = filePath
 
-	var err error
-	db.Connection, err = sql.Open("sqlite", "file:"+filePath)
+	db.Connection, err = sql.Open("sqlite", sqliteDSN(urlstr, filePath))
 	if err != nil {
 		return err
 	}
@@ -60,21 +61,82 @@ func (db *SQLite) SetProvider(provider string) {
 }
 
 func (db *SQLite) TestConnection(urlstr string) error {
-	filePath := strings.TrimPrefix(urlstr, "sqlite://")
-	filePath = strings.TrimPrefix(filePath, "file:")
-	filePath = strings.TrimPrefix(filePath, "//")
+	return db.TestConnectionContext(context.Background(), urlstr)
+}
 
-	if filePath == "" {
-		return fmt.Errorf("SQLite database file path is required")
+// TestConnectionContext connects and pings the database, giving up when ctx is cancelled
+func (db *SQLite) TestConnectionContext(ctx context.Context, urlstr string) error {
+	conn, err := openSQLiteURL(urlstr)
+	if err != nil {
+		return err
 	}
+	defer conn.Close()
+
+	return conn.PingContext(ctx)
+}
 
-	conn, err := sql.Open("sqlite", "file:"+filePath)
+// ServerVersion opens a short-lived connection and returns the SQLite library version
+func (db *SQLite) ServerVersion(urlstr string) (string, error) {
+	conn, err := openSQLiteURL(urlstr)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer conn.Close()
 
-	return conn.Ping()
+	var version string
+	if err := conn.QueryRow("SELECT sqlite_version()").Scan(&version); err != nil {
+		return "", err
+	}
+	return "SQLite " + version, nil
+}
+
+// openSQLiteURL opens the database file referenced by a sqlite:// URL
+func openSQLiteURL(urlstr string) (*sql.DB, error) {
+	filePath, err := SQLiteFilePath(urlstr)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.Open("sqlite", sqliteDSN(urlstr, filePath))
+}
+
+// SQLiteURL builds a sqlite:// URL for a database file. Characters that would
+// otherwise end the path, like "?" and "#", are percent-encoded.
+func SQLiteURL(filePath string) string {
+	return "sqlite://" + (&url.URL{Path: filePath}).EscapedPath()
+}
+
+// SQLiteFilePath returns the database file path of a sqlite:// or file: URL.
+// Percent-encoded characters are decoded and anything after an unencoded "?"
+// is treated as query parameters, so paths may contain spaces, and a literal
+// "?" or "%" is written as %3F or %25.
+func SQLiteFilePath(urlstr string) (string, error) {
+	rest := strings.TrimPrefix(urlstr, "sqlite://")
+	rest = strings.TrimPrefix(rest, "file:")
+	rest = strings.TrimPrefix(rest, "//")
+
+	rawPath, _, _ := strings.Cut(rest, "?")
+	rawPath, _, _ = strings.Cut(rawPath, "#")
+
+	filePath, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid SQLite database path %q (write a literal %% as %%25): %w", rawPath, err)
+	}
+	if filePath == "" {
+		return "", fmt.Errorf("SQLite database file path is required")
+	}
+	return filePath, nil
+}
+
+// sqliteDSN returns the driver DSN for the database file of urlstr, keeping the
+// URL's query parameters. The DSN is an SQLite URI, so the path is escaped to
+// keep "?", "#" and "%" part of the file name.
+func sqliteDSN(urlstr, filePath string) string {
+	dsn := "file:" + (&url.URL{Path: filePath}).EscapedPath()
+	if _, query, ok := strings.Cut(urlstr, "?"); ok && query != "" {
+		dsn += "?" + query
+	}
+	return dsn
 }
 
 // QuoteIdentifier quotes an identifier for SQLite (uses double quotes)
@@ -82,12 +144,26 @@ func (db *SQLite) QuoteIdentifier(identifier string) string {
 	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
 }
 
-// GetTables returns all tables in the SQLite database
+// ContainsClause returns a LIKE clause matching column values containing value
+func (db *SQLite) ContainsClause(column, value string) string {
+	pattern := strings.ReplaceAll(EscapeLike(value), "'", "''")
+	return fmt.Sprintf(`%s LIKE '%%%s%%' ESCAPE '\'`, db.QuoteIdentifier(column), pattern)
+}
+
+// Close closes the database connection
+func (db *SQLite) Close() error {
+	if db.Connection == nil {
+		return nil
+	}
+	return db.Connection.Close()
+}
+
+// GetTables returns all tables and views in the SQLite database
 // For SQLite, there's no concept of "databases" within a file, so we use the file name as database
 func (db *SQLite) GetTables(database string) (map[string][]string, error) {
 	query := `
 		SELECT name FROM sqlite_master 
-		WHERE type='table' AND name NOT LIKE 'sqlite_%'
+		WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%'
 		ORDER BY name
 	`
 
@@ -113,6 +189,32 @@ func (db *SQLite) GetTables(database string) (map[string][]string, error) {
 	return tables, nil
 }
 
+// GetViews returns the names of all views in the SQLite database
+func (db *SQLite) GetViews(database string) ([]string, error) {
+	query := "SELECT name FROM sqlite_master WHERE type = 'view' ORDER BY name"
+
+	rows, err := db.Connection.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []string
+	for rows.Next() {
+		var viewName string
+		if err := rows.Scan(&viewName); err != nil {
+			return nil, err
+		}
+		views = append(views, viewName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
 // GetTableColumns returns column information for a table
 func (db *SQLite) GetTableColumns(database, table string) ([][]string, error) {
 	query := fmt.Sprintf("PRAGMA table_info(%s)", quoteIdentifier(table))
@@ -142,7 +244,7 @@ func (db *SQLite) GetTableColumns(database, table string) ([][]string, error) {
 		}
 
 		columnKey := ""
-		if pk == 1 {
+		if pk > 0 {
 			columnKey = "PRI"
 		}
 
@@ -271,11 +373,23 @@ func (db *SQLite) GetTableDataWithFilter(database, table string, whereClause str
 
 // GetTableDataPaginated returns paginated table data
 func (db *SQLite) GetTableDataPaginated(database, table string, pagination Pagination) (*PaginatedResult, error) {
-	// Get total count
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteIdentifier(table))
-	var totalRows int
-	if err := db.Connection.QueryRow(countQuery).Scan(&totalRows); err != nil {
-		return nil, err
+	return db.GetTableDataPaginatedContext(context.Background(), database, table, pagination)
+}
+
+// GetTableDataPaginatedContext is like GetTableDataPaginated but stops when ctx is cancelled
+func (db *SQLite) GetTableDataPaginatedContext(ctx context.Context, database, table string, pagination Pagination) (*PaginatedResult, error) {
+	// Get total count, from table statistics when an estimate was asked for
+	totalRows, totalApprox := -1, false
+	if pagination.EstimateTotal {
+		if estimate, err := db.GetEstimatedRowCount(database, table); err == nil && estimate >= 0 {
+			totalRows, totalApprox = int(estimate), true
+		}
+	}
+	if !totalApprox {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteIdentifier(table))
+		if err := db.Connection.QueryRowContext(ctx, countQuery).Scan(&totalRows); err != nil {
+			return nil, err
+		}
 	}
 
 	// Calculate offset
@@ -287,14 +401,8 @@ func (db *SQLite) GetTableDataPaginated(database, table string, pagination Pagin
 	// Get paginated data
 	query := fmt.Sprintf("SELECT * FROM %s", quoteIdentifier(table))
 
-	// Add ORDER BY if sort column is specified
-	if pagination.SortColumn != "" {
-		sortOrder := pagination.SortOrder
-		if sortOrder != "DESC" {
-			sortOrder = "ASC"
-		}
-		query += fmt.Sprintf(" ORDER BY %s %s", quoteIdentifier(pagination.SortColumn), sortOrder)
-	}
+	// Add ORDER BY if a sort order is specified
+	query += orderByClause(pagination, db.QuoteIdentifier)
 
 	query += " LIMIT " + strconv.Itoa(pagination.PageSize) + " OFFSET " + strconv.Itoa(offset)
 
@@ -306,7 +414,7 @@ func (db *SQLite) GetTableDataPaginated(database, table string, pagination Pagin
 		"totalRows": totalRows,
 	})
 
-	rows, err := db.Connection.Query(query)
+	rows, err := db.Connection.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -354,16 +462,22 @@ func (db *SQLite) GetTableDataPaginated(database, table string, pagination Pagin
 	}
 
 	return &PaginatedResult{
-		Data:       data,
-		TotalRows:  totalRows,
-		Page:       pagination.Page,
-		PageSize:   pagination.PageSize,
-		TotalPages: totalPages,
+		Data:        data,
+		TotalRows:   totalRows,
+		Page:        pagination.Page,
+		PageSize:    pagination.PageSize,
+		TotalPages:  totalPages,
+		TotalApprox: totalApprox,
 	}, nil
 }
 
 // GetTableDataWithFilterPaginated returns paginated and filtered table data
 func (db *SQLite) GetTableDataWithFilterPaginated(database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
+	return db.GetTableDataWithFilterPaginatedContext(context.Background(), database, table, whereClause, pagination)
+}
+
+// GetTableDataWithFilterPaginatedContext is like GetTableDataWithFilterPaginated but stops when ctx is cancelled
+func (db *SQLite) GetTableDataWithFilterPaginatedContext(ctx context.Context, database, table string, whereClause string, pagination Pagination) (*PaginatedResult, error) {
 	baseQuery := fmt.Sprintf("SELECT * FROM %s", quoteIdentifier(table))
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteIdentifier(table))
 
@@ -375,7 +489,7 @@ func (db *SQLite) GetTableDataWithFilterPaginated(database, table string, whereC
 
 	// Get total count with filters
 	var totalRows int
-	if err := db.Connection.QueryRow(countQuery).Scan(&totalRows); err != nil {
+	if err := db.Connection.QueryRowContext(ctx, countQuery).Scan(&totalRows); err != nil {
 		return nil, err
 	}
 
@@ -385,14 +499,8 @@ func (db *SQLite) GetTableDataWithFilterPaginated(database, table string, whereC
 	// Build final query with pagination
 	query := baseQuery
 
-	// Add ORDER BY if sort column is specified
-	if pagination.SortColumn != "" {
-		sortOrder := pagination.SortOrder
-		if sortOrder != "DESC" {
-			sortOrder = "ASC"
-		}
-		query += fmt.Sprintf(" ORDER BY %s %s", quoteIdentifier(pagination.SortColumn), sortOrder)
-	}
+	// Add ORDER BY if a sort order is specified
+	query += orderByClause(pagination, db.QuoteIdentifier)
 
 	query += " LIMIT " + strconv.Itoa(pagination.PageSize) + " OFFSET " + strconv.Itoa(offset)
 
@@ -404,7 +512,7 @@ func (db *SQLite) GetTableDataWithFilterPaginated(database, table string, whereC
 		"totalRows": totalRows,
 	})
 
-	rows, err := db.Connection.Query(query)
+	rows, err := db.Connection.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -494,7 +602,8 @@ func (db *SQLite) GetTableStructure(database, table string) (*TableStructure, er
 
 // GetColumnInfo returns detailed column information for a table
 func (db *SQLite) GetColumnInfo(database, table string) ([]ColumnInfo, error) {
-	query := fmt.Sprintf("PRAGMA table_info(%s)", quoteIdentifier(table))
+	// table_xinfo also lists generated columns, flagged in its hidden column
+	query := fmt.Sprintf("PRAGMA table_xinfo(%s)", quoteIdentifier(table))
 
 	rows, err := db.Connection.Query(query)
 	if err != nil {
@@ -510,18 +619,32 @@ func (db *SQLite) GetColumnInfo(database, table string) ([]ColumnInfo, error) {
 		var notnull int
 		var defaultValue sql.NullString
 		var pk int
+		var hidden int
 
-		if err := rows.Scan(&cid, &name, &dataType, &notnull, &defaultValue, &pk); err != nil {
+		if err := rows.Scan(&cid, &name, &dataType, &notnull, &defaultValue, &pk, &hidden); err != nil {
 			return nil, err
 		}
 
+		// 1 is a hidden column of a virtual table, which table_info leaves out too
+		if hidden == 1 {
+			continue
+		}
+
 		col := ColumnInfo{
 			Name:         name,
 			DataType:     dataType,
 			Nullable:     notnull == 0,
-			IsPrimaryKey: pk == 1,
+			IsPrimaryKey: pk > 0,
 			DefaultValue: defaultValue.String,
 		}
+		switch hidden {
+		case 2:
+			col.Extra = "VIRTUAL GENERATED"
+			col.Generated = true
+		case 3:
+			col.Extra = "STORED GENERATED"
+			col.Generated = true
+		}
 
 		columns = append(columns, col)
 	}
@@ -687,13 +810,237 @@ func (db *SQLite) GetTriggerInfo(database, table string) ([]TriggerInfo, error)
 	return triggers, rows.Err()
 }
 
+// GetDependencies returns the views, foreign keys and triggers that depend on a table.
+// SQLite does not track view dependencies, so views are matched on the identifiers
+// of their SQL.
+func (db *SQLite) GetDependencies(database, table string) ([]DependencyInfo, error) {
+	query := `
+		SELECT name, type, tbl_name, COALESCE(sql, '') FROM sqlite_master
+		WHERE type = 'view'
+			OR (type = 'trigger' AND tbl_name = ?)
+			OR type = 'table'
+		ORDER BY type DESC, name
+	`
+
+	rows, err := db.Connection.Query(query, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var deps []DependencyInfo
+	var tables []string
+	for rows.Next() {
+		var name, objType, tableName, definition string
+
+		if err := rows.Scan(&name, &objType, &tableName, &definition); err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		switch objType {
+		case "view":
+			if referencesIdentifier(definition, table) {
+				deps = append(deps, DependencyInfo{Name: name, Type: DependencyTypeView, Table: name})
+			}
+		case "trigger":
+			deps = append(deps, DependencyInfo{Name: name, Type: DependencyTypeTrigger, Table: tableName})
+		case "table":
+			tables = append(tables, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	// Foreign keys are only visible from the referencing side
+	for _, t := range tables {
+		relations, err := db.GetRelationInfo(database, t)
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range relations {
+			if strings.EqualFold(rel.ReferencedTable, table) {
+				deps = append(deps, DependencyInfo{
+					Name:   rel.Name,
+					Type:   DependencyTypeForeignKey,
+					Table:  t,
+					Detail: rel.Column,
+				})
+			}
+		}
+	}
+
+	return deps, nil
+}
+
+// referencesIdentifier reports whether the SQL statement names identifier, bare
+// or quoted in any of the ways SQLite accepts, outside string literals and
+// comments. Identifiers compare case-insensitively, as SQLite resolves them.
+func referencesIdentifier(statement, identifier string) bool {
+	for i := 0; i < len(statement); {
+		c := statement[i]
+		switch {
+		case c == '\'':
+			i = skipQuoted(statement, i, '\'')
+		case c == '-' && strings.HasPrefix(statement[i:], "--"):
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				return false
+			}
+			i += end + 1
+		case c == '/' && strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				return false
+			}
+			i += end + 4
+		case c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			end := skipQuoted(statement, i, closing)
+			if end == len(statement) && statement[end-1] != closing || end-i < 2 {
+				return false
+			}
+			name := statement[i+1 : end-1]
+			if closing != ']' {
+				name = strings.ReplaceAll(name, string([]byte{closing, closing}), string(closing))
+			}
+			if strings.EqualFold(name, identifier) {
+				return true
+			}
+			i = end
+		case isIdentifierByte(c):
+			start := i
+			for i < len(statement) && isIdentifierByte(statement[i]) {
+				i++
+			}
+			if strings.EqualFold(statement[start:i], identifier) {
+				return true
+			}
+		default:
+			i++
+		}
+	}
+	return false
+}
+
+// skipQuoted returns the index just past the quoted text opening at start and
+// closed by closing, a doubled closing character standing for itself
+func skipQuoted(statement string, start int, closing byte) int {
+	i := start + 1
+	for i < len(statement) {
+		if statement[i] != closing {
+			i++
+			continue
+		}
+		if closing != ']' && i+1 < len(statement) && statement[i+1] == closing {
+			i += 2
+			continue
+		}
+		return i + 1
+	}
+	return len(statement)
+}
+
+// GetTableInfo returns row count, column count and size for a table.
+// The size comes from the dbstat virtual table and is left unknown when it isn't available.
+func (db *SQLite) GetTableInfo(database, table string) (*TableInfo, error) {
+	info := &TableInfo{SizeBytes: -1}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteIdentifier(table))
+	if err := db.Connection.QueryRow(countQuery).Scan(&info.RowCount); err != nil {
+		return nil, err
+	}
+
+	columns, err := db.GetTableColumns(database, table)
+	if err != nil {
+		return nil, err
+	}
+	info.ColumnCount = len(columns)
+
+	sizeQuery := `
+		SELECT SUM(pgsize) FROM dbstat
+		WHERE name = ? OR name IN (SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ?)
+	`
+	var size sql.NullInt64
+	if err := db.Connection.QueryRow(sizeQuery, table, table).Scan(&size); err == nil && size.Valid {
+		info.SizeBytes = size.Int64
+	}
+
+	return info, nil
+}
+
+// GetCreateStatements returns the SQL sqlite_master keeps for the table and its indexes.
+// Indexes SQLite creates itself for keys have no SQL and are left out.
+func (db *SQLite) GetCreateStatements(database, table string) ([]string, error) {
+	query := `
+		SELECT sql FROM sqlite_master
+		WHERE tbl_name = ? AND type IN ('table', 'index') AND sql IS NOT NULL
+		ORDER BY type = 'index', name
+	`
+	rows, err := db.Connection.Query(query, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var statement string
+		if err := rows.Scan(&statement); err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("table %s not found", table)
+	}
+	return statements, nil
+}
+
+// GetEstimatedRowCount always reports -1: SQLite keeps no row estimate outside of
+// ANALYZE, and counting a local file is cheap enough to do exactly
+func (db *SQLite) GetEstimatedRowCount(database, table string) (int64, error) {
+	return -1, nil
+}
+
+// GetRowCount returns the exact number of rows in a table
+func (db *SQLite) GetRowCount(database, table string) (int64, error) {
+	return db.GetRowCountWithFilter(database, table, "")
+}
+
+// GetRowCountWithFilter counts the rows matching whereClause with COUNT(*)
+func (db *SQLite) GetRowCountWithFilter(database, table, whereClause string) (int64, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteIdentifier(table))
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+	var count int64
+	if err := db.Connection.QueryRow(query).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // ExecuteQuery executes a raw SQL query and returns the results
 func (db *SQLite) ExecuteQuery(query string) ([][]string, error) {
+	return db.ExecuteQueryContext(context.Background(), query)
+}
+
+// ExecuteQueryContext executes a raw SQL query and returns the results, stopping when ctx is cancelled
+func (db *SQLite) ExecuteQueryContext(ctx context.Context, query string) ([][]string, error) {
 	logger.Debug("Executing raw query", map[string]any{
 		"query": query,
 	})
 
-	rows, err := db.Connection.Query(query)
+	rows, err := db.Connection.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -737,6 +1084,36 @@ func (db *SQLite) ExecuteQuery(query string) ([][]string, error) {
 	return data, nil
 }
 
+// ExecuteScript executes each statement of a script and returns every statement's result
+func (db *SQLite) ExecuteScript(script string) ([]StatementResult, error) {
+	return db.ExecuteScriptContext(context.Background(), script)
+}
+
+// ExecuteScriptContext is ExecuteScript stopping at the running statement when ctx is cancelled
+func (db *SQLite) ExecuteScriptContext(ctx context.Context, script string) ([]StatementResult, error) {
+	return executeScript(ctx, db.Connection, script, scriptOptions{})
+}
+
+// ExecuteInTransaction runs the statements in one transaction
+func (db *SQLite) ExecuteInTransaction(statements ...string) error {
+	return executeInTransaction(db.Connection, statements)
+}
+
+// ExecuteStatement runs a statement with args bound to its placeholders
+func (db *SQLite) ExecuteStatement(query string, args ...any) (int64, error) {
+	return execWithArgs(db.Connection, query, args)
+}
+
+// Placeholder returns ?, SQLite binds ? placeholders in order
+func (db *SQLite) Placeholder(_ int) string {
+	return "?"
+}
+
+// TruncateStatement returns a DELETE FROM for the table, SQLite has no TRUNCATE
+func (db *SQLite) TruncateStatement(table string) string {
+	return "DELETE FROM " + db.QuoteIdentifier(table)
+}
+
 // quoteIdentifier safely quotes a table or column name for SQLite
 func quoteIdentifier(name string) string {
 	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
@@ -0,0 +1,142 @@
+package drivers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteURLRoundTrip(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/data/app.db", "sqlite:///data/app.db"},
+		{"/my data/app db.sqlite", "sqlite:///my%20data/app%20db.sqlite"},
+		{"/data/what?.db", "sqlite:///data/what%3F.db"},
+		{"/data/#1.db", "sqlite:///data/%231.db"},
+		{"/data/100%.db", "sqlite:///data/100%25.db"},
+		{"/data/%20.db", "sqlite:///data/%2520.db"},
+		{"relative/app.db", "sqlite://relative/app.db"},
+	}
+	for _, tt := range tests {
+		url := SQLiteURL(tt.path)
+		if url != tt.want {
+			t.Errorf("SQLiteURL(%q) = %q, want %q", tt.path, url, tt.want)
+		}
+		got, err := SQLiteFilePath(url)
+		if err != nil {
+			t.Errorf("SQLiteFilePath(%q): %v", url, err)
+			continue
+		}
+		if got != tt.path {
+			t.Errorf("SQLiteFilePath(SQLiteURL(%q)) = %q", tt.path, got)
+		}
+	}
+}
+
+func TestSQLiteFilePath(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{"sqlite:///data/app.db", "/data/app.db", false},
+		{"sqlite:///data/app.db?mode=ro", "/data/app.db", false},
+		{"sqlite:///data/app.db#frag", "/data/app.db", false},
+		{"sqlite:///my%20data/app.db", "/my data/app.db", false},
+		{"sqlite:///my data/app.db", "/my data/app.db", false},
+		{"sqlite:///data/what%3F.db?cache=shared", "/data/what?.db", false},
+		{"sqlite:///data/%231.db", "/data/#1.db", false},
+		{"file:///data/app.db", "/data/app.db", false},
+		{"file:rel.db?mode=memory", "rel.db", false},
+		{"sqlite:///data/100%.db", "", true},
+		{"sqlite://", "", true},
+		{"sqlite://?mode=ro", "", true},
+	}
+	for _, tt := range tests {
+		got, err := SQLiteFilePath(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SQLiteFilePath(%q) error = %v, want error %v", tt.url, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("SQLiteFilePath(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestSQLiteConnectsToEscapedPaths(t *testing.T) {
+	for _, name := range []string{"with space.db", "what?.db", "#1.db", "100%.db"} {
+		path := filepath.Join(t.TempDir(), name)
+		db := &SQLite{}
+		if err := db.Connect(SQLiteURL(path)); err != nil {
+			t.Errorf("Connect(%q): %v", name, err)
+			continue
+		}
+		if _, err := db.ExecuteStatement(`CREATE TABLE t (id INTEGER)`); err != nil {
+			t.Errorf("%q: %v", name, err)
+		}
+		db.Close()
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("database for %q not created at its path: %v", name, err)
+		}
+	}
+}
+
+func TestReferencesIdentifier(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      bool
+	}{
+		{"CREATE VIEW v AS SELECT * FROM orders", true},
+		{"CREATE VIEW v AS SELECT * FROM ORDERS", true},
+		{`CREATE VIEW v AS SELECT * FROM "orders"`, true},
+		{"CREATE VIEW v AS SELECT * FROM `orders`", true},
+		{"CREATE VIEW v AS SELECT * FROM [orders]", true},
+		{"CREATE VIEW v AS SELECT * FROM main.orders", true},
+		{"CREATE VIEW v AS SELECT * FROM orders_archive", false},
+		{"CREATE VIEW v AS SELECT * FROM old_orders", false},
+		{"CREATE VIEW v AS SELECT * FROM t WHERE note = 'orders'", false},
+		{"CREATE VIEW v AS SELECT * FROM t -- orders\n", false},
+		{"CREATE VIEW v AS SELECT * FROM t /* orders */", false},
+		{`CREATE VIEW v AS SELECT * FROM "my ""orders"""`, false},
+		{`CREATE VIEW v AS SELECT * FROM t WHERE x = "`, false},
+	}
+	for _, tt := range tests {
+		if got := referencesIdentifier(tt.statement, "orders"); got != tt.want {
+			t.Errorf("referencesIdentifier(%q, orders) = %v, want %v", tt.statement, got, tt.want)
+		}
+	}
+
+	if !referencesIdentifier(`SELECT * FROM "my ""orders"""`, `my "orders"`) {
+		t.Error(`doubled quotes in a quoted identifier should match my "orders"`)
+	}
+}
+
+func TestSQLiteGetDependenciesMatchesIdentifiers(t *testing.T) {
+	db := &SQLite{}
+	if err := db.Connect("file:" + filepath.Join(t.TempDir(), "deps.db")); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for _, statement := range []string{
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY)`,
+		`CREATE TABLE orders_archive (id INTEGER PRIMARY KEY)`,
+		`CREATE VIEW recent AS SELECT * FROM "orders"`,
+		`CREATE VIEW archived AS SELECT * FROM orders_archive`,
+	} {
+		if _, err := db.ExecuteStatement(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deps, err := db.GetDependencies("", "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != 1 || deps[0].Name != "recent" {
+		t.Errorf("dependencies of orders = %+v, want only the view recent", deps)
+	}
+}
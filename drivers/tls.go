@@ -0,0 +1,244 @@
+package drivers
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/microsoft/go-mssqldb/msdsn"
+)
+
+// TLS modes, named after PostgreSQL's sslmode
+const (
+	TLSModeDisable    = "disable"
+	TLSModeRequire    = "require"     // Encrypt without checking the server certificate
+	TLSModeVerifyCA   = "verify-ca"   // Also check the certificate is signed by the CA
+	TLSModeVerifyFull = "verify-full" // Also check the certificate names the server host
+)
+
+// TLSModes lists the TLS modes from the least to the most strict
+var TLSModes = []string{TLSModeDisable, TLSModeRequire, TLSModeVerifyCA, TLSModeVerifyFull}
+
+// TLSConfig is how a connection encrypts its traffic to the server. The zero
+// value leaves it to the connection URL.
+type TLSConfig struct {
+	Mode     string // One of TLSModes; empty keeps what the URL says
+	CAPath   string // CA certificate checking the server's; the system CAs when empty
+	CertPath string // Client certificate, for servers that ask for one
+	KeyPath  string // Private key of the client certificate
+}
+
+// Configured reports whether the settings override the connection URL's
+func (c TLSConfig) Configured() bool {
+	return c.Mode != ""
+}
+
+// Encrypted reports whether connections use TLS
+func (c TLSConfig) Encrypted() bool {
+	return c.Configured() && c.Mode != TLSModeDisable
+}
+
+// ParseTLSMode returns mode as one of TLSModes, ignoring case and surrounding
+// spaces. An empty mode is returned as is.
+func ParseTLSMode(mode string) (string, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		return "", nil
+	}
+	for _, m := range TLSModes {
+		if mode == m {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("SSL mode must be one of %s", strings.Join(TLSModes, ", "))
+}
+
+// Validate checks the mode and that the client certificate comes with its key
+func (c TLSConfig) Validate() error {
+	if _, err := ParseTLSMode(c.Mode); err != nil {
+		return err
+	}
+	if (c.CertPath == "") != (c.KeyPath == "") {
+		return fmt.Errorf("the SSL client certificate and key must be set together")
+	}
+	return nil
+}
+
+// postgresTLSURL returns urlstr with the sslmode and certificate parameters of
+// cfg, replacing those it had. URLs are returned as is when cfg isn't configured.
+func postgresTLSURL(urlstr string, cfg TLSConfig) (string, error) {
+	if !cfg.Configured() {
+		return urlstr, nil
+	}
+	u, err := url.Parse(urlstr)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set("sslmode", cfg.Mode)
+	for param, path := range map[string]string{
+		"sslrootcert": cfg.CAPath,
+		"sslcert":     cfg.CertPath,
+		"sslkey":      cfg.KeyPath,
+	} {
+		query.Del(param)
+		if path != "" && cfg.Encrypted() {
+			query.Set(param, expandHomePath(path))
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// mysqlTLSURL returns urlstr with the tls parameter of go-sql-driver/mysql set
+// for cfg, registering a tls.Config under a name of its own. Certificates are
+// checked against serverName, which stays the server's host when the URL is
+// rewritten to go through an SSH tunnel. URLs are returned as is when cfg isn't
+// configured.
+func mysqlTLSURL(urlstr string, cfg TLSConfig, serverName string) (string, error) {
+	if !cfg.Configured() {
+		return urlstr, nil
+	}
+	if _, _, _, _, ok := ParseMySQLSocketURL(urlstr); ok {
+		if cfg.Encrypted() {
+			return "", fmt.Errorf("SSL isn't used over a Unix socket, set the SSL mode to disable")
+		}
+		return urlstr, nil
+	}
+	u, err := url.Parse(urlstr)
+	if err != nil {
+		return "", err
+	}
+
+	name := "false"
+	if cfg.Encrypted() {
+		config, err := cfg.clientConfig(serverName)
+		if err != nil {
+			return "", err
+		}
+		// The same settings always get the same name, so reconnecting replaces
+		// the registered config instead of adding one
+		sum := sha256.Sum256([]byte(strings.Join([]string{cfg.Mode, cfg.CAPath, cfg.CertPath, cfg.KeyPath, serverName}, "\x00")))
+		name = "sq-" + hex.EncodeToString(sum[:8])
+		if err := mysql.RegisterTLSConfig(name, config); err != nil {
+			return "", err
+		}
+	}
+
+	query := u.Query()
+	query.Set("tls", name)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// mssqlTLSConfig sets the encryption of a go-mssqldb connection config for cfg,
+// checking the server certificate against the config's host. Configs are left as
+// the URL set them when cfg isn't configured.
+func mssqlTLSConfig(config *msdsn.Config, cfg TLSConfig) error {
+	if !cfg.Configured() {
+		return nil
+	}
+	if !cfg.Encrypted() {
+		config.Encryption = msdsn.EncryptionDisabled
+		config.TLSConfig = nil
+		return nil
+	}
+
+	tlsConfig, err := cfg.clientConfig(config.Host)
+	if err != nil {
+		return err
+	}
+	// SQL Server expects each TDS packet in a TLS record of its own
+	tlsConfig.DynamicRecordSizingDisabled = true
+	config.Encryption = msdsn.EncryptionRequired
+	config.TLSConfig = tlsConfig
+	return nil
+}
+
+// clientConfig builds the tls.Config for cfg the way libpq reads sslmode: require
+// checks the server certificate against the CA only when one is set, verify-ca
+// always checks it against the CA (or the system CAs) and verify-full also checks
+// it names serverName.
+func (c TLSConfig) clientConfig(serverName string) (*tls.Config, error) {
+	config := &tls.Config{ServerName: serverName}
+
+	if c.CAPath != "" {
+		pem, err := os.ReadFile(expandHomePath(c.CAPath))
+		if err != nil {
+			return nil, fmt.Errorf("SSL CA: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("SSL CA: no certificates in %s", c.CAPath)
+		}
+	}
+
+	if c.CertPath != "" {
+		cert, err := tls.LoadX509KeyPair(expandHomePath(c.CertPath), expandHomePath(c.KeyPath))
+		if err != nil {
+			return nil, fmt.Errorf("SSL client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	switch c.Mode {
+	case TLSModeRequire:
+		config.InsecureSkipVerify = true
+		if config.RootCAs != nil {
+			config.VerifyPeerCertificate = verifyCertificateChain(config.RootCAs)
+		}
+	case TLSModeVerifyCA:
+		// Go checks the host along with the chain, so the chain is checked by hand
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = verifyCertificateChain(config.RootCAs)
+	}
+	return config, nil
+}
+
+// verifyCertificateChain checks the server certificate is signed by roots, or
+// by the system CAs when roots is nil, whatever host it names
+func verifyCertificateChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("server sent no certificate")
+		}
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return err
+			}
+			certs[i] = cert
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+		return err
+	}
+}
+
+// TLSConfigurer is implemented by drivers that can encrypt their connections with TLS settings
+type TLSConfigurer interface {
+	SetTLSConfig(cfg TLSConfig)
+}
+
+// tlsSettings makes a driver a TLSConfigurer, keeping the settings to apply
+// to the URLs it connects to
+type tlsSettings struct {
+	tlsConfig TLSConfig
+}
+
+// SetTLSConfig makes the driver connect with cfg
+func (s *tlsSettings) SetTLSConfig(cfg TLSConfig) {
+	s.tlsConfig = cfg
+}
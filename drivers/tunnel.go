@@ -0,0 +1,466 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sheenazien8/sq/logger"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// DefaultSSHPort is the port of SSH servers given without one
+const DefaultSSHPort = 22
+
+// sshDialTimeout bounds connecting and authenticating to the SSH server
+const sshDialTimeout = 15 * time.Second
+
+// SSHTunnel is the SSH server (a bastion) a connection reaches its database
+// through. The zero value connects directly.
+type SSHTunnel struct {
+	Host     string
+	Port     int    // DefaultSSHPort when 0
+	User     string // The current user when empty
+	KeyPath  string // Private key file; ~ is the home directory
+	Password string // Password, or the passphrase of an encrypted key
+}
+
+// Enabled reports whether connections go through the tunnel
+func (t SSHTunnel) Enabled() bool {
+	return t.Host != ""
+}
+
+// Address returns the server as user@host:port, leaving out what isn't set.
+// ParseSSHAddress reads it back.
+func (t SSHTunnel) Address() string {
+	if !t.Enabled() {
+		return ""
+	}
+	address := t.Host
+	if t.Port != 0 {
+		address = net.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+	}
+	if t.User != "" {
+		address = t.User + "@" + address
+	}
+	return address
+}
+
+// ParseSSHAddress parses an SSH server written as [user@]host[:port]; IPv6
+// hosts are written in brackets. An empty address gives a disabled tunnel.
+func ParseSSHAddress(address string) (SSHTunnel, error) {
+	var t SSHTunnel
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return t, nil
+	}
+
+	if at := strings.LastIndex(address, "@"); at != -1 {
+		t.User = address[:at]
+		address = address[at+1:]
+	}
+
+	host, port := address, ""
+	if i := strings.LastIndex(address, ":"); i > strings.LastIndex(address, "]") {
+		host, port = address[:i], address[i+1:]
+	}
+	t.Host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if t.Host == "" {
+		return SSHTunnel{}, fmt.Errorf("SSH host is required")
+	}
+
+	if port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil || p < 1 || p > 65535 {
+			return SSHTunnel{}, fmt.Errorf("SSH port must be between 1 and 65535")
+		}
+		t.Port = p
+	}
+	return t, nil
+}
+
+// SSHTunneler is implemented by drivers that can reach their server through an SSH tunnel
+type SSHTunneler interface {
+	SetSSHTunnel(tunnel SSHTunnel)
+}
+
+// Tunnel forwards the connections made to a local port through an SSH server
+// to a remote address
+type Tunnel struct {
+	client   *ssh.Client
+	listener net.Listener
+	remote   string
+}
+
+// OpenTunnel connects to the SSH server of cfg and starts forwarding a port on
+// 127.0.0.1 to remote, an address as the SSH server sees it
+func OpenTunnel(ctx context.Context, cfg SSHTunnel, remote string) (*Tunnel, error) {
+	client, err := dialSSH(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	t := &Tunnel{client: client, listener: listener, remote: remote}
+	logger.Debug("SSH tunnel opened", map[string]any{
+		"server": cfg.Address(),
+		"remote": remote,
+		"local":  t.LocalAddr(),
+	})
+	go t.serve()
+	return t, nil
+}
+
+// TunnelURL opens a tunnel through cfg to the host of urlstr and returns urlstr
+// pointing at the tunnel's local end, with the tunnel to close once done.
+// defaultPort is used when the URL has no port.
+func TunnelURL(ctx context.Context, cfg SSHTunnel, urlstr, defaultPort string) (string, *Tunnel, error) {
+	u, err := url.Parse(urlstr)
+	if err != nil {
+		return "", nil, err
+	}
+	t, err := openURLTunnel(ctx, cfg, u, defaultPort)
+	if err != nil {
+		return "", nil, err
+	}
+	u.Host = t.LocalAddr()
+	return u.String(), t, nil
+}
+
+// openURLTunnel opens a tunnel through cfg to the host and port of u, or
+// defaultPort when it has none
+func openURLTunnel(ctx context.Context, cfg SSHTunnel, u *url.URL, defaultPort string) (*Tunnel, error) {
+	host, port := u.Hostname(), u.Port()
+	if host == "" {
+		return nil, fmt.Errorf("an SSH tunnel needs the database host in the connection URL")
+	}
+	if port == "" {
+		port = defaultPort
+	}
+	return OpenTunnel(ctx, cfg, net.JoinHostPort(host, port))
+}
+
+// LocalAddr returns the host:port the tunnel listens on
+func (t *Tunnel) LocalAddr() string {
+	return t.listener.Addr().String()
+}
+
+// Dial connects to the tunnel's local end, whatever address is asked for, so
+// database drivers that take a dialer reach the remote address through it while
+// their URL keeps the server's host
+func (t *Tunnel) Dial(network, address string) (net.Conn, error) {
+	return t.DialContext(context.Background(), network, address)
+}
+
+// DialTimeout is Dial giving up after timeout
+func (t *Tunnel) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return t.DialContext(ctx, network, address)
+}
+
+// DialContext is Dial giving up when ctx is done
+func (t *Tunnel) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp", t.LocalAddr())
+}
+
+// Close stops forwarding and disconnects from the SSH server. Closing a nil
+// tunnel does nothing.
+func (t *Tunnel) Close() error {
+	if t == nil {
+		return nil
+	}
+	err := t.listener.Close()
+	if closeErr := t.client.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// serve forwards every connection made to the local port until the tunnel is closed
+func (t *Tunnel) serve() {
+	for {
+		local, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+		go t.forward(local)
+	}
+}
+
+// forward copies a local connection to and from a new connection to the remote
+// address, until either side closes
+func (t *Tunnel) forward(local net.Conn) {
+	defer local.Close()
+
+	remote, err := t.client.Dial("tcp", t.remote)
+	if err != nil {
+		logger.Warn("SSH tunnel failed to reach the database", map[string]any{
+			"remote": t.remote,
+			"error":  err.Error(),
+		})
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// dialSSH connects and authenticates to the SSH server of cfg, checking its
+// host key against ~/.ssh/known_hosts. Cancelling ctx aborts the handshake.
+func dialSSH(ctx context.Context, cfg SSHTunnel) (*ssh.Client, error) {
+	config, agentConn, err := cfg.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if agentConn != nil {
+		defer agentConn.Close()
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = DefaultSSHPort
+	}
+	address := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+
+	dialer := net.Dialer{Timeout: sshDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("SSH: %w", err)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	conn.SetDeadline(time.Now().Add(sshDialTimeout))
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("SSH: %w", err)
+	}
+	conn.SetDeadline(time.Time{})
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// clientConfig returns the user, authentication and host key check to connect
+// with, and the connection to the SSH agent to close after authenticating, if any
+func (t SSHTunnel) clientConfig() (*ssh.ClientConfig, io.Closer, error) {
+	username := t.User
+	if username == "" {
+		current, err := user.Current()
+		if err != nil {
+			return nil, nil, fmt.Errorf("SSH user is required: %w", err)
+		}
+		username = current.Username
+	}
+
+	hostKeyCallback, err := knownHostsCallback()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	auth, agentConn, err := t.authMethods()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ssh.ClientConfig{
+		User:            username,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshDialTimeout,
+	}, agentConn, nil
+}
+
+// authMethods returns the ways to authenticate: the key file when one is set,
+// otherwise the password, falling back to the SSH agent and the default keys
+// in ~/.ssh when neither is set. The agent connection is returned to be closed.
+func (t SSHTunnel) authMethods() ([]ssh.AuthMethod, io.Closer, error) {
+	if t.KeyPath != "" {
+		signer, err := readPrivateKey(expandHomePath(t.KeyPath), t.Password, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil, nil
+	}
+
+	if t.Password != "" {
+		password := t.Password
+		return []ssh.AuthMethod{
+			ssh.Password(password),
+			// Servers asking for the password as a prompt
+			ssh.KeyboardInteractive(func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range answers {
+					answers[i] = password
+				}
+				return answers, nil
+			}),
+		}, nil, nil
+	}
+
+	var methods []ssh.AuthMethod
+	var agentConn io.Closer
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			agentConn = conn
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	var signers []ssh.Signer
+	if home, err := os.UserHomeDir(); err == nil {
+		for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+			// Encrypted default keys are left to the agent
+			if signer, err := readPrivateKey(filepath.Join(home, ".ssh", name), "", false); err == nil {
+				signers = append(signers, signer)
+			}
+		}
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	if len(methods) == 0 {
+		return nil, nil, fmt.Errorf("SSH: no key, password or agent to authenticate with")
+	}
+	return methods, agentConn, nil
+}
+
+// readPrivateKey parses the private key in path, decrypting it with passphrase
+// when it is encrypted. logMissing logs keys that can't be read.
+func readPrivateKey(path, passphrase string, logMissing bool) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if logMissing {
+			logger.Warn("Failed to read SSH key", map[string]any{"path": path, "error": err.Error()})
+		}
+		return nil, fmt.Errorf("SSH key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if passphrase == "" {
+			return nil, fmt.Errorf("SSH key %s is encrypted; set its passphrase as the SSH password", path)
+		}
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(data, []byte(passphrase))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SSH key %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// knownHostsCallback checks host keys against ~/.ssh/known_hosts, explaining
+// how to trust servers that aren't in it yet
+func knownHostsCallback() (ssh.HostKeyCallback, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("SSH: %w", err)
+	}
+	path := filepath.Join(home, ".ssh", "known_hosts")
+
+	check, err := knownhosts.New(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("SSH known hosts: %w", err)
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if check == nil {
+			return fmt.Errorf("host key of %s is unknown; connect once with ssh to add it to %s", hostname, path)
+		}
+		err := check(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) {
+			if len(keyErr.Want) == 0 {
+				return fmt.Errorf("host key of %s is unknown; connect once with ssh to add it to %s", hostname, path)
+			}
+			return fmt.Errorf("host key of %s does not match %s; it may have been replaced", hostname, path)
+		}
+		return err
+	}, nil
+}
+
+// expandHomePath replaces a leading ~ in path with the home directory
+func expandHomePath(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// sshTunnel makes a driver an SSHTunneler: it keeps the tunnel settings and the
+// tunnel opened by Connect, which Close closes along with the connection
+type sshTunnel struct {
+	tunnelConfig SSHTunnel
+	tunnel       *Tunnel
+}
+
+// SetSSHTunnel makes the driver connect through tunnel
+func (s *sshTunnel) SetSSHTunnel(tunnel SSHTunnel) {
+	s.tunnelConfig = tunnel
+}
+
+// tunnelURL returns urlstr as is when no tunnel is set. Otherwise it opens a
+// tunnel to the host of urlstr and returns the URL pointing at it, along with
+// the tunnel to close once done.
+func (s *sshTunnel) tunnelURL(ctx context.Context, urlstr, defaultPort string) (string, *Tunnel, error) {
+	if !s.tunnelConfig.Enabled() {
+		return urlstr, nil, nil
+	}
+	return TunnelURL(ctx, s.tunnelConfig, urlstr, defaultPort)
+}
+
+// openTunnel opens a tunnel to the host of urlstr when one is set, leaving the
+// URL to the caller. It returns nil when no tunnel is set.
+func (s *sshTunnel) openTunnel(ctx context.Context, urlstr, defaultPort string) (*Tunnel, error) {
+	if !s.tunnelConfig.Enabled() {
+		return nil, nil
+	}
+	u, err := url.Parse(urlstr)
+	if err != nil {
+		return nil, err
+	}
+	return openURLTunnel(ctx, s.tunnelConfig, u, defaultPort)
+}
+
+// closeTunnel closes the tunnel opened by Connect, if any
+func (s *sshTunnel) closeTunnel() {
+	if err := s.tunnel.Close(); err != nil {
+		logger.Warn("Failed to close SSH tunnel", map[string]any{"error": err.Error()})
+	}
+	s.tunnel = nil
+}
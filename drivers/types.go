@@ -1,12 +1,151 @@
 package drivers
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xo/dburl"
+)
+
 // Driver type constants for use in switch cases and comparisons
 const (
 	DriverTypeMySQL      = "mysql"
 	DriverTypePostgreSQL = "postgresql"
 	DriverTypeSQLite     = "sqlite"
+	DriverTypeMSSQL      = "mssql"
+	DriverTypeRedis      = "redis"
+	DriverTypeOracle     = "oracle"
 )
 
+// DefaultPort returns the port the server of a driver type listens on when its
+// URL gives none, or "" for drivers without a server
+func DefaultPort(driverType string) string {
+	switch driverType {
+	case DriverTypeMySQL:
+		return "3306"
+	case DriverTypePostgreSQL:
+		return "5432"
+	case DriverTypeMSSQL:
+		return "1433"
+	case DriverTypeRedis:
+		return "6379"
+	case DriverTypeOracle:
+		return "1521"
+	}
+	return ""
+}
+
+// DriverTypeFromURL returns the driver type for the scheme of a connection URL,
+// e.g. "postgres://..." is DriverTypePostgreSQL. Other schemes dburl knows, like
+// "pgsql", "tidb" or "azuresql", are looked up by the Go driver dburl opens them with.
+func DriverTypeFromURL(urlstr string) (string, error) {
+	scheme, _, ok := strings.Cut(urlstr, ":")
+	if !ok || scheme == "" {
+		return "", fmt.Errorf("connection URL %q has no scheme (e.g. postgres://, mysql://, sqlite://, sqlserver://, redis://, oracle://)", urlstr)
+	}
+
+	switch strings.ToLower(scheme) {
+	case "mysql", "mariadb":
+		return DriverTypeMySQL, nil
+	case "postgres", "postgresql", "pg":
+		return DriverTypePostgreSQL, nil
+	case "sqlite", "sqlite3", "file":
+		return DriverTypeSQLite, nil
+	case "sqlserver", "mssql", "ms":
+		return DriverTypeMSSQL, nil
+	case "redis", "rediss":
+		return DriverTypeRedis, nil
+	case "oracle", "ora", "or":
+		return DriverTypeOracle, nil
+	}
+
+	// A "+" separates the transport, e.g. "postgres+unix"
+	base, _, _ := strings.Cut(strings.ToLower(scheme), "+")
+	goDriver, _ := dburl.SchemeDriverAndAliases(base)
+	switch goDriver {
+	case "mysql", "mymysql":
+		return DriverTypeMySQL, nil
+	case "postgres", "pgx":
+		return DriverTypePostgreSQL, nil
+	case "sqlite3", "moderncsqlite":
+		return DriverTypeSQLite, nil
+	case "sqlserver":
+		return DriverTypeMSSQL, nil
+	case "oracle", "godror":
+		return DriverTypeOracle, nil
+	}
+	return "", fmt.Errorf("unsupported URL scheme: %s", scheme)
+}
+
+// ParseDSN detects the driver type of a DSN from its scheme, like
+// DriverTypeFromURL, and returns the DSN rewritten to the scheme the driver
+// reads, e.g. "pgsql://host/db" becomes "postgres://host/db". The rest of the
+// DSN, query parameters included, is kept as typed.
+func ParseDSN(dsn string) (driverType, urlstr string, err error) {
+	dsn = strings.TrimSpace(dsn)
+	driverType, err = DriverTypeFromURL(dsn)
+	if err != nil {
+		return "", "", err
+	}
+
+	scheme, rest, _ := strings.Cut(dsn, ":")
+	_, transport, _ := strings.Cut(strings.ToLower(scheme), "+")
+	switch driverType {
+	case DriverTypeSQLite:
+		// file: is read whatever follows it, "file:///abs.db" or "file:rel.db"
+		return driverType, "file:" + rest, nil
+	case DriverTypeRedis:
+		// redis:// and rediss:// differ in whether they use TLS
+		return driverType, dsn, nil
+	}
+
+	scheme = map[string]string{
+		DriverTypeMySQL:      "mysql",
+		DriverTypePostgreSQL: "postgres",
+		DriverTypeMSSQL:      "sqlserver",
+		DriverTypeOracle:     "oracle",
+	}[driverType]
+	if transport != "" {
+		scheme += "+" + transport
+	}
+	return driverType, scheme + ":" + rest, nil
+}
+
+// ConnectionURL builds the URL of a connection from the host, port, user,
+// password and database typed in the connection forms
+func ConnectionURL(driverType, host, port, username, password, database string) string {
+	switch driverType {
+	case DriverTypeSQLite:
+		return SQLiteURL(database)
+	case DriverTypePostgreSQL:
+		if password != "" {
+			return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", username, password, host, port, database)
+		}
+		return fmt.Sprintf("postgres://%s@%s:%s/%s?sslmode=disable", username, host, port, database)
+	case DriverTypeMSSQL:
+		if password != "" {
+			return fmt.Sprintf("sqlserver://%s:%s@%s:%s/%s", username, password, host, port, database)
+		}
+		return fmt.Sprintf("sqlserver://%s@%s:%s/%s", username, host, port, database)
+	case DriverTypeRedis:
+		return RedisURL(username, password, host, port, database)
+	case DriverTypeOracle:
+		if password != "" {
+			return fmt.Sprintf("oracle://%s:%s@%s:%s/%s", username, password, host, port, database)
+		}
+		return fmt.Sprintf("oracle://%s@%s:%s/%s", username, host, port, database)
+	case DriverTypeMySQL:
+		if strings.HasPrefix(host, "/") {
+			return MySQLSocketURL(username, password, host, database)
+		}
+		if password != "" {
+			return fmt.Sprintf("mysql://%s:%s@%s:%s/%s", username, password, host, port, database)
+		}
+		return fmt.Sprintf("mysql://%s@%s:%s/%s", username, host, port, database)
+	}
+	return ""
+}
+
 // ColumnInfo represents detailed column information
 type ColumnInfo struct {
 	Name         string
@@ -16,6 +155,7 @@ type ColumnInfo struct {
 	DefaultValue string
 	Extra        string // e.g., auto_increment
 	Comment      string
+	Generated    bool // Computed by the database (generated or GENERATED ALWAYS identity), can't be written
 }
 
 // IndexInfo represents index information
@@ -46,10 +186,35 @@ type TriggerInfo struct {
 	Table     string
 }
 
+// Dependency type constants for DependencyInfo.Type
+const (
+	DependencyTypeView       = "VIEW"
+	DependencyTypeForeignKey = "FOREIGN KEY"
+	DependencyTypeTrigger    = "TRIGGER"
+)
+
+// DependencyInfo represents an object that depends on a table
+type DependencyInfo struct {
+	Name   string // Name of the view, trigger or constraint
+	Type   string // One of the DependencyType constants
+	Table  string // Table or view that owns the dependent object
+	Detail string // e.g., the referencing column of a foreign key
+}
+
+// TableInfo holds summary statistics for a table
+type TableInfo struct {
+	RowCount       int64 // -1 if unknown
+	RowCountApprox bool  // True when RowCount comes from planner statistics rather than COUNT(*)
+	ColumnCount    int
+	SizeBytes      int64  // On-disk size including indexes, -1 if unknown
+	LastModified   string // Empty when the database doesn't track it
+}
+
 // TableStructure holds all structure information for a table
 type TableStructure struct {
-	Columns   []ColumnInfo
-	Indexes   []IndexInfo
-	Relations []RelationInfo
-	Triggers  []TriggerInfo
+	Columns      []ColumnInfo
+	Indexes      []IndexInfo
+	Relations    []RelationInfo
+	Triggers     []TriggerInfo
+	Dependencies []DependencyInfo
 }
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -11,10 +12,12 @@ import (
 )
 
 type logger struct {
-	mu     sync.Mutex
-	file   *os.File
-	level  slog.Level
-	output string
+	mu      sync.Mutex
+	file    *os.File
+	level   slog.Level
+	output  string
+	size    int64
+	maxSize int64 // 0 disables rotation
 }
 
 type logMessage struct {
@@ -24,6 +27,9 @@ type logMessage struct {
 	Data      map[string]any `json:"additional_info,omitempty"`
 }
 
+// OutputStderr is the output name reported when logging to standard error
+const OutputStderr = "stderr"
+
 var logInstance *logger
 
 func init() {
@@ -56,35 +62,93 @@ func (l *logger) log(level slog.Level, msg string, data map[string]any) {
 		return
 	}
 
-	_, err = l.file.Write(logData)
+	logData = append(logData, '\n')
+
+	// Rotate before writing if this line would push the file past the limit. A
+	// rotation that fails keeps writing to the current file.
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(logData)) > l.maxSize {
+		if err := l.rotate(); err != nil && l.file == nil {
+			return
+		}
+	}
+
+	n, err := l.file.Write(logData)
+	l.size += int64(n)
 	if err != nil {
 		return
 	}
+}
+
+// rotate moves the current log file to "<output>.1" and reopens a fresh one.
+// When the file can't be moved it is reopened as it is, so logging goes on.
+// Callers must hold l.mu.
+func (l *logger) rotate() error {
+	if l.file == nil || l.output == "" || l.file == os.Stderr {
+		return nil
+	}
+
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
 
-	_, err = l.file.Write([]byte("\n"))
+	if err := os.Rename(l.output, l.output+".1"); err != nil && !os.IsNotExist(err) {
+		if openErr := l.open(l.output); openErr != nil {
+			return errors.Join(err, openErr)
+		}
+		return err
+	}
+
+	return l.open(l.output)
+}
+
+// open opens filename for appending and records its current size.
+// Callers must hold l.mu.
+func (l *logger) open(filename string) error {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		return
+		return err
+	}
+
+	var size int64
+	if info, err := file.Stat(); err == nil {
+		size = info.Size()
 	}
+
+	l.file = file
+	l.output = filename
+	l.size = size
+	return nil
 }
 
 func (l *logger) SetFile(filename string) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if l.file != nil {
+	if l.file != nil && l.file != os.Stderr {
 		err := l.file.Close()
 		if err != nil {
 			return err
 		}
 	}
 
-	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
+	return l.open(filename)
+}
+
+func (l *logger) SetStderr() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file != nil && l.file != os.Stderr {
+		err := l.file.Close()
+		if err != nil {
+			return err
+		}
 	}
 
-	l.file = file
-	l.output = filename
+	l.file = os.Stderr
+	l.output = OutputStderr
+	l.size = 0
 	return nil
 }
 
@@ -95,6 +159,65 @@ func (l *logger) SetLevel(level slog.Level) {
 	l.level = level
 }
 
+func (l *logger) Level() slog.Level {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.level
+}
+
+func (l *logger) Output() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.output
+}
+
+func (l *logger) SetMaxSize(bytes int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if bytes < 0 {
+		bytes = 0
+	}
+	l.maxSize = bytes
+}
+
+func (l *logger) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil || l.file == os.Stderr {
+		return nil
+	}
+	return l.file.Sync()
+}
+
+func (l *logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	// Never close the process's stderr, just stop writing to it
+	if l.file == os.Stderr {
+		l.file = nil
+		return nil
+	}
+
+	syncErr := l.file.Sync()
+	closeErr := l.file.Close()
+	l.file = nil
+	l.size = 0
+
+	if syncErr != nil {
+		return syncErr
+	}
+	return closeErr
+}
+
 func SetLevel(level slog.Level) {
 	logInstance.SetLevel(level)
 }
@@ -103,6 +226,38 @@ func SetFile(filename string) error {
 	return logInstance.SetFile(filename)
 }
 
+// SetStderr sends log output to standard error instead of a file
+func SetStderr() error {
+	return logInstance.SetStderr()
+}
+
+// GetLevel returns the current minimum log level
+func GetLevel() slog.Level {
+	return logInstance.Level()
+}
+
+// Output returns the current log destination (a file name or OutputStderr)
+func Output() string {
+	return logInstance.Output()
+}
+
+// SetMaxSize caps the log file size in bytes. When a write would exceed the
+// limit, the current file is renamed to "<file>.1" and a new one is started.
+// A value of 0 disables rotation.
+func SetMaxSize(bytes int64) {
+	logInstance.SetMaxSize(bytes)
+}
+
+// Sync flushes the log file to disk
+func Sync() error {
+	return logInstance.Sync()
+}
+
+// Close flushes and closes the log file. Subsequent log calls are dropped.
+func Close() error {
+	return logInstance.Close()
+}
+
 func Debug(msg string, data map[string]any) {
 	logInstance.log(slog.LevelDebug, msg, data)
 }
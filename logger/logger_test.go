@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotate(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "sq.log")
+	l := &logger{level: slog.LevelInfo}
+	if err := l.SetFile(output); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l.SetMaxSize(100)
+
+	l.log(slog.LevelInfo, strings.Repeat("a", 60), nil)
+	l.log(slog.LevelInfo, "second", nil)
+
+	rotated, err := os.ReadFile(output + ".1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(rotated), strings.Repeat("a", 60)) {
+		t.Errorf("rotated file = %q, want the first line", rotated)
+	}
+	current, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(current), "second") || strings.Contains(string(current), strings.Repeat("a", 60)) {
+		t.Errorf("log file = %q, want only the second line", current)
+	}
+}
+
+func TestRotateKeepsLoggingWhenRenameFails(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "sq.log")
+	// A directory that isn't empty can't be replaced by the log file
+	if err := os.MkdirAll(filepath.Join(output+".1", "taken"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &logger{level: slog.LevelInfo}
+	if err := l.SetFile(output); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l.SetMaxSize(100)
+
+	l.log(slog.LevelInfo, strings.Repeat("a", 60), nil)
+	if err := l.rotate(); err == nil {
+		t.Fatal("rotate succeeded, want the rename to fail")
+	}
+	if l.file == nil {
+		t.Fatal("log file closed after a failed rotation")
+	}
+	l.log(slog.LevelInfo, "after", nil)
+
+	current, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(current), strings.Repeat("a", 60)) || !strings.Contains(string(current), "after") {
+		t.Errorf("log file = %q, want both lines", current)
+	}
+}
@@ -5,29 +5,44 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/sheenazien8/sq/app"
+	"github.com/sheenazien8/sq/config"
 	"github.com/sheenazien8/sq/drivers"
 	"github.com/sheenazien8/sq/internal/version"
 	"github.com/sheenazien8/sq/logger"
 	"github.com/sheenazien8/sq/storage"
 )
 
+// logMaxSize is the size at which debug.log is rotated
+const logMaxSize = 10 * 1024 * 1024
+
 func main() {
 	// Parse command line flags
 	versionFlag := flag.Bool("version", false, "Show version information")
 	versionShort := flag.Bool("v", false, "Show version information (short)")
+	noAltScreen := flag.Bool("no-altscreen", false, "Render in the normal screen buffer instead of the alternate screen")
+	connURL := flag.String("url", "", "Connect to a database URL for this session without saving it")
 
 	// Connection creation flags
 	createConnFlag := flag.Bool("create-connection", false, "Create a new database connection")
-	connDriver := flag.String("driver", drivers.DriverTypeMySQL, "Database driver (mysql, postgresql, sqlite)")
+	connDriver := flag.String("driver", drivers.DriverTypeMySQL, "Database driver (mysql, postgresql, sqlite, mssql, redis, oracle)")
 	connName := flag.String("name", "", "Connection name")
-	connHost := flag.String("host", "localhost", "Database host")
-	connPort := flag.String("port", "3306", "Database port")
+	connHost := flag.String("host", "localhost", "Database host (or MySQL Unix socket path)")
+	connPort := flag.String("port", "", "Database port (default: the driver's, e.g. 3306 for mysql)")
 	connUser := flag.String("user", "", "Database user")
 	connPass := flag.String("password", "", "Database password")
-	connDB := flag.String("database", "", "Database name or SQLite file path")
+	connDB := flag.String("database", "", "Database name, SQLite file path, Redis database number (default 0) or Oracle service name")
+	connDSN := flag.String("dsn", "", "Connection DSN in any scheme dburl knows, instead of -driver, -host, -port, -user, -password and -database")
+	connSSH := flag.String("ssh", "", "SSH server to tunnel through, as [user@]host[:port]")
+	connSSHKey := flag.String("ssh-key", "", "Private key file for the SSH server")
+	connSSHPass := flag.String("ssh-password", "", "Password for the SSH server, or the passphrase of the key")
+	connSSLMode := flag.String("ssl-mode", "", "SSL mode: "+strings.Join(drivers.TLSModes, ", "))
+	connSSLCA := flag.String("ssl-ca", "", "CA certificate file checking the server's")
+	connSSLCert := flag.String("ssl-cert", "", "Client certificate file")
+	connSSLKey := flag.String("ssl-key", "", "Client key file")
 
 	flag.Parse()
 
@@ -39,7 +54,22 @@ func main() {
 
 	// Handle create connection flag
 	if *createConnFlag {
-		if err := handleCreateConnection(*connDriver, *connName, *connHost, *connPort, *connUser, *connPass, *connDB); err != nil {
+		var opts drivers.ConnectionOptions
+		var err error
+		opts.SSH, err = drivers.ParseSSHAddress(*connSSH)
+		if err == nil {
+			opts.SSH.KeyPath = *connSSHKey
+			opts.SSH.Password = *connSSHPass
+			opts.TLS = drivers.TLSConfig{CAPath: *connSSLCA, CertPath: *connSSLCert, KeyPath: *connSSLKey}
+			opts.TLS.Mode, err = drivers.ParseTLSMode(*connSSLMode)
+		}
+		if err == nil {
+			err = opts.TLS.Validate()
+		}
+		if err == nil {
+			err = handleCreateConnection(*connDriver, *connName, *connHost, *connPort, *connUser, *connPass, *connDB, *connDSN, opts)
+		}
+		if err != nil {
 			fmt.Printf("Error creating connection: %v\n", err)
 			os.Exit(1)
 		}
@@ -47,10 +77,19 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Setup logger
-	if err := logger.SetFile("debug.log"); err != nil {
-		fmt.Println("Failed to setup logger:", err)
-		os.Exit(1)
+	// Setup logger, writing to stderr instead of debug.log if configured
+	cfg, _ := config.Load()
+	if cfg.LogToStderr() {
+		if err := logger.SetStderr(); err != nil {
+			fmt.Println("Failed to setup logger:", err)
+			os.Exit(1)
+		}
+	} else {
+		if err := logger.SetFile("debug.log"); err != nil {
+			fmt.Println("Failed to setup logger:", err)
+			os.Exit(1)
+		}
+		logger.SetMaxSize(logMaxSize)
 	}
 
 	// Set log level based on DEBUG environment variable
@@ -64,49 +103,90 @@ func main() {
 	// Initialize app storage (SQLite database)
 	if err := storage.Init(); err != nil {
 		logger.Error("Failed to initialize storage", map[string]any{"error": err.Error()})
+		logger.Close()
 		fmt.Println("Failed to initialize storage:", err)
 		os.Exit(1)
 	}
+
+	os.Exit(run(*connURL, !*noAltScreen))
+}
+
+// run starts the TUI and returns the process exit code. It is separate from
+// main so deferred cleanup runs before os.Exit. A non-empty connURL is added as
+// an unsaved connection. With altScreen false the UI is drawn in the normal
+// screen buffer, which is easier to capture and debug.
+func run(connURL string, altScreen bool) int {
+	defer logger.Close()
 	defer storage.Close()
 
-	p := tea.NewProgram(
-		app.New(),
-		tea.WithAltScreen(),
-	)
+	model := app.New()
+	if connURL != "" {
+		if err := model.AddEphemeralConnection(connURL); err != nil {
+			logger.Error("Invalid connection URL", map[string]any{"error": err.Error()})
+			fmt.Println("Invalid connection URL:", err)
+			return 1
+		}
+	}
+
+	opts := []tea.ProgramOption{tea.WithMouseCellMotion()}
+	if altScreen {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	p := tea.NewProgram(model, opts...)
 
 	if _, err := p.Run(); err != nil {
-		os.Exit(1)
+		logger.Error("Application exited with error", map[string]any{"error": err.Error()})
+		return 1
 	}
+
+	// A clean exit leaves nothing to recover on the next launch
+	if err := storage.ClearRecovery(); err != nil {
+		logger.Error("Failed to remove query recovery file", map[string]any{"error": err.Error()})
+	}
+
+	logger.Info("Application exiting", nil)
+	return 0
 }
 
-// handleCreateConnection creates a new database connection from CLI flags
-func handleCreateConnection(driver, name, host, port, user, password, database string) error {
-	// Validate driver
-	supportedDrivers := map[string]bool{
-		drivers.DriverTypeMySQL:      true,
-		drivers.DriverTypePostgreSQL: true,
-		drivers.DriverTypeSQLite:     true,
+// handleCreateConnection creates a new database connection from CLI flags,
+// connecting with opts (its SSH tunnel and SSL settings). A non-empty dsn names
+// the driver and replaces the host, port, user, password and database.
+func handleCreateConnection(driver, name, host, port, user, password, database, dsn string, opts drivers.ConnectionOptions) error {
+	var url string
+	if dsn != "" {
+		var err error
+		if driver, url, err = drivers.ParseDSN(dsn); err != nil {
+			return err
+		}
 	}
-	if !supportedDrivers[driver] {
-		return fmt.Errorf("unsupported driver: %s (supported: mysql, postgresql, sqlite)", driver)
+
+	// Validate driver
+	if !drivers.IsRegistered(driver) {
+		return fmt.Errorf("unsupported driver: %s (supported: %s)", driver, strings.Join(drivers.Registered(), ", "))
 	}
 
 	// Validate required fields
 	if name == "" {
 		return fmt.Errorf("connection name is required (--name)")
 	}
-	if database == "" {
+	if database == "" && driver == drivers.DriverTypeRedis {
+		database = "0"
+	}
+	if database == "" && url == "" {
 		return fmt.Errorf("database name/path is required (--database)")
 	}
 
 	// Validate driver-specific fields
-	if driver == drivers.DriverTypeSQLite {
-		// SQLite only needs name and file path
-	} else if driver == drivers.DriverTypeMySQL || driver == drivers.DriverTypePostgreSQL {
-		// MySQL and PostgreSQL need user and database
-		if user == "" {
+	if driver == drivers.DriverTypeSQLite || url != "" {
+		// SQLite only needs name and file path, and a DSN has the rest
+	} else {
+		// MySQL, PostgreSQL, SQL Server and Oracle need user and database; Redis only has ACL users
+		if user == "" && driver != drivers.DriverTypeRedis {
 			return fmt.Errorf("database user is required (--user)")
 		}
+		if port == "" {
+			port = drivers.DefaultPort(driver)
+		}
 	}
 
 	// Initialize storage
@@ -119,29 +199,46 @@ func handleCreateConnection(driver, name, host, port, user, password, database s
 	if err := logger.SetFile("debug.log"); err != nil {
 		return fmt.Errorf("failed to setup logger: %w", err)
 	}
-
-	// Build connection URL based on driver
-	var url string
-	switch driver {
-	case drivers.DriverTypeMySQL:
-		if password == "" {
+	defer logger.Close()
+
+	// Build connection URL based on driver, unless a DSN gave it
+	switch {
+	case url != "":
+	case driver == drivers.DriverTypeMySQL:
+		if strings.HasPrefix(host, "/") {
+			url = drivers.MySQLSocketURL(user, password, host, database)
+		} else if password == "" {
 			url = fmt.Sprintf("mysql://%s@%s:%s/%s", user, host, port, database)
 		} else {
 			url = fmt.Sprintf("mysql://%s:%s@%s:%s/%s", user, password, host, port, database)
 		}
-	case drivers.DriverTypePostgreSQL:
+	case driver == drivers.DriverTypePostgreSQL:
 		if password == "" {
 			url = fmt.Sprintf("postgres://%s@%s:%s/%s?sslmode=disable", user, host, port, database)
 		} else {
 			url = fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", user, password, host, port, database)
 		}
-	case drivers.DriverTypeSQLite:
+	case driver == drivers.DriverTypeMSSQL:
+		if password == "" {
+			url = fmt.Sprintf("sqlserver://%s@%s:%s/%s", user, host, port, database)
+		} else {
+			url = fmt.Sprintf("sqlserver://%s:%s@%s:%s/%s", user, password, host, port, database)
+		}
+	case driver == drivers.DriverTypeRedis:
+		url = drivers.RedisURL(user, password, host, port, database)
+	case driver == drivers.DriverTypeOracle:
+		if password == "" {
+			url = fmt.Sprintf("oracle://%s@%s:%s/%s", user, host, port, database)
+		} else {
+			url = fmt.Sprintf("oracle://%s:%s@%s:%s/%s", user, password, host, port, database)
+		}
+	case driver == drivers.DriverTypeSQLite:
 		// SQLite URL format: sqlite:///path/to/database.db
-		url = fmt.Sprintf("sqlite://%s", database)
+		url = drivers.SQLiteURL(database)
 	}
 
 	// Create connection (this will test the connection before saving)
-	_, err := storage.CreateConnection(name, driver, url)
+	_, err := storage.CreateConnection(name, driver, url, opts)
 	if err != nil {
 		return err
 	}
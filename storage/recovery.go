@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Recovery is an unsaved query buffer kept on disk so it survives a crash
+type Recovery struct {
+	ConnectionName string    `json:"connection"`
+	DatabaseName   string    `json:"database"`
+	Query          string    `json:"query"`
+	SavedAt        time.Time `json:"saved_at"`
+}
+
+// recoveryPath returns the path to the query recovery file, next to the storage database
+func recoveryPath() (string, error) {
+	path, err := storagePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "recovery.json"), nil
+}
+
+// RecoveryPath returns the path to the query recovery file
+func RecoveryPath() (string, error) {
+	return recoveryPath()
+}
+
+// SaveRecovery writes the query buffer to the recovery file. The file is
+// replaced atomically so a crash mid-write never leaves it truncated.
+func SaveRecovery(r Recovery) error {
+	path, err := recoveryPath()
+	if err != nil {
+		return err
+	}
+
+	r.SavedAt = time.Now()
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadRecovery reads the recovery file, returning nil if there is nothing to recover
+func LoadRecovery() (*Recovery, error) {
+	path, err := recoveryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var r Recovery
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	if r.Query == "" {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+// ClearRecovery removes the recovery file
+func ClearRecovery() error {
+	path, err := recoveryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
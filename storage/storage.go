@@ -22,12 +22,51 @@ type Connection struct {
 	URL       string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// SSH server the database is reached through; empty SSHHost connects directly
+	SSHHost     string
+	SSHPort     int
+	SSHUser     string
+	SSHKeyPath  string
+	SSHPassword string // Password, or the passphrase of the key
+
+	// SSL settings; empty SSLMode keeps what the URL says
+	SSLMode string
+	SSLCA   string
+	SSLCert string
+	SSLKey  string
+}
+
+// SSHTunnel returns the SSH tunnel settings of the connection
+func (c Connection) SSHTunnel() drivers.SSHTunnel {
+	return drivers.SSHTunnel{
+		Host:     c.SSHHost,
+		Port:     c.SSHPort,
+		User:     c.SSHUser,
+		KeyPath:  c.SSHKeyPath,
+		Password: c.SSHPassword,
+	}
 }
 
-// SavedQuery represents a saved SQL query
+// TLSConfig returns the SSL settings of the connection
+func (c Connection) TLSConfig() drivers.TLSConfig {
+	return drivers.TLSConfig{
+		Mode:     c.SSLMode,
+		CAPath:   c.SSLCA,
+		CertPath: c.SSLCert,
+		KeyPath:  c.SSLKey,
+	}
+}
+
+// Options returns the settings the connection connects with besides its URL
+func (c Connection) Options() drivers.ConnectionOptions {
+	return drivers.ConnectionOptions{SSH: c.SSHTunnel(), TLS: c.TLSConfig()}
+}
+
+// SavedQuery represents a saved SQL query, also called a snippet
 type SavedQuery struct {
 	ID           int64
-	ConnectionID int64
+	ConnectionID int64 // 0 when saved from an unsaved connection
 	Name         string
 	Query        string
 	CreatedAt    time.Time
@@ -58,6 +97,11 @@ func storagePath() (string, error) {
 	return filepath.Join(dir, "storage.db"), nil
 }
 
+// Path returns the path to the SQLite database holding connections and queries
+func Path() (string, error) {
+	return storagePath()
+}
+
 // Init initializes the SQLite database connection and creates tables
 func Init() error {
 	path, err := storagePath()
@@ -75,6 +119,11 @@ func Init() error {
 		return err
 	}
 
+	// Add the columns introduced after a storage file was created
+	if err := addMissingColumns(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -95,7 +144,16 @@ func createTables() error {
         driver TEXT NOT NULL,
         url TEXT NOT NULL,
         created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-        updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+        updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        ssh_host TEXT NOT NULL DEFAULT '',
+        ssh_port INTEGER NOT NULL DEFAULT 0,
+        ssh_user TEXT NOT NULL DEFAULT '',
+        ssh_key_path TEXT NOT NULL DEFAULT '',
+        ssh_password TEXT NOT NULL DEFAULT '',
+        ssl_mode TEXT NOT NULL DEFAULT '',
+        ssl_ca TEXT NOT NULL DEFAULT '',
+        ssl_cert TEXT NOT NULL DEFAULT '',
+        ssl_key TEXT NOT NULL DEFAULT ''
     );
 
     CREATE TABLE IF NOT EXISTS saved_queries (
@@ -128,21 +186,60 @@ func createTables() error {
 	return err
 }
 
-// CreateConnection creates a new connection and returns its ID
-// It tests the connection before saving to ensure it's valid
-func CreateConnection(name, driverName, url string) (int64, error) {
-	// Test connection before saving
-	var driver drivers.Driver
+// connectionColumns are the columns added to the connections table since its
+// first version, with their definitions
+var connectionColumns = []struct{ name, definition string }{
+	{"ssh_host", "TEXT NOT NULL DEFAULT ''"},
+	{"ssh_port", "INTEGER NOT NULL DEFAULT 0"},
+	{"ssh_user", "TEXT NOT NULL DEFAULT ''"},
+	{"ssh_key_path", "TEXT NOT NULL DEFAULT ''"},
+	{"ssh_password", "TEXT NOT NULL DEFAULT ''"},
+	{"ssl_mode", "TEXT NOT NULL DEFAULT ''"},
+	{"ssl_ca", "TEXT NOT NULL DEFAULT ''"},
+	{"ssl_cert", "TEXT NOT NULL DEFAULT ''"},
+	{"ssl_key", "TEXT NOT NULL DEFAULT ''"},
+}
+
+// addMissingColumns adds the connectionColumns a storage file created by an
+// older version lacks
+func addMissingColumns() error {
+	rows, err := DB.Query("SELECT name FROM pragma_table_info('connections')")
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, col := range connectionColumns {
+		if existing[col.name] {
+			continue
+		}
+		if _, err := DB.Exec(fmt.Sprintf("ALTER TABLE connections ADD COLUMN %s %s", col.name, col.definition)); err != nil {
+			return fmt.Errorf("adding column %s: %w", col.name, err)
+		}
+	}
+	return nil
+}
 
-	switch driverName {
-	case drivers.DriverTypeMySQL:
-		driver = &drivers.MySQL{}
-	case drivers.DriverTypePostgreSQL:
-		driver = &drivers.PostgreSQL{}
-	case drivers.DriverTypeSQLite:
-		driver = &drivers.SQLite{}
-	default:
-		return 0, fmt.Errorf("unsupported driver: %s", driverName)
+// CreateConnection creates a new connection using opts (an SSH tunnel and SSL
+// settings, either of which may be unset) and returns its ID. It tests the
+// connection before saving to ensure it's valid
+func CreateConnection(name, driverName, url string, opts drivers.ConnectionOptions) (int64, error) {
+	// Test connection before saving
+	driver, err := drivers.NewWithOptions(driverName, opts)
+	if err != nil {
+		return 0, err
 	}
 
 	if err := driver.TestConnection(url); err != nil {
@@ -151,8 +248,10 @@ func CreateConnection(name, driverName, url string) (int64, error) {
 
 	// Connection is valid, save to database
 	result, err := DB.Exec(
-		"INSERT INTO connections (name, driver, url) VALUES (?, ?, ?)",
-		name, driverName, url,
+		`INSERT INTO connections (name, driver, url, ssh_host, ssh_port, ssh_user, ssh_key_path, ssh_password,
+		ssl_mode, ssl_ca, ssl_cert, ssl_key) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		name, driverName, url, opts.SSH.Host, opts.SSH.Port, opts.SSH.User, opts.SSH.KeyPath, opts.SSH.Password,
+		opts.TLS.Mode, opts.TLS.CAPath, opts.TLS.CertPath, opts.TLS.KeyPath,
 	)
 	if err != nil {
 		return 0, err
@@ -162,21 +261,20 @@ func CreateConnection(name, driverName, url string) (int64, error) {
 
 // GetConnection retrieves a connection by ID
 func GetConnection(id int64) (*Connection, error) {
-	conn := &Connection{}
-	err := DB.QueryRow(
-		"SELECT id, name, driver, url, created_at, updated_at FROM connections WHERE id = ?",
+	conn, err := scanConnection(DB.QueryRow(
+		"SELECT "+connectionSelectColumns+" FROM connections WHERE id = ?",
 		id,
-	).Scan(&conn.ID, &conn.Name, &conn.Driver, &conn.URL, &conn.CreatedAt, &conn.UpdatedAt)
+	))
 	if err != nil {
 		return nil, err
 	}
-	return conn, nil
+	return &conn, nil
 }
 
 // GetAllConnections retrieves all saved connections
 func GetAllConnections() ([]Connection, error) {
 	rows, err := DB.Query(
-		"SELECT id, name, driver, url, created_at, updated_at FROM connections ORDER BY name",
+		"SELECT " + connectionSelectColumns + " FROM connections ORDER BY name",
 	)
 	if err != nil {
 		return nil, err
@@ -185,8 +283,8 @@ func GetAllConnections() ([]Connection, error) {
 
 	var connections []Connection
 	for rows.Next() {
-		var conn Connection
-		if err := rows.Scan(&conn.ID, &conn.Name, &conn.Driver, &conn.URL, &conn.CreatedAt, &conn.UpdatedAt); err != nil {
+		conn, err := scanConnection(rows)
+		if err != nil {
 			return nil, err
 		}
 		connections = append(connections, conn)
@@ -194,11 +292,27 @@ func GetAllConnections() ([]Connection, error) {
 	return connections, rows.Err()
 }
 
-// UpdateConnection updates an existing connection
-func UpdateConnection(id int64, name, driver, url string) error {
+// connectionSelectColumns are the columns scanConnection reads, in its order
+const connectionSelectColumns = "id, name, driver, url, created_at, updated_at, ssh_host, ssh_port, ssh_user, ssh_key_path, ssh_password, ssl_mode, ssl_ca, ssl_cert, ssl_key"
+
+// scanConnection reads a connection selected with connectionSelectColumns
+func scanConnection(row interface{ Scan(dest ...any) error }) (Connection, error) {
+	var conn Connection
+	err := row.Scan(&conn.ID, &conn.Name, &conn.Driver, &conn.URL, &conn.CreatedAt, &conn.UpdatedAt,
+		&conn.SSHHost, &conn.SSHPort, &conn.SSHUser, &conn.SSHKeyPath, &conn.SSHPassword,
+		&conn.SSLMode, &conn.SSLCA, &conn.SSLCert, &conn.SSLKey)
+	return conn, err
+}
+
+// UpdateConnection updates an existing connection, along with its SSH tunnel and SSL settings
+func UpdateConnection(id int64, name, driver, url string, opts drivers.ConnectionOptions) error {
 	_, err := DB.Exec(
-		"UPDATE connections SET name = ?, driver = ?, url = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
-		name, driver, url, id,
+		`UPDATE connections SET name = ?, driver = ?, url = ?,
+		ssh_host = ?, ssh_port = ?, ssh_user = ?, ssh_key_path = ?, ssh_password = ?,
+		ssl_mode = ?, ssl_ca = ?, ssl_cert = ?, ssl_key = ?,
+		updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		name, driver, url, opts.SSH.Host, opts.SSH.Port, opts.SSH.User, opts.SSH.KeyPath, opts.SSH.Password,
+		opts.TLS.Mode, opts.TLS.CAPath, opts.TLS.CertPath, opts.TLS.KeyPath, id,
 	)
 	return err
 }
@@ -213,11 +327,11 @@ func DeleteConnection(id int64) error {
 // SavedQuery CRUD operations
 // =============================================================================
 
-// CreateSavedQuery creates a new saved query
+// CreateSavedQuery creates a new saved query; connectionID 0 saves it without a connection
 func CreateSavedQuery(connectionID int64, name, query string) (int64, error) {
 	result, err := DB.Exec(
 		"INSERT INTO saved_queries (connection_id, name, query) VALUES (?, ?, ?)",
-		connectionID, name, query,
+		sql.NullInt64{Int64: connectionID, Valid: connectionID != 0}, name, query,
 	)
 	if err != nil {
 		return 0, err
@@ -236,15 +350,7 @@ func GetSavedQueriesByConnection(connectionID int64) ([]SavedQuery, error) {
 	}
 	defer rows.Close()
 
-	var queries []SavedQuery
-	for rows.Next() {
-		var q SavedQuery
-		if err := rows.Scan(&q.ID, &q.ConnectionID, &q.Name, &q.Query, &q.CreatedAt, &q.UpdatedAt); err != nil {
-			return nil, err
-		}
-		queries = append(queries, q)
-	}
-	return queries, rows.Err()
+	return scanSavedQueries(rows)
 }
 
 // GetAllSavedQueries retrieves all saved queries
@@ -257,12 +363,19 @@ func GetAllSavedQueries() ([]SavedQuery, error) {
 	}
 	defer rows.Close()
 
+	return scanSavedQueries(rows)
+}
+
+// scanSavedQueries reads the saved queries selected by rows
+func scanSavedQueries(rows *sql.Rows) ([]SavedQuery, error) {
 	var queries []SavedQuery
 	for rows.Next() {
 		var q SavedQuery
-		if err := rows.Scan(&q.ID, &q.ConnectionID, &q.Name, &q.Query, &q.CreatedAt, &q.UpdatedAt); err != nil {
+		var connectionID sql.NullInt64
+		if err := rows.Scan(&q.ID, &connectionID, &q.Name, &q.Query, &q.CreatedAt, &q.UpdatedAt); err != nil {
 			return nil, err
 		}
+		q.ConnectionID = connectionID.Int64
 		queries = append(queries, q)
 	}
 	return queries, rows.Err()
@@ -277,6 +390,15 @@ func UpdateSavedQuery(id int64, name, query string) error {
 	return err
 }
 
+// RenameSavedQuery changes the name of a saved query
+func RenameSavedQuery(id int64, name string) error {
+	_, err := DB.Exec(
+		"UPDATE saved_queries SET name = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+		name, id,
+	)
+	return err
+}
+
 // DeleteSavedQuery deletes a saved query by ID
 func DeleteSavedQuery(id int64) error {
 	_, err := DB.Exec("DELETE FROM saved_queries WHERE id = ?", id)
@@ -369,17 +491,9 @@ func ClearAllQueryHistory() error {
 
 // Connect establishes a connection to an external database using the saved connection info
 func Connect(conn *Connection) (drivers.Driver, error) {
-	var driver drivers.Driver
-
-	switch conn.Driver {
-	case drivers.DriverTypeMySQL:
-		driver = &drivers.MySQL{}
-	case drivers.DriverTypePostgreSQL:
-		driver = &drivers.PostgreSQL{}
-	case drivers.DriverTypeSQLite:
-		driver = &drivers.SQLite{}
-	default:
-		return nil, fmt.Errorf("unsupported driver: %s", conn.Driver)
+	driver, err := drivers.NewWithOptions(conn.Driver, conn.Options())
+	if err != nil {
+		return nil, err
 	}
 
 	if err := driver.Connect(conn.URL); err != nil {
@@ -396,17 +510,9 @@ func TestConnectionByID(id int64) error {
 		return fmt.Errorf("connection not found: %w", err)
 	}
 
-	var driver drivers.Driver
-
-	switch conn.Driver {
-	case drivers.DriverTypeMySQL:
-		driver = &drivers.MySQL{}
-	case drivers.DriverTypePostgreSQL:
-		driver = &drivers.PostgreSQL{}
-	case drivers.DriverTypeSQLite:
-		driver = &drivers.SQLite{}
-	default:
-		return fmt.Errorf("unsupported driver: %s", conn.Driver)
+	driver, err := drivers.NewWithOptions(conn.Driver, conn.Options())
+	if err != nil {
+		return err
 	}
 
 	return driver.TestConnection(conn.URL)
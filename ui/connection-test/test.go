@@ -0,0 +1,131 @@
+package connectiontest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+)
+
+// lastSeq numbers the tests of every form, so the result of a test a form
+// dropped is never taken for the one another form runs
+var lastSeq int
+
+// TestedMsg reports the outcome of a connection test started from a form
+type TestedMsg struct {
+	seq     int
+	connStr string
+	opts    drivers.ConnectionOptions
+	latency time.Duration
+	version string
+	err     error
+}
+
+// Test runs a cancelable connection test in the background and remembers the
+// latency and server version of the last one that succeeded, with the connection
+// string and options they belong to
+type Test struct {
+	running bool
+	seq     int                // Identifies the running test, so results of cancelled ones are ignored
+	cancel  context.CancelFunc // Aborts the running test
+
+	summary string                    // Latency and server version of the last successful test
+	connStr string                    // Connection string the summary belongs to
+	opts    drivers.ConnectionOptions // SSH tunnel and SSL settings the summary belongs to
+}
+
+// Start tests connStr with a driverType driver connecting with opts. The returned
+// command measures the latency and reads the server version, reporting them in a
+// TestedMsg.
+func (t *Test) Start(driverType, connStr string, opts drivers.ConnectionOptions) (tea.Cmd, error) {
+	t.Reset()
+
+	driver, err := drivers.NewWithOptions(driverType, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lastSeq++
+	t.seq = lastSeq
+	t.running = true
+	t.cancel = cancel
+	seq := t.seq
+
+	return func() tea.Msg {
+		defer cancel()
+
+		start := time.Now()
+		if err := driver.TestConnectionContext(ctx, connStr); err != nil {
+			return TestedMsg{seq: seq, connStr: connStr, opts: opts, err: err}
+		}
+		latency := time.Since(start)
+
+		version, err := driver.ServerVersion(connStr)
+		if err != nil {
+			logger.Warn("Failed to read server version", map[string]any{
+				"driver": driverType,
+				"error":  err.Error(),
+			})
+			version = "unknown version"
+		}
+		return TestedMsg{seq: seq, connStr: connStr, opts: opts, latency: latency, version: version}
+	}, nil
+}
+
+// Handle records the latency and server version of a finished test and returns
+// the error if the connection failed. Results of other or cancelled tests are
+// dropped; ok is false for them.
+func (t *Test) Handle(msg TestedMsg) (ok bool, err error) {
+	if !t.running || msg.seq != t.seq {
+		return false, nil
+	}
+	t.Stop()
+
+	if msg.err != nil {
+		return true, msg.err
+	}
+
+	logger.Info("Connection test succeeded", map[string]any{
+		"latency": msg.latency.String(),
+		"version": msg.version,
+	})
+	t.summary = fmt.Sprintf("Connected in %dms · %s", msg.latency.Milliseconds(), msg.version)
+	t.connStr = msg.connStr
+	t.opts = msg.opts
+	return true, nil
+}
+
+// Stop marks the running test as finished, aborting it if it is still connecting
+func (t *Test) Stop() {
+	if t.cancel != nil {
+		t.cancel()
+		t.cancel = nil
+	}
+	t.running = false
+}
+
+// Reset stops the running test and forgets the last successful one
+func (t *Test) Reset() {
+	t.Stop()
+	t.summary = ""
+	t.connStr = ""
+	t.opts = drivers.ConnectionOptions{}
+}
+
+// Running reports whether a test is in progress
+func (t *Test) Running() bool {
+	return t.running
+}
+
+// Passed returns the latency and server version of the last successful test
+// when it tested connStr with opts, and whether it did
+func (t *Test) Passed(connStr string, opts drivers.ConnectionOptions) (string, bool) {
+	if t.summary == "" || connStr != t.connStr || opts != t.opts {
+		return "", false
+	}
+	return t.summary, true
+}
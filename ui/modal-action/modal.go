@@ -7,6 +7,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/config"
+	"github.com/sheenazien8/sq/drivers"
 	"github.com/sheenazien8/sq/ui/modal"
 	"github.com/sheenazien8/sq/ui/theme"
 )
@@ -23,6 +25,19 @@ const (
 	ActionCopyCell
 	ActionCopyJSON
 	ActionCopySQL
+	ActionCopyWhere
+	ActionCopySelect
+	ActionCopyCellJSON
+	ActionFilterIsNull
+	ActionFilterIsNotNull
+	ActionCopyMarkedSQL
+	ActionCopyMarkedMultiSQL
+	ActionInsertRow
+	ActionCopyMarkedCSV
+	ActionCopyMarkedJSON
+	ActionDeleteMarked
+	ActionSetNullMarked
+	ActionFilterContains
 )
 
 // Model wraps the generic modal with action content
@@ -41,6 +56,44 @@ func New() Model {
 	}
 }
 
+// SetIdentifierQuoter sets the function used to quote column names in generated SQL
+func (m *Model) SetIdentifierQuoter(quote func(string) string) {
+	m.content.quoteIdentifier = quote
+}
+
+// SetSQLStyle sets the style of generated SQL and the schema used to qualify table names
+func (m *Model) SetSQLStyle(style config.SQLStyle, schema string) {
+	m.content.sqlStyle = style
+	m.content.schema = schema
+}
+
+// SetTableSchema sets the schema of a table outside the connection's schema, whose
+// name is then schema.table; empty for tables of the connection's schema
+func (m *Model) SetTableSchema(schema string) {
+	m.content.tableSchema = schema
+}
+
+// SetMarkedRows sets the rows marked in the table, offered for copying together;
+// nil hides the marked row actions
+func (m *Model) SetMarkedRows(rows [][]string) {
+	m.content.markedRows = rows
+}
+
+// MarkedRowCount returns the number of rows marked when the modal was opened
+func (m Model) MarkedRowCount() int {
+	return len(m.content.markedRows)
+}
+
+// GetMarkedRows returns the rows marked when the modal was opened
+func (m Model) GetMarkedRows() [][]string {
+	return m.content.markedRows
+}
+
+// SetReadOnly limits the modal to copy actions, for views and other read-only tabs
+func (m *Model) SetReadOnly(readOnly bool) {
+	m.content.SetReadOnly(readOnly)
+}
+
 // Show displays the modal with the given cell and row context
 func (m *Model) Show(cellValue string, rowData []string, columnNames []string, selectedCol int, tableName string) {
 	m.content.SetContext(cellValue, rowData, columnNames, selectedCol, tableName)
@@ -116,7 +169,9 @@ func (m Model) GetActionData(action Action) string {
 
 // ActionContent implements Content for action selection
 type ActionContent struct {
-	actions []ActionItem
+	actions    []ActionItem
+	allActions []ActionItem
+	readOnly   bool
 
 	selectedIndex  int
 	selectedAction Action
@@ -129,6 +184,20 @@ type ActionContent struct {
 	selectedCol int
 	tableName   string
 
+	// Rows marked in the table, copied together as INSERT statements
+	markedRows [][]string
+
+	// quoteIdentifier quotes column names for the active driver
+	quoteIdentifier func(string) string
+
+	// Generated SQL style and the schema to qualify table names with
+	sqlStyle config.SQLStyle
+	schema   string
+
+	// Schema of a table outside the connection's schema, named schema.table;
+	// generated SQL always qualifies such tables
+	tableSchema string
+
 	width  int
 	closed bool
 }
@@ -143,20 +212,112 @@ type ActionItem struct {
 
 // NewActionContent creates a new action content
 func NewActionContent() *ActionContent {
-	return &ActionContent{
-		actions: []ActionItem{
-			{ActionDeleteRow, "Delete Row", "Delete this entire row/record", "d"},
-			{ActionSetNull, "Set NULL", "Set this cell value to NULL", "n"},
-			{ActionSetEmpty, "Set Empty", "Set this cell value to empty string", "e"},
-			{ActionEditCell, "Edit Cell", "Edit this cell value", "i"},
-			{ActionCopyCell, "Copy Cell", "Copy cell value to clipboard", "c"},
-			{ActionCopyJSON, "Copy as JSON", "Copy row data as JSON", "j"},
-			{ActionCopySQL, "Copy as SQL", "Copy row data as SQL syntax", "s"},
-		},
-		selectedIndex:  4, // Default to copy cell
+	actions := []ActionItem{
+		{ActionInsertRow, "Insert Row", "Add a new row to this table", "o"},
+		{ActionDeleteRow, "Delete Row", "Delete this entire row/record", "d"},
+		{ActionSetNull, "Set NULL", "Set this cell value to NULL", "n"},
+		{ActionSetEmpty, "Set Empty", "Set this cell value to empty string", "e"},
+		{ActionEditCell, "Edit Cell", "Edit this cell value", "i"},
+		{ActionCopyCell, "Copy Cell", "Copy cell value to clipboard", "c"},
+		{ActionCopyJSON, "Copy as JSON", "Copy row data as JSON", "j"},
+		{ActionCopyCellJSON, "Copy Cell as JSON", "Copy {\"column\": value} for this cell", "J"},
+		{ActionCopySQL, "Copy as SQL", "Copy row data as SQL syntax", "s"},
+		{ActionCopyWhere, "Copy as WHERE", "Copy column = value for a WHERE clause", "w"},
+		{ActionCopySelect, "Copy as SELECT", "Copy SELECT column FROM table for a new query", "S"},
+		{ActionFilterIsNull, "Filter IS NULL", "Show only rows where this column IS NULL", "f"},
+		{ActionFilterIsNotNull, "Filter IS NOT NULL", "Show only rows where this column IS NOT NULL", "F"},
+		{ActionFilterContains, "Filter Contains", "Show only rows where this column contains the cell value", "l"},
+		{ActionCopyMarkedSQL, "Copy Marked as SQL", "Copy the marked rows as one INSERT each", "m"},
+		{ActionCopyMarkedMultiSQL, "Copy Marked as One INSERT", "Copy the marked rows as a single multi-row INSERT", "M"},
+		{ActionCopyMarkedCSV, "Copy Marked as CSV", "Copy the marked rows as CSV with a header", "C"},
+		{ActionCopyMarkedJSON, "Copy Marked as JSON", "Copy the marked rows as a JSON array", "Y"},
+		{ActionDeleteMarked, "Delete Marked Rows", "Delete every marked row", "D"},
+		{ActionSetNullMarked, "Set NULL in Marked", "Set this column to NULL in every marked row", "N"},
+	}
+	a := &ActionContent{
+		actions:        actions,
+		allActions:     actions,
 		selectedAction: ActionNone,
+		sqlStyle:       config.SQLStyle{QuoteIdentifiers: true, TrailingSemicolon: true},
 		closed:         false,
 	}
+	a.selectedIndex = a.defaultIndex() // Default to copy cell
+	return a
+}
+
+// IsCopyAction returns true for actions that only copy data and never modify the database
+func IsCopyAction(action Action) bool {
+	switch action {
+	case ActionCopyCell, ActionCopyJSON, ActionCopyCellJSON, ActionCopySQL, ActionCopyWhere, ActionCopySelect,
+		ActionCopyMarkedSQL, ActionCopyMarkedMultiSQL, ActionCopyMarkedCSV, ActionCopyMarkedJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsCellEditAction returns true for actions that write a new value into the selected cell
+func IsCellEditAction(action Action) bool {
+	switch action {
+	case ActionEditCell, ActionSetNull, ActionSetEmpty, ActionSetNullMarked:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsMarkedAction returns true for actions on the rows marked in the table rather than the selected row
+func IsMarkedAction(action Action) bool {
+	switch action {
+	case ActionCopyMarkedSQL, ActionCopyMarkedMultiSQL, ActionCopyMarkedCSV, ActionCopyMarkedJSON,
+		ActionDeleteMarked, ActionSetNullMarked:
+		return true
+	}
+	return false
+}
+
+// IsFilterAction returns true for actions that filter the table by the cell
+func IsFilterAction(action Action) bool {
+	return action == ActionFilterIsNull || action == ActionFilterIsNotNull || action == ActionFilterContains
+}
+
+// SetReadOnly hides the actions that modify data
+func (a *ActionContent) SetReadOnly(readOnly bool) {
+	a.readOnly = readOnly
+	a.updateActions()
+}
+
+// updateActions lists the actions that apply to the tab and cell: read-only tabs
+// only copy and filter, IS NULL is only offered on a NULL cell, contains only on
+// a non-NULL one, and the marked row
+// actions only when rows are marked
+func (a *ActionContent) updateActions() {
+	a.actions = nil
+	for _, item := range a.allActions {
+		if a.readOnly && !IsCopyAction(item.Action) && !IsFilterAction(item.Action) {
+			continue
+		}
+		if item.Action == ActionFilterIsNull && a.cellValue != "NULL" {
+			continue
+		}
+		if item.Action == ActionFilterContains && a.cellValue == "NULL" {
+			continue
+		}
+		if IsMarkedAction(item.Action) && len(a.markedRows) == 0 {
+			continue
+		}
+		a.actions = append(a.actions, item)
+	}
+}
+
+// defaultIndex returns the index of the copy cell action
+func (a *ActionContent) defaultIndex() int {
+	for i, item := range a.actions {
+		if item.Action == ActionCopyCell {
+			return i
+		}
+	}
+	return 0
 }
 
 // SetContext sets the cell and row context for the actions
@@ -168,7 +329,8 @@ func (a *ActionContent) SetContext(cellValue string, rowData []string, columnNam
 	copy(a.columnNames, columnNames)
 	a.selectedCol = selectedCol
 	a.tableName = tableName
-	a.selectedIndex = 4 // Reset to copy cell
+	a.updateActions()
+	a.selectedIndex = a.defaultIndex() // Reset to copy cell
 	a.selectedAction = ActionNone
 	a.confirmed = false
 	a.closed = false
@@ -223,6 +385,9 @@ func (a *ActionContent) View() string {
 	// Context info - left aligned within available width
 	contextStyle := t.StatusBar.Copy().Padding(0, 1)
 	contextInfo := fmt.Sprintf("Table: %s | Cell: %s", a.tableName, truncateCell(a.cellValue, 30))
+	if a.readOnly {
+		contextInfo += " | view (read-only)"
+	}
 	contextLine := contextStyle.Width(a.width).Align(lipgloss.Left).Render(contextInfo)
 	lines = append(lines, contextLine)
 
@@ -296,8 +461,26 @@ func (a *ActionContent) GetActionData(action Action) string {
 		return a.cellValue
 	case ActionCopyJSON:
 		return a.getRowAsJSON()
+	case ActionCopyCellJSON:
+		return a.getCellAsJSON()
 	case ActionCopySQL:
 		return a.getRowAsSQL()
+	case ActionCopyWhere:
+		return a.getCellAsWhere()
+	case ActionCopySelect:
+		return a.getColumnAsSelect()
+	case ActionFilterIsNull:
+		return a.getNullCondition(true)
+	case ActionFilterIsNotNull:
+		return a.getNullCondition(false)
+	case ActionCopyMarkedSQL:
+		return a.getMarkedRowsAsSQL(false)
+	case ActionCopyMarkedMultiSQL:
+		return a.getMarkedRowsAsSQL(true)
+	case ActionCopyMarkedCSV:
+		return a.getMarkedRowsAs(drivers.ExportFormatCSV)
+	case ActionCopyMarkedJSON:
+		return a.getMarkedRowsAs(drivers.ExportFormatJSON)
 	default:
 		return ""
 	}
@@ -315,8 +498,10 @@ func (a *ActionContent) getRowAsJSON() string {
 		minLen = len(a.columnNames)
 	}
 
+	// Repeated column names would overwrite each other's keys
+	keys := drivers.UniqueColumnNames(a.columnNames)
 	for i := 0; i < minLen; i++ {
-		rowMap[a.columnNames[i]] = a.rowData[i]
+		rowMap[keys[i]] = a.rowData[i]
 	}
 
 	jsonBytes, err := json.MarshalIndent(rowMap, "", "  ")
@@ -326,30 +511,173 @@ func (a *ActionContent) getRowAsJSON() string {
 	return string(jsonBytes)
 }
 
+// getCellAsJSON returns the selected cell as a one-key JSON object, with NULL as null.
+// Other values stay strings like in getRowAsJSON, the cell text doesn't say
+// whether "007" or "true" was a number, a boolean or text.
+func (a *ActionContent) getCellAsJSON() string {
+	if a.selectedCol < 0 || a.selectedCol >= len(a.columnNames) {
+		return ""
+	}
+
+	var value any = a.cellValue
+	if a.cellValue == "NULL" {
+		value = nil
+	}
+
+	jsonBytes, err := json.Marshal(map[string]any{a.columnNames[a.selectedCol]: value})
+	if err != nil {
+		return fmt.Sprintf("{\"error\": \"Failed to marshal JSON: %v\"}", err)
+	}
+	return string(jsonBytes)
+}
+
 // getRowAsSQL returns the row data as SQL INSERT syntax
 func (a *ActionContent) getRowAsSQL() string {
 	if len(a.rowData) == 0 || len(a.columnNames) == 0 || a.tableName == "" {
 		return "-- No data available"
 	}
 
-	minLen := len(a.rowData)
-	if len(a.columnNames) < minLen {
-		minLen = len(a.columnNames)
+	return a.statement(fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+		a.qualifiedTableName(), a.insertColumns(len(a.rowData)), a.insertValues(a.rowData)))
+}
+
+// getMarkedRowsAsSQL returns the marked rows as one INSERT per row, or as a
+// single INSERT with a values list per row when multiRow is set
+func (a *ActionContent) getMarkedRowsAsSQL(multiRow bool) string {
+	if len(a.markedRows) == 0 || len(a.columnNames) == 0 || a.tableName == "" {
+		return "-- No rows marked"
 	}
 
+	table := a.qualifiedTableName()
+	columns := a.insertColumns(len(a.markedRows[0]))
+	if multiRow {
+		var values []string
+		for _, row := range a.markedRows {
+			values = append(values, a.insertValues(row))
+		}
+		return a.statement(fmt.Sprintf("INSERT INTO %s (%s) VALUES\n  %s",
+			table, columns, strings.Join(values, ",\n  ")))
+	}
+
+	var statements []string
+	for _, row := range a.markedRows {
+		statements = append(statements, a.statement(fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+			table, columns, a.insertValues(row))))
+	}
+	return strings.Join(statements, "\n")
+}
+
+// getMarkedRowsAs returns the marked rows written the way they are exported in format
+func (a *ActionContent) getMarkedRowsAs(format drivers.ExportFormat) string {
+	var b strings.Builder
+	if _, err := drivers.ExportRows(format, &b, a.columnNames, a.markedRows, a.tableName, a.identifier); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+// insertColumns returns the quoted column list of an INSERT for rows of n values
+func (a *ActionContent) insertColumns(n int) string {
 	var columns []string
-	var values []string
+	for i := 0; i < min(n, len(a.columnNames)); i++ {
+		columns = append(columns, a.identifier(a.columnNames[i]))
+	}
+	return strings.Join(columns, ", ")
+}
 
-	for i := 0; i < minLen; i++ {
-		// Use double quotes for identifiers (PostgreSQL/SQL standard)
-		columns = append(columns, fmt.Sprintf("\"%s\"", a.columnNames[i]))
+// insertValues returns the parenthesized values of row for an INSERT
+func (a *ActionContent) insertValues(row []string) string {
+	var values []string
+	for i := 0; i < min(len(row), len(a.columnNames)); i++ {
 		// Escape single quotes in the value
-		escapedValue := strings.ReplaceAll(a.rowData[i], "'", "''")
+		escapedValue := strings.ReplaceAll(row[i], "'", "''")
 		values = append(values, fmt.Sprintf("'%s'", escapedValue))
 	}
+	return "(" + strings.Join(values, ", ") + ")"
+}
+
+// identifier returns a table or column name quoted according to the SQL style
+func (a *ActionContent) identifier(name string) string {
+	if !a.sqlStyle.QuoteIdentifiers {
+		return name
+	}
+	if a.quoteIdentifier != nil {
+		return a.quoteIdentifier(name)
+	}
+	// Use double quotes for identifiers (PostgreSQL/SQL standard)
+	return fmt.Sprintf("\"%s\"", name)
+}
+
+// sqlTableName returns the table name without the schema of a table outside the connection's schema
+func (a *ActionContent) sqlTableName() string {
+	if a.tableSchema != "" {
+		return strings.TrimPrefix(a.tableName, a.tableSchema+".")
+	}
+	return a.tableName
+}
+
+// qualifiedTableName returns the table name, prefixed with the schema when the SQL style asks for it
+// or the table is outside the connection's schema
+func (a *ActionContent) qualifiedTableName() string {
+	if a.tableSchema != "" {
+		return a.identifier(a.tableSchema) + "." + a.identifier(a.sqlTableName())
+	}
+	if a.sqlStyle.QualifySchema && a.schema != "" {
+		return a.identifier(a.schema) + "." + a.identifier(a.tableName)
+	}
+	return a.identifier(a.tableName)
+}
+
+// statement terminates a full SQL statement according to the SQL style
+func (a *ActionContent) statement(sql string) string {
+	if a.sqlStyle.TrailingSemicolon {
+		return sql + ";"
+	}
+	return sql
+}
+
+// getCellAsWhere returns the selected cell as a "column = 'value'" WHERE fragment
+func (a *ActionContent) getCellAsWhere() string {
+	if a.selectedCol < 0 || a.selectedCol >= len(a.columnNames) {
+		return ""
+	}
+
+	column := a.identifier(a.columnNames[a.selectedCol])
+
+	// NULL never matches with =, so use IS NULL instead
+	if a.cellValue == "NULL" {
+		return column + " IS NULL"
+	}
+
+	escapedValue := strings.ReplaceAll(a.cellValue, "'", "''")
+	return fmt.Sprintf("%s = '%s'", column, escapedValue)
+}
+
+// getNullCondition returns "column IS NULL", or "column IS NOT NULL", for the selected column
+func (a *ActionContent) getNullCondition(isNull bool) string {
+	if a.selectedCol < 0 || a.selectedCol >= len(a.columnNames) {
+		return ""
+	}
+
+	column := a.identifier(a.columnNames[a.selectedCol])
+	if isNull {
+		return column + " IS NULL"
+	}
+	return column + " IS NOT NULL"
+}
+
+// getColumnAsSelect returns a SELECT of the selected column from the schema-qualified table
+func (a *ActionContent) getColumnAsSelect() string {
+	if a.selectedCol < 0 || a.selectedCol >= len(a.columnNames) || a.tableName == "" {
+		return ""
+	}
+
+	table := a.identifier(a.sqlTableName())
+	if a.tableSchema != "" {
+		table = a.identifier(a.tableSchema) + "." + table
+	} else if a.schema != "" {
+		table = a.identifier(a.schema) + "." + table
+	}
 
-	return fmt.Sprintf("INSERT INTO \"%s\" (%s) VALUES (%s);",
-		a.tableName,
-		strings.Join(columns, ", "),
-		strings.Join(values, ", "))
+	return a.statement(fmt.Sprintf("SELECT %s FROM %s", a.identifier(a.columnNames[a.selectedCol]), table))
 }
@@ -0,0 +1,57 @@
+package modalaction
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetCellAsJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+		value  string
+		want   string
+	}{
+		{"text", "name", "Ada", `{"name":"Ada"}`},
+		{"null", "deleted_at", "NULL", `{"deleted_at":null}`},
+		{"empty", "note", "", `{"note":""}`},
+		{"quotes", "quote", `say "hi"`, `{"quote":"say \"hi\""}`},
+		{"backslash", "path", `C:\temp`, `{"path":"C:\\temp"}`},
+		{"newline and tab", "body", "a\n\tb", `{"body":"a\n\tb"}`},
+		{"quoted column", `weird "col"`, "x", `{"weird \"col\"":"x"}`},
+		{"html", "html", "<b>&</b>", `{"html":"\u003cb\u003e\u0026\u003c/b\u003e"}`},
+		{"unicode", "city", "Zürich", `{"city":"Zürich"}`},
+		{"number stays a string", "zip", "007", `{"zip":"007"}`},
+		{"integer stays a string", "id", "42", `{"id":"42"}`},
+		{"decimal stays a string", "price", "9.90", `{"price":"9.90"}`},
+		{"boolean stays a string", "active", "true", `{"active":"true"}`},
+		{"null text in lowercase", "word", "null", `{"word":"null"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewActionContent()
+			a.SetContext(tt.value, []string{"1", tt.value}, []string{"other", tt.column}, 1, "t")
+
+			got := a.getCellAsJSON()
+			if got != tt.want {
+				t.Errorf("getCellAsJSON() = %s, want %s", got, tt.want)
+			}
+
+			var decoded map[string]any
+			if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+				t.Fatalf("getCellAsJSON() = %s is not valid JSON: %v", got, err)
+			}
+			if len(decoded) != 1 {
+				t.Errorf("getCellAsJSON() = %s, want a single key", got)
+			}
+		})
+	}
+}
+
+func TestGetCellAsJSONWithoutColumn(t *testing.T) {
+	a := NewActionContent()
+	a.SetContext("x", []string{"x"}, []string{"a"}, 3, "t")
+	if got := a.getCellAsJSON(); got != "" {
+		t.Errorf("getCellAsJSON() = %q for a column out of range, want empty", got)
+	}
+}
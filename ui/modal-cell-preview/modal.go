@@ -18,8 +18,13 @@ type Model struct {
 
 // New creates a new cell preview modal
 func New() Model {
+	return NewWithTitle("Cell Preview")
+}
+
+// NewWithTitle creates a preview modal with a custom title, for showing other read-only text
+func NewWithTitle(title string) Model {
 	content := NewPreviewContent()
-	m := modal.New("Cell Preview", content)
+	m := modal.New(title, content)
 	return Model{
 		modal:   m,
 		content: content,
@@ -45,6 +50,7 @@ func (m Model) Visible() bool {
 // SetSize sets the terminal size for centering
 func (m *Model) SetSize(width, height int) {
 	m.modal.SetSize(width, height)
+	m.content.SetMaxHeight(height)
 }
 
 // Update handles input
@@ -70,6 +76,7 @@ type PreviewContent struct {
 	rawContent string
 	width      int
 	height     int
+	maxHeight  int // Tallest the preview may be to fit the terminal, 0 = no limit
 	closed     bool
 }
 
@@ -154,7 +161,16 @@ func (p *PreviewContent) ShouldClose() bool {
 func (p *PreviewContent) SetWidth(width int) {
 	p.width = width
 	p.height = 20 // Compact height with scrolling
+	if p.maxHeight > 0 {
+		p.height = max(5, min(p.height, p.maxHeight))
+	}
 	p.viewport.Width = width
 	p.viewport.Height = p.height - 2 // Account for info line
 	p.updateViewportContent()        // Re-wrap content with new width
 }
+
+// SetMaxHeight limits the preview height to fit a terminal terminalHeight rows tall
+func (p *PreviewContent) SetMaxHeight(terminalHeight int) {
+	p.maxHeight = terminalHeight - 6 // Dialog border, padding and title
+	p.SetWidth(p.width)
+}
@@ -200,7 +200,7 @@ func (m *Model) Visible() bool {
 // SetSize sets the terminal size for centering
 func (m *Model) SetSize(width, height int) {
 	m.modal.SetSize(width, height)
-	m.content.SetWidth(60)
+	m.content.SetWidth(modal.ContentWidth(width, 60))
 }
 
 // Update handles input
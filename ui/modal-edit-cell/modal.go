@@ -1,12 +1,15 @@
 package modaleditcell
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sheenazien8/sq/ui/modal"
+	syntaxeditor "github.com/sheenazien8/sq/ui/syntax-editor"
 	"github.com/sheenazien8/sq/ui/theme"
 )
 
@@ -32,6 +35,13 @@ func (m *Model) Show(currentValue, columnName, tableName string) {
 	m.modal.Show()
 }
 
+// ShowJSON displays the modal with a multi-line JSON editor for a json/jsonb
+// cell; the document is validated before the edit is confirmed
+func (m *Model) ShowJSON(currentValue, columnName, tableName string) {
+	m.content.SetJSONValue(currentValue, columnName, tableName)
+	m.modal.Show()
+}
+
 // Hide hides the modal
 func (m *Model) Hide() {
 	m.modal.Hide()
@@ -76,16 +86,25 @@ func (m Model) Confirmed() bool {
 
 // EditCellContent implements Content for cell editing
 type EditCellContent struct {
-	columnName string
-	tableName  string
-	input      textinput.Model
-	result     modal.Result
-	closed     bool
-	width      int
+	columnName    string
+	tableName     string
+	originalValue string // Value before the edit, shown next to the new one
+	input         textinput.Model
+	result        modal.Result
+	closed        bool
+	width         int
+
+	// JSON columns are edited in a multi-line editor and only submitted when valid
+	json      bool
+	editor    syntaxeditor.Model
+	jsonError string
 }
 
 const maxInputWidth = 60
 
+// jsonEditorHeight is the number of lines of the JSON editor
+const jsonEditorHeight = 12
+
 // NewEditCellContent creates a new edit cell content
 func NewEditCellContent() *EditCellContent {
 	ti := textinput.New()
@@ -93,8 +112,13 @@ func NewEditCellContent() *EditCellContent {
 	ti.CharLimit = 1000
 	ti.Width = maxInputWidth
 
+	editor := syntaxeditor.New()
+	editor.SetLanguage("json")
+	editor.SetCursorStyle(syntaxeditor.CursorLine)
+
 	return &EditCellContent{
 		input:  ti,
+		editor: editor,
 		result: modal.ResultNone,
 		closed: false,
 	}
@@ -104,14 +128,42 @@ func NewEditCellContent() *EditCellContent {
 func (e *EditCellContent) SetValue(currentValue, columnName, tableName string) {
 	e.columnName = columnName
 	e.tableName = tableName
+	e.originalValue = currentValue
 	e.input.SetValue(currentValue)
 	e.input.Focus()
 	e.result = modal.ResultNone
 	e.closed = false
+	e.json = false
 }
 
-// GetValue returns the current input value
+// SetJSONValue sets the current value of a JSON column, indented for editing
+func (e *EditCellContent) SetJSONValue(currentValue, columnName, tableName string) {
+	e.SetValue(currentValue, columnName, tableName)
+	e.json = true
+	e.jsonError = ""
+
+	value := currentValue
+	if value == "NULL" {
+		value = ""
+	}
+	var indented bytes.Buffer
+	if json.Indent(&indented, []byte(value), "", "  ") == nil {
+		value = indented.String()
+	}
+	e.editor.SetValue(value)
+	e.editor.CursorStart()
+	e.editor.Focus()
+}
+
+// GetValue returns the current input value; JSON is returned compacted
 func (e *EditCellContent) GetValue() string {
+	if e.json {
+		var compacted bytes.Buffer
+		if json.Compact(&compacted, []byte(e.editor.Value())) == nil {
+			return compacted.String()
+		}
+		return strings.TrimSpace(e.editor.Value())
+	}
 	return strings.TrimSpace(e.input.Value())
 }
 
@@ -121,6 +173,9 @@ func (e *EditCellContent) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if e.json {
+			return e.updateJSON(msg)
+		}
 		switch msg.String() {
 		case "enter":
 			// Confirm the edit
@@ -141,6 +196,31 @@ func (e *EditCellContent) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
 	return e, cmd
 }
 
+// updateJSON handles keys in the JSON editor, where Enter starts a new line and
+// Ctrl+S confirms the edit once the document parses
+func (e *EditCellContent) updateJSON(msg tea.KeyMsg) (modal.Content, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+s":
+		var document any
+		if err := json.Unmarshal([]byte(e.editor.Value()), &document); err != nil {
+			e.jsonError = "Invalid JSON: " + err.Error()
+			return e, nil
+		}
+		e.result = modal.ResultSubmit
+		e.closed = true
+		return e, nil
+	case "esc":
+		e.result = modal.ResultCancel
+		e.closed = true
+		return e, nil
+	}
+
+	var cmd tea.Cmd
+	e.editor, cmd = e.editor.Update(msg)
+	e.jsonError = ""
+	return e, cmd
+}
+
 // View renders the content
 func (e *EditCellContent) View() string {
 	if e.width == 0 {
@@ -163,16 +243,46 @@ func (e *EditCellContent) View() string {
 
 	// Input field with label - left aligned
 	inputLabel := "New value:"
+	if e.json {
+		inputLabel = "New value (JSON):"
+	}
 	labelStyle := t.TableCell.Copy().Bold(true)
 	labelLine := labelStyle.Width(e.width).Align(lipgloss.Left).Render(inputLabel)
 	lines = append(lines, labelLine)
 
+	if e.json {
+		lines = append(lines, lipgloss.NewStyle().Padding(0, 1).Render(e.editor.View()))
+		if e.jsonError != "" {
+			errorStyle := lipgloss.NewStyle().Foreground(t.Colors.Error).Padding(0, 1)
+			lines = append(lines, errorStyle.Width(e.width).Render(e.jsonError))
+		}
+		helpStyle := lipgloss.NewStyle().Foreground(t.Colors.ForegroundDim).Padding(1, 0, 0, 0)
+		help := helpStyle.Width(e.width).Align(lipgloss.Left).Render("Ctrl+S: Validate and confirm | Enter: New line | Esc: Cancel")
+		lines = append(lines, help)
+		return strings.Join(lines, "\n")
+	}
+
 	// Input field - left aligned
 	inputStyle := t.TableCell.Copy().Padding(0, 1)
 	inputDisplay := e.input.View()
 	inputLine := inputStyle.Width(e.width).Align(lipgloss.Left).Render(inputDisplay)
 	lines = append(lines, inputLine)
 
+	// Old and new value side by side once the input differs, so a stray keystroke is visible
+	if newValue := e.GetValue(); newValue != strings.TrimSpace(e.originalValue) {
+		lines = append(lines, separatorLine)
+		lines = append(lines, labelStyle.Width(e.width).Align(lipgloss.Left).Render("Change:"))
+
+		// Leave room for the padding and the arrow between the values
+		valueWidth := max((e.width-2-3)/2, 1)
+		oldStyle := lipgloss.NewStyle().Foreground(t.Colors.Error).Strikethrough(true)
+		newStyle := lipgloss.NewStyle().Foreground(t.Colors.Success).Bold(true)
+		change := oldStyle.Render(truncateValue(displayValue(e.originalValue), valueWidth)) +
+			" → " +
+			newStyle.Render(truncateValue(displayValue(newValue), valueWidth))
+		lines = append(lines, lipgloss.NewStyle().Padding(0, 1).Width(e.width).Render(change))
+	}
+
 	// Help text - left aligned
 	helpStyle := lipgloss.NewStyle().Foreground(t.Colors.ForegroundDim).Padding(1, 0, 0, 0)
 	help := helpStyle.Width(e.width).Align(lipgloss.Left).Render("Enter: Confirm | Esc: Cancel")
@@ -195,6 +305,28 @@ func (e *EditCellContent) ShouldClose() bool {
 func (e *EditCellContent) SetWidth(width int) {
 	e.width = width
 	e.input.Width = min(width-4, maxInputWidth) // Account for padding
+	// Leave room for the padding and the editor's border
+	e.editor.SetSize(max(width-4, 10), jsonEditorHeight)
+}
+
+// displayValue makes empty values visible in the change line
+func displayValue(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	return value
+}
+
+// truncateValue shortens a value to at most width columns, ending it with an ellipsis
+func truncateValue(value string, width int) string {
+	if lipgloss.Width(value) <= width {
+		return value
+	}
+	runes := []rune(value)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
 }
 
 // Helper function
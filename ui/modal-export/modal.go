@@ -0,0 +1,316 @@
+package modalexport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/modal"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// Scope is the set of rows an export covers
+type Scope int
+
+const (
+	ScopePage   Scope = iota // The rows shown
+	ScopeAll                 // Every row matching the filter, fetched from the database
+	ScopeMarked              // The rows marked with m
+)
+
+// field is one line of the export form
+type field int
+
+const (
+	fieldFormat field = iota
+	fieldScope
+	fieldPath
+	fieldCount
+)
+
+// Content implements modal.Content for choosing the format, rows and file of an export
+type Content struct {
+	source string // Table or result set being exported
+	scopes []Scope
+	marked int
+
+	format int // Index into drivers.ExportFormats
+	scope  int // Index into scopes
+	input  textinput.Model
+	field  field
+
+	result modal.Result
+	closed bool
+	width  int
+}
+
+// NewContent creates a new export content
+func NewContent() *Content {
+	ti := textinput.New()
+	ti.Placeholder = "file path"
+	ti.CharLimit = 1024
+	ti.Width = 50
+	return &Content{
+		input:  ti,
+		result: modal.ResultNone,
+	}
+}
+
+// SetExport resets the form for exporting source to path, offering scopes;
+// marked is the number of marked rows ScopeMarked exports
+func (c *Content) SetExport(source, path string, scopes []Scope, marked int) {
+	c.source = source
+	c.scopes = scopes
+	c.marked = marked
+	c.format = 0
+	c.scope = 0
+	c.field = fieldFormat
+	c.input.SetValue(path)
+	c.input.CursorEnd()
+	c.input.Blur()
+	c.result = modal.ResultNone
+	c.closed = false
+}
+
+func (c *Content) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		logger.Debug("Export cancelled", map[string]any{"source": c.source})
+		c.result = modal.ResultCancel
+		c.closed = true
+		return c, nil
+	case "enter":
+		if strings.TrimSpace(c.input.Value()) == "" {
+			c.field = fieldPath
+			return c, c.input.Focus()
+		}
+		c.result = modal.ResultSubmit
+		c.closed = true
+		return c, nil
+	case "tab", "down":
+		return c, c.focusField((c.field + 1) % fieldCount)
+	case "shift+tab", "up":
+		return c, c.focusField((c.field + fieldCount - 1) % fieldCount)
+	}
+
+	if c.field == fieldPath {
+		var cmd tea.Cmd
+		c.input, cmd = c.input.Update(msg)
+		return c, cmd
+	}
+
+	delta := 0
+	switch keyMsg.String() {
+	case "right", "l", " ":
+		delta = 1
+	case "left", "h":
+		delta = -1
+	}
+	if delta == 0 {
+		return c, nil
+	}
+	if c.field == fieldFormat {
+		c.setFormat((c.format + delta + len(drivers.ExportFormats)) % len(drivers.ExportFormats))
+	} else if len(c.scopes) > 0 {
+		c.scope = (c.scope + delta + len(c.scopes)) % len(c.scopes)
+	}
+	return c, nil
+}
+
+// focusField moves to field f, focusing the path input when it is the one
+func (c *Content) focusField(f field) tea.Cmd {
+	c.field = f
+	if f == fieldPath {
+		return c.input.Focus()
+	}
+	c.input.Blur()
+	return nil
+}
+
+// setFormat selects the format at index, swapping the extension of the path
+// when it still has the one of the previous format
+func (c *Content) setFormat(index int) {
+	oldExt := "." + drivers.ExportFormats[c.format].Extension()
+	c.format = index
+	if path := c.input.Value(); strings.HasSuffix(path, oldExt) {
+		c.input.SetValue(strings.TrimSuffix(path, oldExt) + "." + drivers.ExportFormats[index].Extension())
+		c.input.CursorEnd()
+	}
+}
+
+// scopeLabel returns the name of scope shown in the form
+func (c *Content) scopeLabel(scope Scope) string {
+	switch scope {
+	case ScopeAll:
+		return "All rows"
+	case ScopeMarked:
+		return fmt.Sprintf("Marked rows (%d)", c.marked)
+	}
+	return "Current page"
+}
+
+func (c *Content) View() string {
+	t := theme.Current
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Width(8)
+
+	focusedLabelStyle := labelStyle.Copy().
+		Foreground(t.Colors.Primary).
+		Bold(true)
+
+	optionStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Padding(0, 1)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.SelectionFg).
+		Background(t.Colors.SelectionBg).
+		Bold(true).
+		Padding(0, 1)
+
+	sourceStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground).
+		Bold(true).
+		Padding(0, 0, 1, 0)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Padding(1, 0, 0, 0)
+
+	label := func(f field, text string) string {
+		if c.field == f {
+			return focusedLabelStyle.Render(text)
+		}
+		return labelStyle.Render(text)
+	}
+	options := func(labels []string, selected int) string {
+		rendered := make([]string, len(labels))
+		for i, l := range labels {
+			if i == selected {
+				rendered[i] = selectedStyle.Render(l)
+			} else {
+				rendered[i] = optionStyle.Render(l)
+			}
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+	}
+
+	formats := make([]string, len(drivers.ExportFormats))
+	for i, format := range drivers.ExportFormats {
+		formats[i] = format.String()
+	}
+	scopes := make([]string, len(c.scopes))
+	for i, scope := range c.scopes {
+		scopes[i] = c.scopeLabel(scope)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		sourceStyle.Render("Export "+c.source),
+		lipgloss.JoinHorizontal(lipgloss.Top, label(fieldFormat, "Format"), options(formats, c.format)),
+		lipgloss.JoinHorizontal(lipgloss.Top, label(fieldScope, "Rows"), options(scopes, c.scope)),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, label(fieldPath, "File"), c.input.View()),
+		helpStyle.Render("Tab/↑↓: Field • h/l: Choose • Enter: Export • Esc: Cancel"),
+	)
+}
+
+func (c *Content) Result() modal.Result {
+	return c.result
+}
+
+func (c *Content) ShouldClose() bool {
+	return c.closed
+}
+
+func (c *Content) SetWidth(width int) {
+	c.width = width
+	c.input.Width = max(20, min(60, width-12))
+}
+
+// Model wraps the generic modal with export content
+type Model struct {
+	modal   modal.Model
+	content *Content
+}
+
+// New creates a new export modal
+func New() Model {
+	content := NewContent()
+	m := modal.New("Export", content)
+	return Model{
+		modal:   m,
+		content: content,
+	}
+}
+
+// Show opens the form for exporting source, suggesting path as the file and
+// offering scopes; marked is the number of rows ScopeMarked covers
+func (m *Model) Show(source, path string, scopes []Scope, marked int) {
+	logger.Debug("Export modal opened", map[string]any{
+		"source": source,
+		"scopes": len(scopes),
+	})
+	m.content.SetExport(source, path, scopes, marked)
+	m.modal.Show()
+}
+
+// Hide hides the modal
+func (m *Model) Hide() {
+	m.modal.Hide()
+}
+
+// Visible returns whether the modal is visible
+func (m Model) Visible() bool {
+	return m.modal.Visible()
+}
+
+// SetSize sets the terminal size for centering
+func (m *Model) SetSize(width, height int) {
+	m.modal.SetSize(width, height)
+}
+
+// Update handles input
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.modal, cmd = m.modal.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m Model) View() string {
+	return m.modal.View()
+}
+
+// Result returns the modal result
+func (m Model) Result() modal.Result {
+	return m.modal.Result()
+}
+
+// Format returns the chosen export format
+func (m Model) Format() drivers.ExportFormat {
+	return drivers.ExportFormats[m.content.format]
+}
+
+// Scope returns the chosen set of rows
+func (m Model) Scope() Scope {
+	if len(m.content.scopes) == 0 {
+		return ScopePage
+	}
+	return m.content.scopes[m.content.scope]
+}
+
+// Path returns the file path typed in the form
+func (m Model) Path() string {
+	return strings.TrimSpace(m.content.input.Value())
+}
@@ -42,9 +42,18 @@ func NewHelpContent() *HelpContent {
 					{"q / Ctrl+C", "Quit application"},
 					{"Tab", "Switch focus between panels"},
 					{"s", "Toggle sidebar"},
+					{"Ctrl+← / Ctrl+→", "Resize sidebar"},
+					{"Esc", "Dismiss notification"},
 					{"T", "Cycle themes"},
+					{"D", "Toggle debug logging"},
+					{"P", "Show config/storage/log paths"},
+					{"I", "Diagnostics (versions, paths)"},
+					{"Ctrl+D", "Toggle dry-run mode"},
+					{"Ctrl+P", "Recent tables"},
+					{"!", "Open native client (mysql, psql, redis-cli...)"},
 					{"[", "Previous tab"},
 					{"]", "Next tab"},
+					{"O", "Duplicate table tab"},
 					{"Ctrl+W", "Close current tab"},
 				},
 			},
@@ -54,12 +63,19 @@ func NewHelpContent() *HelpContent {
 					{"j / ↓", "Move down"},
 					{"k / ↑", "Move up"},
 					{"Enter", "Select/Connect database"},
+					{"O", "Open table in new tab"},
 					{"e", "Open query editor"},
 					{"d", "View table structure"},
+					{"i", "Table info (rows, size)"},
 					{"n", "New connection"},
 					{"/", "Filter connections/tables"},
 					{"C", "Clear filter"},
 					{"R", "Refresh connections"},
+					{"Ctrl+R", "Reconnect connection"},
+					{"Ctrl+S", "Export connection schema (.sql)"},
+					{"Space", "Mark table"},
+					{".", "Toggle schema.table names"},
+					{"y / Y", "Copy marked table names (Y qualified)"},
 				},
 			},
 			{
@@ -73,6 +89,10 @@ func NewHelpContent() *HelpContent {
 					{"K / PgUp", "Page up"},
 					{"H", "Jump to first column"},
 					{"L", "Jump to last column"},
+					{"w", "Toggle auto-fit columns"},
+					{"N", "Toggle NULL/empty counts"},
+					{"z", "Toggle dense rendering"},
+					{"x", "Export rows to a file"},
 					{"Home", "Jump to first row"},
 					{"End", "Jump to last row"},
 					{">", "Next page (query)"},
@@ -80,11 +100,24 @@ func NewHelpContent() *HelpContent {
 					{"Space", "Sort by column (toggle ASC/DESC)"},
 					{"y", "Yank (copy) cell"},
 					{"p", "Preview cell content"},
+					{"m / M", "Mark row / unmark all"},
+					{"V", "Visual mode: mark a range of rows"},
+					{"Shift+↑/↓", "Extend marks up/down"},
+					{"v", "Record view of row"},
+					{"n / p", "Next/prev row in record view"},
+					{"o", "Insert row"},
 					{"a", "Cell actions menu"},
+					{"A", "Table actions (truncate)"},
+					{"r", "Refresh data (keeps filters/sort)"},
 					{"gd", "Go to definition (FK)"},
+					{"Ctrl+O / Ctrl+N", "Back / forward (gd history)"},
 					{"Ctrl+T", "Toggle column visibility"},
 					{"/", "Focus filter"},
 					{"C", "Clear filter"},
+					{"c", "Count rows matching filter"},
+					{"U", "Clear sort (keep filters)"},
+					{"F", "Pin/unpin default filter"},
+					{"#", "Toggle exact/estimated row count"},
 					{"e", "Open query editor"},
 					{"d", "View table structure"},
 				},
@@ -102,16 +135,21 @@ func NewHelpContent() *HelpContent {
 					{"h/j/k/l", "Navigate"},
 					{"w", "Move word forward"},
 					{"b", "Move word backward"},
+					{"f<char> / t<char>", "Find / till character"},
 					{"0", "Go to line start"},
 					{"$", "Go to line end"},
 					{"g", "Go to start"},
 					{"G", "Go to end"},
 					{"x", "Delete character"},
+					{"r<char>", "Replace character"},
+					{"~", "Toggle case"},
 					{"dd", "Delete line"},
 					{"yy", "Yank line"},
 					{"Y", "Yank query to clipboard"},
 					{"p", "Paste"},
 					{"u", "Undo"},
+					{"q<reg> / q", "Record macro / stop"},
+					{"@<reg> / @@", "Replay macro / last"},
 					{"v", "Visual mode"},
 					{"", ""},
 					{"", "─── Insert Mode ───"},
@@ -126,9 +164,16 @@ func NewHelpContent() *HelpContent {
 					{"", ""},
 					{"", "─── All Modes ───"},
 					{"F5 / Ctrl+E", "Execute query"},
+					{"Ctrl+C", "Cancel running query"},
+					{"Ctrl+L", "Re-run last query"},
 					{"Ctrl+F", "Format SQL"},
 					{"Ctrl+Y", "Copy query to clipboard"},
+					{"Ctrl+S", "Save query as snippet"},
+					{"Ctrl+O", "Insert a saved snippet"},
 					{"Ctrl+R", "Toggle results focus"},
+					{"{ / }", "Previous/next result set"},
+					{"z", "Toggle full-screen results"},
+					{"x", "Export results to a file"},
 				},
 			},
 			{
@@ -150,6 +195,10 @@ func NewHelpContent() *HelpContent {
 					{"2", "Indexes section"},
 					{"3", "Relations section"},
 					{"4", "Triggers section"},
+					{"5", "Dependencies section"},
+					{"Enter", "Open dependent view/table"},
+					{"/", "Filter section"},
+					{"Esc", "Clear section filter"},
 					{"Tab", "Next section"},
 					{"j/k", "Navigate rows"},
 					{"h/l", "Navigate columns"},
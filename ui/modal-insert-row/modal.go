@@ -0,0 +1,357 @@
+package modalinsertrow
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/modal"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// visibleFields is the number of fields shown at once
+const visibleFields = 6
+
+// maxInputWidth caps the width of a field's input
+const maxInputWidth = 60
+
+// FieldMode says what the INSERT does with a column
+type FieldMode int
+
+const (
+	FieldDefault FieldMode = iota // Left out of the INSERT, so the database default applies
+	FieldText                     // The typed text is inserted, possibly an empty string
+	FieldNull                     // NULL is inserted
+)
+
+// FieldValue is the value chosen for one column of the new row
+type FieldValue struct {
+	Column string
+	Mode   FieldMode
+	Text   string // Only used with FieldText
+}
+
+// field is the input state of one column
+type field struct {
+	column drivers.ColumnInfo
+	mode   FieldMode
+	input  textinput.Model
+}
+
+// Content implements modal.Content for entering the values of a new row
+type Content struct {
+	tableName string
+	fields    []field
+	cursor    int // Index of the focused field
+	offset    int // First field shown
+	width     int
+	result    modal.Result
+	closed    bool
+}
+
+// NewContent creates a new insert row content
+func NewContent() *Content {
+	return &Content{result: modal.ResultNone}
+}
+
+// SetColumns sets the table and its columns. Nullable columns without a default
+// start at NULL, every other field at its default.
+func (c *Content) SetColumns(tableName string, columns []drivers.ColumnInfo) {
+	c.tableName = tableName
+	c.fields = make([]field, len(columns))
+	for i, col := range columns {
+		ti := textinput.New()
+		ti.CharLimit = 10000
+		ti.Width = c.inputWidth()
+		mode := FieldDefault
+		if col.Nullable && col.DefaultValue == "" && col.Extra == "" && !col.Generated {
+			mode = FieldNull
+		}
+		c.fields[i] = field{column: col, mode: mode, input: ti}
+	}
+	c.cursor = 0
+	c.offset = 0
+	c.result = modal.ResultNone
+	c.closed = false
+	c.focusField(0)
+}
+
+// Values returns the value chosen for every column
+func (c *Content) Values() []FieldValue {
+	values := make([]FieldValue, len(c.fields))
+	for i, f := range c.fields {
+		values[i] = FieldValue{Column: f.column.Name, Mode: f.mode, Text: f.input.Value()}
+	}
+	return values
+}
+
+// focusField moves the input focus to the field at index i
+func (c *Content) focusField(i int) {
+	if len(c.fields) == 0 {
+		return
+	}
+	c.fields[c.cursor].input.Blur()
+	c.cursor = max(0, min(i, len(c.fields)-1))
+	c.fields[c.cursor].input.Focus()
+
+	if c.cursor < c.offset {
+		c.offset = c.cursor
+	} else if c.cursor >= c.offset+visibleFields {
+		c.offset = c.cursor - visibleFields + 1
+	}
+}
+
+func (c *Content) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(c.fields) == 0 {
+		if ok && keyMsg.String() == "esc" {
+			c.result = modal.ResultCancel
+			c.closed = true
+		}
+		return c, nil
+	}
+
+	f := &c.fields[c.cursor]
+	switch keyMsg.String() {
+	case "enter":
+		c.result = modal.ResultSubmit
+		c.closed = true
+		return c, nil
+	case "esc":
+		c.result = modal.ResultCancel
+		c.closed = true
+		return c, nil
+	case "tab", "down":
+		c.focusField(c.cursor + 1)
+		return c, nil
+	case "shift+tab", "up":
+		c.focusField(c.cursor - 1)
+		return c, nil
+	}
+
+	// The database computes generated columns, so they stay at their default
+	if f.column.Generated {
+		return c, nil
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+n":
+		// NULL is only offered where the column allows it
+		if f.column.Nullable {
+			f.mode = FieldNull
+			f.input.SetValue("")
+		}
+		return c, nil
+	case "ctrl+d":
+		f.mode = FieldDefault
+		f.input.SetValue("")
+		return c, nil
+	case "ctrl+e":
+		// An explicit empty string, as opposed to leaving the field at its default
+		f.mode = FieldText
+		f.input.SetValue("")
+		return c, nil
+	}
+
+	var cmd tea.Cmd
+	before := f.input.Value()
+	f.input, cmd = f.input.Update(msg)
+	if after := f.input.Value(); after != before {
+		// Clearing the text goes back to the default rather than an empty string
+		if after == "" {
+			f.mode = FieldDefault
+		} else {
+			f.mode = FieldText
+		}
+	}
+	return c, cmd
+}
+
+func (c *Content) View() string {
+	if c.width == 0 {
+		return "Loading..."
+	}
+
+	t := theme.Current
+
+	contextStyle := t.StatusBar.Copy().Padding(0, 1)
+	nameStyle := t.TableCell.Copy().Bold(true)
+	selectedNameStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground).
+		Background(t.Colors.Primary).
+		Bold(true)
+	infoStyle := lipgloss.NewStyle().Foreground(t.Colors.ForegroundDim)
+	placeholderStyle := lipgloss.NewStyle().Foreground(t.Colors.ForegroundDim).Italic(true)
+	requiredStyle := lipgloss.NewStyle().Foreground(t.Colors.Warning)
+	inputStyle := lipgloss.NewStyle().Padding(0, 1)
+	helpStyle := lipgloss.NewStyle().Foreground(t.Colors.ForegroundDim).Padding(1, 0, 0, 0)
+
+	lines := []string{
+		contextStyle.Width(c.width).Align(lipgloss.Left).Render("Insert row into table '" + c.tableName + "'"),
+		"",
+	}
+
+	if len(c.fields) == 0 {
+		lines = append(lines, "No columns found")
+	}
+
+	end := min(len(c.fields), c.offset+visibleFields)
+	for i := c.offset; i < end; i++ {
+		f := c.fields[i]
+
+		name := nameStyle.Render(" " + f.column.Name + " ")
+		if i == c.cursor {
+			name = selectedNameStyle.Render(" " + f.column.Name + " ")
+		}
+		lines = append(lines, name+" "+infoStyle.Render(columnInfo(f.column)))
+
+		var value string
+		switch f.mode {
+		case FieldNull:
+			value = placeholderStyle.Render("NULL")
+		case FieldDefault:
+			value = placeholderStyle.Render(defaultLabel(f.column))
+			if !f.column.Nullable && f.column.DefaultValue == "" && f.column.Extra == "" {
+				value += requiredStyle.Render("  (required)")
+			}
+			if i == c.cursor && !f.column.Generated {
+				value = f.input.View() + " " + value
+			}
+		default:
+			value = f.input.View()
+			if f.input.Value() == "" {
+				value += " " + placeholderStyle.Render("(empty string)")
+			}
+		}
+		lines = append(lines, inputStyle.Render(value))
+	}
+
+	if len(c.fields) > visibleFields {
+		lines = append(lines, infoStyle.Render("Field "+strconv.Itoa(c.cursor+1)+" of "+strconv.Itoa(len(c.fields))))
+	}
+
+	help := "Tab/↑↓: Field • Ctrl+N: NULL • Ctrl+D: Default • Ctrl+E: Empty string • Enter: Insert • Esc: Cancel"
+	lines = append(lines, helpStyle.Width(c.width).Render(help))
+
+	return strings.Join(lines, "\n")
+}
+
+// columnInfo describes a column's type, nullability and default, e.g. "varchar(255) · NULL · default 'x'"
+func columnInfo(col drivers.ColumnInfo) string {
+	parts := []string{col.DataType}
+	if col.Nullable {
+		parts = append(parts, "NULL")
+	} else {
+		parts = append(parts, "NOT NULL")
+	}
+	if col.DefaultValue != "" {
+		parts = append(parts, "default "+col.DefaultValue)
+	}
+	if col.Extra != "" {
+		parts = append(parts, col.Extra)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// defaultLabel is shown for a field left at its database default
+func defaultLabel(col drivers.ColumnInfo) string {
+	if col.Generated {
+		return "GENERATED (read-only)"
+	}
+	if col.DefaultValue != "" {
+		return "DEFAULT (" + col.DefaultValue + ")"
+	}
+	return "DEFAULT"
+}
+
+func (c *Content) Result() modal.Result {
+	return c.result
+}
+
+func (c *Content) ShouldClose() bool {
+	return c.closed
+}
+
+func (c *Content) SetWidth(width int) {
+	c.width = width
+	for i := range c.fields {
+		c.fields[i].input.Width = c.inputWidth()
+	}
+}
+
+// inputWidth returns the width of a field's input, leaving room for the padding
+func (c *Content) inputWidth() int {
+	return max(10, min(c.width-4, maxInputWidth))
+}
+
+// Model wraps the generic modal with insert row content
+type Model struct {
+	modal   modal.Model
+	content *Content
+}
+
+// New creates a new insert row modal
+func New() Model {
+	content := NewContent()
+	m := modal.New("Insert Row", content)
+	return Model{
+		modal:   m,
+		content: content,
+	}
+}
+
+// Show displays the modal with a field for each column of the table
+func (m *Model) Show(tableName string, columns []drivers.ColumnInfo) {
+	logger.Debug("Insert row modal opened", map[string]any{
+		"table":   tableName,
+		"columns": len(columns),
+	})
+	m.content.SetColumns(tableName, columns)
+	m.modal.Show()
+}
+
+// Hide hides the modal
+func (m *Model) Hide() {
+	m.modal.Hide()
+}
+
+// Visible returns whether the modal is visible
+func (m Model) Visible() bool {
+	return m.modal.Visible()
+}
+
+// SetSize sets the terminal size for centering
+func (m *Model) SetSize(width, height int) {
+	m.modal.SetSize(width, height)
+}
+
+// Update handles input
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.modal, cmd = m.modal.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m Model) View() string {
+	return m.modal.View()
+}
+
+// Confirmed returns true if the user asked to insert the row
+func (m Model) Confirmed() bool {
+	return m.modal.Result() == modal.ResultSubmit
+}
+
+// Values returns the value chosen for every column of the new row
+func (m Model) Values() []FieldValue {
+	return m.content.Values()
+}
+
+// TableName returns the table the row is inserted into
+func (m Model) TableName() string {
+	return m.content.tableName
+}
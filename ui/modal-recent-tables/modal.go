@@ -0,0 +1,189 @@
+package modalrecenttables
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/modal"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// listHeight is the number of entries shown at once
+const listHeight = 15
+
+// TableSelectedMsg is sent when the user picks a recent table to reopen
+type TableSelectedMsg struct {
+	ConnectionName string
+	TableName      string
+}
+
+// Entry is a recently opened table
+type Entry struct {
+	ConnectionName string
+	TableName      string
+}
+
+// Content implements modal.Content for picking a recently opened table
+type Content struct {
+	entries  []Entry
+	selected int
+	offset   int
+	width    int
+	closed   bool
+}
+
+// NewContent creates a new recent tables content
+func NewContent() *Content {
+	return &Content{}
+}
+
+// SetEntries sets the tables to pick from, most recent first
+func (c *Content) SetEntries(entries []Entry) {
+	c.entries = entries
+	c.selected = 0
+	c.offset = 0
+	c.closed = false
+}
+
+func (c *Content) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			c.closed = true
+		case "down", "j", "ctrl+n":
+			if c.selected < len(c.entries)-1 {
+				c.selected++
+			}
+		case "up", "k", "ctrl+p":
+			if c.selected > 0 {
+				c.selected--
+			}
+		case "home", "g":
+			c.selected = 0
+		case "end", "G":
+			c.selected = max(0, len(c.entries)-1)
+		case "enter":
+			if c.selected < len(c.entries) {
+				entry := c.entries[c.selected]
+				c.closed = true
+				return c, func() tea.Msg {
+					return TableSelectedMsg{ConnectionName: entry.ConnectionName, TableName: entry.TableName}
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *Content) View() string {
+	t := theme.Current
+
+	itemStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground).
+		Background(t.Colors.Primary).
+		Bold(true)
+
+	connectionStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Padding(1, 0, 0, 0)
+
+	if len(c.entries) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			"No recently opened tables",
+			helpStyle.Render("Esc: Close"))
+	}
+
+	// Scroll so the selected entry stays in view
+	if c.selected < c.offset {
+		c.offset = c.selected
+	} else if c.selected >= c.offset+listHeight {
+		c.offset = c.selected - listHeight + 1
+	}
+	end := min(len(c.entries), c.offset+listHeight)
+
+	var lines []string
+	for i := c.offset; i < end; i++ {
+		entry := c.entries[i]
+		if i == c.selected {
+			lines = append(lines, selectedStyle.Render(" "+entry.TableName+"  "+entry.ConnectionName+" "))
+			continue
+		}
+		lines = append(lines, itemStyle.Render(" "+entry.TableName)+"  "+connectionStyle.Render(entry.ConnectionName))
+	}
+
+	help := helpStyle.Render("j/k: Move • Enter: Open • Esc: Close")
+	return lipgloss.JoinVertical(lipgloss.Left, strings.Join(lines, "\n"), help)
+}
+
+func (c *Content) Result() modal.Result {
+	return modal.ResultNone
+}
+
+func (c *Content) ShouldClose() bool {
+	return c.closed
+}
+
+func (c *Content) SetWidth(width int) {
+	c.width = width
+}
+
+// Model wraps the generic modal with recent tables content
+type Model struct {
+	modal   modal.Model
+	content *Content
+}
+
+// New creates a new recent tables modal
+func New() Model {
+	content := NewContent()
+	m := modal.New("Recent Tables", content)
+	return Model{
+		modal:   m,
+		content: content,
+	}
+}
+
+// Show displays the modal with the given tables, most recent first
+func (m *Model) Show(entries []Entry) {
+	logger.Debug("Recent tables modal opened", map[string]any{
+		"entries": len(entries),
+	})
+	m.content.SetEntries(entries)
+	m.modal.Show()
+}
+
+// Hide hides the modal
+func (m *Model) Hide() {
+	m.modal.Hide()
+}
+
+// Visible returns whether the modal is visible
+func (m Model) Visible() bool {
+	return m.modal.Visible()
+}
+
+// SetSize sets the terminal size for centering
+func (m *Model) SetSize(width, height int) {
+	m.modal.SetSize(width, height)
+}
+
+// Update handles input
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.modal, cmd = m.modal.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m Model) View() string {
+	return m.modal.View()
+}
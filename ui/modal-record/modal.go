@@ -0,0 +1,346 @@
+package modalrecord
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/modal"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// recordHeight is the number of lines the field list occupies
+const recordHeight = 20
+
+// scrollStep is the number of characters h/l scroll an unwrapped value by
+const scrollStep = 10
+
+// CopyFieldMsg is sent when the user copies a field value from the record view
+type CopyFieldMsg struct {
+	Column string
+	Value  string
+}
+
+// NavigateRowMsg is sent when the user asks for the next (Delta 1) or previous
+// (Delta -1) row of the underlying table while the record view is open
+type NavigateRowMsg struct {
+	Delta int
+}
+
+// Content implements modal.Content for a vertical, scrollable view of one row
+type Content struct {
+	columnNames []string
+	rowData     []string
+	selected    int // Index of the selected field
+	offset      int // First rendered line shown
+	width       int
+	closed      bool
+
+	// Long values wrap by default; unwrapped, the selected value scrolls with h/l
+	noWrap  bool
+	hOffset int // First character shown of the selected value when unwrapped
+
+	// Lines of the selected field in the last render, so a field taller than
+	// the view can be scrolled through before moving to the next one
+	selectedStart, selectedEnd int
+	follow                     bool // Scroll the selected field into view on the next render
+}
+
+// NewContent creates a new record content
+func NewContent() *Content {
+	return &Content{}
+}
+
+// SetRecord sets the row to display
+func (c *Content) SetRecord(columnNames, rowData []string) {
+	c.columnNames = columnNames
+	c.rowData = rowData
+	c.selected = 0
+	c.offset = 0
+	c.hOffset = 0
+	c.follow = true
+	c.closed = false
+}
+
+// SetRow replaces the displayed row, keeping the selected field
+func (c *Content) SetRow(rowData []string) {
+	c.rowData = rowData
+}
+
+func (c *Content) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "v":
+			c.closed = true
+		case "down", "j":
+			// Scroll through a field taller than the view before leaving it
+			if c.selectedEnd >= c.offset+recordHeight {
+				c.offset++
+			} else {
+				c.selectField(c.selected + 1)
+			}
+		case "up", "k":
+			if c.selectedStart < c.offset {
+				c.offset--
+			} else {
+				c.selectField(c.selected - 1)
+			}
+		case "tab":
+			c.selectField(c.selected + 1)
+		case "shift+tab":
+			c.selectField(c.selected - 1)
+		case "home", "g":
+			c.selectField(0)
+		case "end", "G":
+			c.selectField(len(c.columnNames) - 1)
+		case "w":
+			c.noWrap = !c.noWrap
+			c.hOffset = 0
+			c.follow = true
+		case "l", "right":
+			if c.noWrap {
+				c.hOffset = min(c.hOffset+scrollStep, max(0, c.longestLine(c.selected)-c.clipWidth()))
+			}
+		case "h", "left":
+			if c.noWrap {
+				c.hOffset = max(0, c.hOffset-scrollStep)
+			}
+		case "0":
+			c.hOffset = 0
+		case "n", "p":
+			delta := 1
+			if msg.String() == "p" {
+				delta = -1
+			}
+			return c, func() tea.Msg { return NavigateRowMsg{Delta: delta} }
+		case "y", "enter":
+			if c.selected < len(c.columnNames) {
+				field := CopyFieldMsg{Column: c.columnNames[c.selected], Value: c.value(c.selected)}
+				return c, func() tea.Msg { return field }
+			}
+		}
+	}
+	return c, nil
+}
+
+// selectField selects field i, clamped to the fields, and scrolls it into view
+func (c *Content) selectField(i int) {
+	i = max(0, min(i, len(c.columnNames)-1))
+	if i != c.selected {
+		c.selected = i
+		c.hOffset = 0
+	}
+	c.follow = true
+}
+
+// valueWidth returns the width values are rendered in
+func (c *Content) valueWidth() int {
+	return max(10, c.width-2)
+}
+
+// clipWidth returns the characters of an unwrapped value line that fit beside the indent
+func (c *Content) clipWidth() int {
+	return c.valueWidth() - 2
+}
+
+// longestLine returns the length in characters of the longest line of field i
+func (c *Content) longestLine(i int) int {
+	longest := 0
+	for _, line := range strings.Split(c.value(i), "\n") {
+		longest = max(longest, len([]rune(line)))
+	}
+	return longest
+}
+
+// clipLine returns width characters of line from offset on, marking cut off
+// text with ‹ and ›
+func clipLine(line string, offset, width int) string {
+	runes := []rune(line)
+	if offset > 0 && offset >= len(runes) {
+		return "‹"
+	}
+	runes = runes[min(offset, len(runes)):]
+	if len(runes) > width {
+		runes = append(runes[:width-1], '›')
+	}
+	if offset > 0 && len(runes) > 0 {
+		runes[0] = '‹'
+	}
+	return string(runes)
+}
+
+// value returns the value of the field at index i
+func (c *Content) value(i int) string {
+	if i < len(c.rowData) {
+		return c.rowData[i]
+	}
+	return ""
+}
+
+func (c *Content) View() string {
+	t := theme.Current
+
+	nameStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Primary).
+		Bold(true)
+
+	selectedNameStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground).
+		Background(t.Colors.Primary).
+		Bold(true)
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground).
+		PaddingLeft(2)
+
+	nullStyle := valueStyle.Copy().
+		Foreground(t.Colors.ForegroundDim).
+		Italic(true)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Padding(1, 0, 0, 0)
+
+	if len(c.columnNames) == 0 {
+		return "No row selected"
+	}
+
+	valueWidth := c.valueWidth()
+
+	// Render every field, remembering where the selected one starts and ends
+	var lines []string
+	selectedStart, selectedEnd := 0, 0
+	for i, name := range c.columnNames {
+		if i == c.selected {
+			selectedStart = len(lines)
+			lines = append(lines, selectedNameStyle.Render(" "+name+" "))
+		} else {
+			lines = append(lines, nameStyle.Render(" "+name+" "))
+		}
+
+		value := c.value(i)
+		var rendered string
+		switch {
+		case value == "NULL":
+			rendered = nullStyle.Render("NULL")
+		case value == "":
+			rendered = nullStyle.Render("(empty)")
+		case c.noWrap:
+			// One screen line per line of the value; only the selected one scrolls
+			hOffset := 0
+			if i == c.selected {
+				hOffset = c.hOffset
+			}
+			var clipped []string
+			for _, line := range strings.Split(value, "\n") {
+				clipped = append(clipped, valueStyle.Render(clipLine(line, hOffset, c.clipWidth())))
+			}
+			rendered = strings.Join(clipped, "\n")
+		default:
+			rendered = valueStyle.Width(valueWidth).Render(value)
+		}
+		lines = append(lines, strings.Split(rendered, "\n")...)
+		lines = append(lines, "")
+
+		if i == c.selected {
+			selectedEnd = len(lines) - 1
+		}
+	}
+
+	// Scroll so the selected field comes into view when the selection changed;
+	// otherwise j/k may be scrolling through a field taller than the view
+	if c.follow {
+		if selectedStart < c.offset {
+			c.offset = selectedStart
+		} else if selectedEnd >= c.offset+recordHeight {
+			c.offset = min(selectedStart, selectedEnd-recordHeight+1)
+		}
+		c.follow = false
+	}
+	c.offset = max(0, min(c.offset, len(lines)-recordHeight))
+	c.selectedStart, c.selectedEnd = selectedStart, selectedEnd
+	end := min(len(lines), c.offset+recordHeight)
+
+	visible := lines[c.offset:end]
+	for len(visible) < recordHeight {
+		visible = append(visible, "")
+	}
+
+	helpText := "j/k: Fields/scroll • n/p: Next/prev row • w: Wrap • y/Enter: Copy field • Esc: Close"
+	if c.noWrap {
+		helpText = "j/k: Fields • h/l: Scroll value • w: Wrap • y/Enter: Copy field • Esc: Close"
+	}
+	help := helpStyle.Render(helpText)
+	return lipgloss.JoinVertical(lipgloss.Left, append(visible, help)...)
+}
+
+func (c *Content) Result() modal.Result {
+	return modal.ResultNone
+}
+
+func (c *Content) ShouldClose() bool {
+	return c.closed
+}
+
+func (c *Content) SetWidth(width int) {
+	c.width = width
+}
+
+// Model wraps the generic modal with record content
+type Model struct {
+	modal   modal.Model
+	content *Content
+}
+
+// New creates a new record modal
+func New() Model {
+	content := NewContent()
+	m := modal.New("Record", content)
+	return Model{
+		modal:   m,
+		content: content,
+	}
+}
+
+// Show displays the modal with one row, its values matching columnNames by index
+func (m *Model) Show(columnNames, rowData []string) {
+	logger.Debug("Record modal opened", map[string]any{
+		"columns": len(columnNames),
+	})
+	m.content.SetRecord(columnNames, rowData)
+	m.modal.Show()
+}
+
+// SetRow shows another row of the same table without closing the modal
+func (m *Model) SetRow(rowData []string) {
+	m.content.SetRow(rowData)
+}
+
+// Hide hides the modal
+func (m *Model) Hide() {
+	m.modal.Hide()
+}
+
+// Visible returns whether the modal is visible
+func (m Model) Visible() bool {
+	return m.modal.Visible()
+}
+
+// SetSize sets the terminal size for centering
+func (m *Model) SetSize(width, height int) {
+	m.modal.SetSize(width, height)
+}
+
+// Update handles input
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.modal, cmd = m.modal.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m Model) View() string {
+	return m.modal.View()
+}
@@ -0,0 +1,381 @@
+package modalsnippets
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/modal"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// listHeight is the number of snippets shown at once
+const listHeight = 12
+
+// previewHeight is the number of lines of the selected snippet shown below the list
+const previewHeight = 6
+
+// InsertMsg is sent when the user picks a snippet to insert into the query editor
+type InsertMsg struct {
+	Query string
+}
+
+// SaveMsg is sent when the user names the query editor buffer to save it as a snippet
+type SaveMsg struct {
+	Name string
+}
+
+// RenameMsg is sent when the user gives a snippet a new name
+type RenameMsg struct {
+	ID   int64
+	Name string
+}
+
+// DeleteMsg is sent when the user confirms deleting a snippet
+type DeleteMsg struct {
+	ID   int64
+	Name string
+}
+
+// Snippet is a saved query
+type Snippet struct {
+	ID    int64
+	Name  string
+	Query string
+}
+
+// mode is what the modal is doing
+type mode int
+
+const (
+	modeList          mode = iota // Picking a snippet
+	modeName                      // Typing the name to save or rename under
+	modeConfirmDelete             // Asked whether to delete the selected snippet
+)
+
+// Content implements modal.Content for listing, inserting, saving, renaming and
+// deleting snippets
+type Content struct {
+	snippets []Snippet
+	selected int
+	offset   int
+
+	mode      mode
+	input     textinput.Model
+	renameID  int64 // Snippet being renamed; 0 when naming a new one
+	saveOnly  bool  // Opened to save, so naming closes the modal
+	nameEmpty bool  // Enter was pressed without a name
+
+	width  int
+	closed bool
+}
+
+// NewContent creates a new snippets content
+func NewContent() *Content {
+	ti := textinput.New()
+	ti.Placeholder = "snippet name"
+	ti.CharLimit = 128
+	ti.Width = 40
+	return &Content{input: ti}
+}
+
+// SetSnippets replaces the listed snippets, keeping the selection in range
+func (c *Content) SetSnippets(snippets []Snippet) {
+	c.snippets = snippets
+	c.selected = max(0, min(c.selected, len(snippets)-1))
+}
+
+// reset shows the list from the top, or the name prompt for a new snippet when saveOnly
+func (c *Content) reset(saveOnly bool) tea.Cmd {
+	c.selected = 0
+	c.offset = 0
+	c.closed = false
+	c.saveOnly = saveOnly
+	if saveOnly {
+		return c.startNaming(0, "")
+	}
+	c.mode = modeList
+	c.input.Blur()
+	return nil
+}
+
+// startNaming prompts for the name of snippet id, or of a new snippet when id is 0
+func (c *Content) startNaming(id int64, name string) tea.Cmd {
+	c.mode = modeName
+	c.renameID = id
+	c.nameEmpty = false
+	c.input.SetValue(name)
+	c.input.CursorEnd()
+	return c.input.Focus()
+}
+
+// backToList leaves the name prompt or delete confirmation, closing the modal
+// when it was only opened to save
+func (c *Content) backToList() {
+	c.input.Blur()
+	if c.saveOnly {
+		c.closed = true
+		return
+	}
+	c.mode = modeList
+}
+
+func (c *Content) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+
+	switch c.mode {
+	case modeName:
+		return c.updateName(keyMsg)
+	case modeConfirmDelete:
+		switch keyMsg.String() {
+		case "y", "enter":
+			snippet := c.snippets[c.selected]
+			c.backToList()
+			return c, func() tea.Msg { return DeleteMsg{ID: snippet.ID, Name: snippet.Name} }
+		case "n", "esc":
+			c.backToList()
+		}
+		return c, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "q":
+		c.closed = true
+	case "down", "j", "ctrl+n":
+		if c.selected < len(c.snippets)-1 {
+			c.selected++
+		}
+	case "up", "k", "ctrl+p":
+		if c.selected > 0 {
+			c.selected--
+		}
+	case "home", "g":
+		c.selected = 0
+	case "end", "G":
+		c.selected = max(0, len(c.snippets)-1)
+	case "s":
+		return c, c.startNaming(0, "")
+	case "r":
+		if c.selected < len(c.snippets) {
+			snippet := c.snippets[c.selected]
+			return c, c.startNaming(snippet.ID, snippet.Name)
+		}
+	case "d", "x":
+		if c.selected < len(c.snippets) {
+			c.mode = modeConfirmDelete
+		}
+	case "enter":
+		if c.selected < len(c.snippets) {
+			query := c.snippets[c.selected].Query
+			c.closed = true
+			return c, func() tea.Msg { return InsertMsg{Query: query} }
+		}
+	}
+	return c, nil
+}
+
+// updateName handles the name prompt, sending SaveMsg or RenameMsg on Enter
+func (c *Content) updateName(keyMsg tea.KeyMsg) (modal.Content, tea.Cmd) {
+	switch keyMsg.String() {
+	case "esc":
+		c.backToList()
+		return c, nil
+	case "enter":
+		name := strings.TrimSpace(c.input.Value())
+		if name == "" {
+			c.nameEmpty = true
+			return c, nil
+		}
+		id := c.renameID
+		c.backToList()
+		if id != 0 {
+			return c, func() tea.Msg { return RenameMsg{ID: id, Name: name} }
+		}
+		return c, func() tea.Msg { return SaveMsg{Name: name} }
+	}
+
+	c.nameEmpty = false
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(keyMsg)
+	return c, cmd
+}
+
+func (c *Content) View() string {
+	t := theme.Current
+
+	itemStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground).
+		Background(t.Colors.Primary).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim)
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Warning).
+		Bold(true)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Padding(1, 0, 0, 0)
+
+	if c.mode == modeName {
+		title := "Save the query as:"
+		if c.renameID != 0 {
+			title = "Rename snippet to:"
+		}
+		content := []string{title, c.input.View()}
+		if c.nameEmpty {
+			content = append(content, warningStyle.Render("Type a name"))
+		}
+		content = append(content, helpStyle.Render("Enter: Save • Esc: Cancel"))
+		return lipgloss.JoinVertical(lipgloss.Left, content...)
+	}
+
+	if len(c.snippets) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			"No snippets yet",
+			helpStyle.Render("s: Save the current query • Esc: Close"))
+	}
+
+	// Scroll so the selected snippet stays in view
+	if c.selected < c.offset {
+		c.offset = c.selected
+	} else if c.selected >= c.offset+listHeight {
+		c.offset = c.selected - listHeight + 1
+	}
+	end := min(len(c.snippets), c.offset+listHeight)
+
+	width := max(20, min(80, c.width))
+	var lines []string
+	for i := c.offset; i < end; i++ {
+		snippet := c.snippets[i]
+		// The query on one line after the name, as much as fits
+		summary := truncate(strings.Join(strings.Fields(snippet.Query), " "), width-len([]rune(snippet.Name))-4)
+		if i == c.selected {
+			lines = append(lines, selectedStyle.Render(" "+snippet.Name+"  "+summary+" "))
+			continue
+		}
+		lines = append(lines, itemStyle.Render(" "+snippet.Name)+"  "+dimStyle.Render(summary))
+	}
+
+	// The selected snippet's query, as it will be inserted
+	preview := strings.Split(c.snippets[c.selected].Query, "\n")
+	if len(preview) > previewHeight {
+		preview = append(preview[:previewHeight-1], "…")
+	}
+	for i, line := range preview {
+		preview[i] = dimStyle.Render(truncate(line, width))
+	}
+
+	var help string
+	if c.mode == modeConfirmDelete {
+		help = "\n" + warningStyle.Render("Delete \""+c.snippets[c.selected].Name+"\"?") + dimStyle.Render("  y: Delete • n: Keep")
+	} else {
+		help = helpStyle.Render("j/k: Move • Enter: Insert • s: Save query • r: Rename • d: Delete • Esc: Close")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		strings.Join(lines, "\n"),
+		"",
+		strings.Join(preview, "\n"),
+		help)
+}
+
+// truncate cuts text to width characters, marking the cut with …
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if width <= 0 {
+		return ""
+	}
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return text
+}
+
+func (c *Content) Result() modal.Result {
+	return modal.ResultNone
+}
+
+func (c *Content) ShouldClose() bool {
+	return c.closed
+}
+
+func (c *Content) SetWidth(width int) {
+	c.width = width
+}
+
+// Model wraps the generic modal with snippets content
+type Model struct {
+	modal   modal.Model
+	content *Content
+}
+
+// New creates a new snippets modal
+func New() Model {
+	content := NewContent()
+	m := modal.New("Snippets", content)
+	return Model{
+		modal:   m,
+		content: content,
+	}
+}
+
+// Show displays the modal listing snippets
+func (m *Model) Show(snippets []Snippet) {
+	logger.Debug("Snippets modal opened", map[string]any{
+		"snippets": len(snippets),
+	})
+	m.content.SetSnippets(snippets)
+	m.content.reset(false)
+	m.modal.Show()
+}
+
+// ShowSave displays the modal asking for the name to save the query under
+func (m *Model) ShowSave() tea.Cmd {
+	logger.Debug("Snippet save prompt opened", nil)
+	cmd := m.content.reset(true)
+	m.modal.Show()
+	return cmd
+}
+
+// SetSnippets refreshes the listed snippets after one was saved, renamed or deleted
+func (m *Model) SetSnippets(snippets []Snippet) {
+	m.content.SetSnippets(snippets)
+}
+
+// Hide hides the modal
+func (m *Model) Hide() {
+	m.modal.Hide()
+}
+
+// Visible returns whether the modal is visible
+func (m Model) Visible() bool {
+	return m.modal.Visible()
+}
+
+// SetSize sets the terminal size for centering
+func (m *Model) SetSize(width, height int) {
+	m.modal.SetSize(width, height)
+}
+
+// Update handles input
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.modal, cmd = m.modal.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m Model) View() string {
+	return m.modal.View()
+}
@@ -0,0 +1,225 @@
+package modaltableaction
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/ui/modal"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// Action represents the table action selected
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionCopyTableName
+	ActionTruncate
+)
+
+// ActionItem represents an action with description
+type ActionItem struct {
+	Action      Action
+	Label       string
+	Description string
+	Shortcut    string
+}
+
+// IsWriteAction returns true for actions that modify the table
+func IsWriteAction(action Action) bool {
+	return action == ActionTruncate
+}
+
+// Content implements modal.Content for actions on a whole table
+type Content struct {
+	actions    []ActionItem
+	allActions []ActionItem
+	readOnly   bool
+
+	selectedIndex  int
+	selectedAction Action
+	tableName      string
+
+	width  int
+	closed bool
+}
+
+// NewContent creates a new table action content
+func NewContent() *Content {
+	actions := []ActionItem{
+		{ActionCopyTableName, "Copy Table Name", "Copy the table name to clipboard", "c"},
+		{ActionTruncate, "Truncate Table", "Delete every row of this table", "t"},
+	}
+	return &Content{
+		actions:        actions,
+		allActions:     actions,
+		selectedAction: ActionNone,
+	}
+}
+
+// SetContext sets the table the actions apply to; read-only tables only get
+// the actions that leave the data untouched
+func (c *Content) SetContext(tableName string, readOnly bool) {
+	c.tableName = tableName
+	c.readOnly = readOnly
+	c.actions = nil
+	for _, item := range c.allActions {
+		if readOnly && IsWriteAction(item.Action) {
+			continue
+		}
+		c.actions = append(c.actions, item)
+	}
+	c.selectedIndex = 0
+	c.selectedAction = ActionNone
+	c.closed = false
+}
+
+func (c *Content) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if c.selectedIndex > 0 {
+				c.selectedIndex--
+			}
+		case "down", "j":
+			if c.selectedIndex < len(c.actions)-1 {
+				c.selectedIndex++
+			}
+		case "enter":
+			if c.selectedIndex < len(c.actions) {
+				c.selectedAction = c.actions[c.selectedIndex].Action
+			}
+			c.closed = true
+		case "esc":
+			c.selectedAction = ActionNone
+			c.closed = true
+		default:
+			for i, action := range c.actions {
+				if action.Shortcut == msg.String() {
+					c.selectedIndex = i
+					c.selectedAction = action.Action
+					c.closed = true
+					break
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *Content) View() string {
+	if c.width == 0 {
+		return "Loading..."
+	}
+
+	t := theme.Current
+
+	var lines []string
+
+	contextStyle := t.StatusBar.Copy().Padding(0, 1)
+	contextInfo := "Table: " + c.tableName
+	if c.readOnly {
+		contextInfo += " | view (read-only)"
+	}
+	lines = append(lines, contextStyle.Width(c.width).Align(lipgloss.Left).Render(contextInfo))
+	lines = append(lines, strings.Repeat(" ", c.width))
+
+	shortcutStyle := lipgloss.NewStyle().Foreground(t.Colors.Primary).Bold(true)
+	labelStyle := lipgloss.NewStyle().Bold(true)
+	descStyle := lipgloss.NewStyle().Foreground(t.Colors.ForegroundDim)
+
+	for i, action := range c.actions {
+		style := t.TableCell.Copy()
+		if i == c.selectedIndex {
+			style = t.TableSelected.Copy()
+		}
+
+		line := fmt.Sprintf(" %s %s - %s",
+			shortcutStyle.Render(fmt.Sprintf("[%s]", action.Shortcut)),
+			labelStyle.Render(action.Label),
+			descStyle.Render(action.Description))
+		lines = append(lines, style.Width(c.width).Align(lipgloss.Left).Render(line))
+	}
+
+	helpStyle := lipgloss.NewStyle().Foreground(t.Colors.ForegroundDim).Padding(1, 0, 0, 0)
+	lines = append(lines, helpStyle.Width(c.width).Align(lipgloss.Left).Render("↑↓/j/k: navigate | Enter: select | Esc: cancel | [keys]: quick select"))
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *Content) Result() modal.Result {
+	if c.selectedAction != ActionNone {
+		return modal.ResultSubmit
+	}
+	return modal.ResultCancel
+}
+
+func (c *Content) ShouldClose() bool {
+	return c.closed
+}
+
+func (c *Content) SetWidth(width int) {
+	c.width = width
+}
+
+// Model wraps the generic modal with table action content
+type Model struct {
+	modal   modal.Model
+	content *Content
+}
+
+// New creates a new table action modal
+func New() Model {
+	content := NewContent()
+	m := modal.New("Table Actions", content)
+	return Model{
+		modal:   m,
+		content: content,
+	}
+}
+
+// Show displays the actions for a table
+func (m *Model) Show(tableName string, readOnly bool) {
+	m.content.SetContext(tableName, readOnly)
+	m.modal.Show()
+}
+
+// Hide hides the modal
+func (m *Model) Hide() {
+	m.modal.Hide()
+}
+
+// Visible returns whether the modal is visible
+func (m Model) Visible() bool {
+	return m.modal.Visible()
+}
+
+// SetSize sets the terminal size for centering
+func (m *Model) SetSize(width, height int) {
+	m.modal.SetSize(width, height)
+}
+
+// Update handles input
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.modal, cmd = m.modal.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m Model) View() string {
+	return m.modal.View()
+}
+
+// SelectedAction returns the action that was selected
+func (m Model) SelectedAction() Action {
+	return m.content.selectedAction
+}
+
+// TableName returns the table the actions apply to
+func (m Model) TableName() string {
+	return m.content.tableName
+}
@@ -0,0 +1,200 @@
+package modaltableinfo
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/modal"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// Content implements modal.Content for showing table statistics
+type Content struct {
+	tableName string
+	info      *drivers.TableInfo
+	err       error
+	closed    bool
+	width     int
+}
+
+// NewContent creates a new table info content
+func NewContent() *Content {
+	return &Content{}
+}
+
+// SetInfo sets the table and its statistics (or the error from loading them)
+func (c *Content) SetInfo(tableName string, info *drivers.TableInfo, err error) {
+	c.tableName = tableName
+	c.info = info
+	c.err = err
+	c.closed = false
+}
+
+func (c *Content) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "enter", "q", "i":
+			c.closed = true
+		}
+	}
+	return c, nil
+}
+
+func (c *Content) View() string {
+	t := theme.Current
+
+	nameStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Primary).
+		Bold(true).
+		Padding(0, 0, 1, 0)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Width(15)
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Padding(1, 0, 0, 0)
+
+	lines := []string{nameStyle.Render(c.tableName)}
+
+	if c.err != nil {
+		lines = append(lines, valueStyle.Render("Failed to load table info: "+c.err.Error()))
+	} else if c.info != nil {
+		rows := "unknown"
+		if c.info.RowCount >= 0 {
+			rows = formatCount(c.info.RowCount)
+			if c.info.RowCountApprox {
+				rows = "~" + rows + " (estimate)"
+			}
+		}
+
+		size := "unknown"
+		if c.info.SizeBytes >= 0 {
+			size = formatBytes(c.info.SizeBytes)
+		}
+
+		lastModified := c.info.LastModified
+		if lastModified == "" {
+			lastModified = "n/a"
+		}
+
+		fields := []struct {
+			label string
+			value string
+		}{
+			{"Rows", rows},
+			{"Columns", strconv.Itoa(c.info.ColumnCount)},
+			{"Size on disk", size},
+			{"Last modified", lastModified},
+		}
+		for _, f := range fields {
+			lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Left,
+				labelStyle.Render(f.label),
+				valueStyle.Render(f.value),
+			))
+		}
+	}
+
+	lines = append(lines, helpStyle.Render("Enter/Esc: close"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (c *Content) Result() modal.Result {
+	return modal.ResultNone
+}
+
+func (c *Content) ShouldClose() bool {
+	return c.closed
+}
+
+func (c *Content) SetWidth(width int) {
+	c.width = width
+}
+
+// formatCount renders a number with thousands separators
+func formatCount(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	if n < 0 {
+		return s
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// formatBytes renders a byte count in the largest fitting binary unit
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// Model wraps the generic modal with table info content
+type Model struct {
+	modal   modal.Model
+	content *Content
+}
+
+// New creates a new table info modal
+func New() Model {
+	content := NewContent()
+	m := modal.New("Table Info", content)
+	return Model{
+		modal:   m,
+		content: content,
+	}
+}
+
+// Show displays the modal with the statistics for a table
+func (m *Model) Show(tableName string, info *drivers.TableInfo, err error) {
+	logger.Debug("Table info modal opened", map[string]any{
+		"table": tableName,
+	})
+	m.content.SetInfo(tableName, info, err)
+	m.modal.Show()
+}
+
+// Hide hides the modal
+func (m *Model) Hide() {
+	m.modal.Hide()
+}
+
+// Visible returns whether the modal is visible
+func (m Model) Visible() bool {
+	return m.modal.Visible()
+}
+
+// SetSize sets the terminal size for centering
+func (m *Model) SetSize(width, height int) {
+	m.modal.SetSize(width, height)
+}
+
+// Update handles input
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.modal, cmd = m.modal.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m Model) View() string {
+	return m.modal.View()
+}
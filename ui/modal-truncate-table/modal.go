@@ -0,0 +1,221 @@
+package modaltruncatetable
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/logger"
+	"github.com/sheenazien8/sq/ui/modal"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// step is the stage of the confirmation
+type step int
+
+const (
+	stepConfirm  step = iota // Asked whether to go on
+	stepTypeName             // Asked to type the table name
+)
+
+// Content implements modal.Content for confirming a table truncate in two
+// steps: a yes/no question, then typing the table name
+type Content struct {
+	tableName string
+	statement string
+	step      step
+	input     textinput.Model
+	mismatch  bool // The typed name did not match on the last Enter
+	result    modal.Result
+	closed    bool
+	width     int
+}
+
+// NewContent creates a new truncate table content
+func NewContent() *Content {
+	ti := textinput.New()
+	ti.Placeholder = "table name"
+	ti.CharLimit = 256
+	ti.Width = 40
+	return &Content{
+		input:  ti,
+		result: modal.ResultNone,
+	}
+}
+
+// SetTable resets the confirmation for a table and the statement that will run
+func (c *Content) SetTable(tableName, statement string) {
+	c.tableName = tableName
+	c.statement = statement
+	c.step = stepConfirm
+	c.input.SetValue("")
+	c.input.Blur()
+	c.mismatch = false
+	c.result = modal.ResultNone
+	c.closed = false
+}
+
+func (c *Content) Update(msg tea.Msg) (modal.Content, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+
+	if keyMsg.String() == "esc" {
+		logger.Debug("Truncate table cancelled", map[string]any{"table": c.tableName})
+		c.result = modal.ResultCancel
+		c.closed = true
+		return c, nil
+	}
+
+	switch c.step {
+	case stepConfirm:
+		switch keyMsg.String() {
+		case "y", "enter":
+			c.step = stepTypeName
+			return c, c.input.Focus()
+		case "n":
+			c.result = modal.ResultCancel
+			c.closed = true
+		}
+		return c, nil
+
+	default:
+		if keyMsg.String() == "enter" {
+			if c.input.Value() != c.tableName {
+				c.mismatch = true
+				return c, nil
+			}
+			logger.Info("Truncate table confirmed", map[string]any{"table": c.tableName})
+			c.result = modal.ResultSubmit
+			c.closed = true
+			return c, nil
+		}
+		c.mismatch = false
+		var cmd tea.Cmd
+		c.input, cmd = c.input.Update(msg)
+		return c, cmd
+	}
+}
+
+func (c *Content) View() string {
+	t := theme.Current
+
+	messageStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Foreground).
+		Align(lipgloss.Center).
+		Padding(0, 0, 1, 0)
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Primary).
+		Align(lipgloss.Center).
+		Bold(true).
+		Padding(0, 0, 1, 0)
+
+	statementStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Align(lipgloss.Center).
+		Padding(0, 0, 1, 0)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Error).
+		Align(lipgloss.Center)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Align(lipgloss.Center).
+		Padding(1, 0, 0, 0)
+
+	content := []string{
+		messageStyle.Render("Delete every row of this table?"),
+		warningStyle.Render("\"" + c.tableName + "\""),
+		statementStyle.Render(c.statement),
+	}
+
+	if c.step == stepConfirm {
+		content = append(content,
+			messageStyle.Render("This action cannot be undone."),
+			helpStyle.Render("Enter/y: continue | Esc/n: cancel"))
+	} else {
+		content = append(content,
+			messageStyle.Render("Type the table name to confirm:"),
+			c.input.View())
+		if c.mismatch {
+			content = append(content, errorStyle.Render("The name does not match"))
+		}
+		content = append(content, helpStyle.Render("Enter: truncate | Esc: cancel"))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center, content...)
+}
+
+func (c *Content) Result() modal.Result {
+	return c.result
+}
+
+func (c *Content) ShouldClose() bool {
+	return c.closed
+}
+
+func (c *Content) SetWidth(width int) {
+	c.width = width
+}
+
+// Model wraps the generic modal with truncate table content
+type Model struct {
+	modal   modal.Model
+	content *Content
+}
+
+// New creates a new truncate table modal
+func New() Model {
+	content := NewContent()
+	m := modal.New("Truncate Table", content)
+	return Model{
+		modal:   m,
+		content: content,
+	}
+}
+
+// Show asks to confirm running statement, which empties tableName
+func (m *Model) Show(tableName, statement string) {
+	logger.Debug("Truncate table modal opened", map[string]any{"table": tableName})
+	m.content.SetTable(tableName, statement)
+	m.modal.Show()
+}
+
+// Hide hides the modal
+func (m *Model) Hide() {
+	m.modal.Hide()
+}
+
+// Visible returns whether the modal is visible
+func (m Model) Visible() bool {
+	return m.modal.Visible()
+}
+
+// SetSize sets the terminal size for centering
+func (m *Model) SetSize(width, height int) {
+	m.modal.SetSize(width, height)
+}
+
+// Update handles input
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.modal, cmd = m.modal.Update(msg)
+	return m, cmd
+}
+
+// View renders the modal
+func (m Model) View() string {
+	return m.modal.View()
+}
+
+// Result returns the modal result
+func (m Model) Result() modal.Result {
+	return m.modal.Result()
+}
+
+// Statement returns the statement the confirmation is for
+func (m Model) Statement() string {
+	return m.content.statement
+}
@@ -81,7 +81,7 @@ func (m *Model) SetSize(width, height int) {
 	m.height = height
 	if m.Content != nil {
 		// Give content a reasonable width (modal inner width)
-		m.Content.SetWidth(width - 20)
+		m.Content.SetWidth(ContentWidth(width, width))
 	}
 }
 
@@ -89,10 +89,20 @@ func (m *Model) SetSize(width, height int) {
 func (m *Model) SetContent(content Content) {
 	m.Content = content
 	if m.width > 0 {
-		m.Content.SetWidth(m.width - 20)
+		m.Content.SetWidth(ContentWidth(m.width, m.width))
 	}
 }
 
+// minContentWidth is the narrowest content width a modal is given
+const minContentWidth = 20
+
+// ContentWidth returns the content width for a modal that prefers preferred
+// columns, shrunk to fit inside the dialog border and margins of a terminal
+// terminalWidth columns wide
+func ContentWidth(terminalWidth, preferred int) int {
+	return max(minContentWidth, min(preferred, terminalWidth-20))
+}
+
 // Update handles input
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	if !m.visible || m.Content == nil {
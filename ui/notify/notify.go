@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/ui/theme"
+)
+
+// Level is the severity of a notification, which picks its icon and colour
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelSuccess
+	LevelWarning
+	LevelError
+)
+
+// Duration is how long a notification stays before it hides itself
+const Duration = 5 * time.Second
+
+// ErrorDuration is how long an error stays, giving more time to read it
+const ErrorDuration = 10 * time.Second
+
+// dismissHint is appended to the text so the key that hides it is discoverable
+const dismissHint = "  Esc: Dismiss"
+
+// ExpiredMsg hides the notification it was scheduled for, unless a newer one replaced it
+type ExpiredMsg struct {
+	seq int
+}
+
+// Model is a single notification shown in place of the footer help
+type Model struct {
+	text  string
+	level Level
+	seq   int
+}
+
+// New creates a notification model with nothing shown
+func New() Model {
+	return Model{}
+}
+
+// Show replaces the current notification with text, returning the command that
+// hides it again
+func (m *Model) Show(level Level, text string) tea.Cmd {
+	m.seq++
+	m.text = text
+	m.level = level
+
+	duration := Duration
+	if level == LevelError {
+		duration = ErrorDuration
+	}
+	seq := m.seq
+	return tea.Tick(duration, func(time.Time) tea.Msg {
+		return ExpiredMsg{seq: seq}
+	})
+}
+
+// Dismiss hides the notification
+func (m *Model) Dismiss() {
+	m.text = ""
+}
+
+// Expire hides the notification when msg belongs to the one still shown,
+// reporting whether it did
+func (m *Model) Expire(msg ExpiredMsg) bool {
+	if msg.seq != m.seq || m.text == "" {
+		return false
+	}
+	m.text = ""
+	return true
+}
+
+// Visible returns whether a notification is shown
+func (m Model) Visible() bool {
+	return m.text != ""
+}
+
+// Level returns the severity of the shown notification
+func (m Model) Level() Level {
+	return m.level
+}
+
+// Text returns the notification with its icon and dismiss hint, cut to fit in
+// width characters
+func (m Model) Text(width int) string {
+	// Multi-line errors, as drivers return them, are kept on the one line
+	text := []rune(m.icon() + " " + strings.Join(strings.Fields(m.text), " "))
+	hint := []rune(dismissHint)
+	if width-len(hint) < 10 {
+		// Too narrow for the hint, the message matters more
+		hint = nil
+	}
+	maxWidth := max(1, width-len(hint))
+	if len(text) > maxWidth {
+		text = append(text[:maxWidth-1], '…')
+	}
+	return string(text) + string(hint)
+}
+
+// Style returns base coloured for the notification's severity: the theme's
+// severity colour as background under dark text, as every theme's is light
+func (m Model) Style(base lipgloss.Style) lipgloss.Style {
+	c := theme.Current.Colors
+	background := c.Info
+	switch m.level {
+	case LevelSuccess:
+		background = c.Success
+	case LevelWarning:
+		background = c.Warning
+	case LevelError:
+		background = c.Error
+	}
+	return base.Copy().
+		Foreground(c.Background).
+		Background(background).
+		Bold(true)
+}
+
+// icon returns the symbol shown in front of the notification
+func (m Model) icon() string {
+	switch m.level {
+	case LevelSuccess:
+		return "✓"
+	case LevelWarning:
+		return "⚠"
+	case LevelError:
+		return "✖"
+	}
+	return "ℹ"
+}
@@ -0,0 +1,88 @@
+package queryeditor
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// editCharUnderCursor rewrites the character under the cursor with edit.
+// It returns false when the cursor is not on a character.
+func (m *Model) editCharUnderCursor(edit func(r rune) rune) bool {
+	x, y := m.syntaxEditor.CursorX(), m.syntaxEditor.CursorY()
+	lines := strings.Split(m.syntaxEditor.Value(), "\n")
+	if y >= len(lines) || x >= len(lines[y]) {
+		return false
+	}
+
+	line := lines[y]
+	r, size := utf8.DecodeRuneInString(line[x:])
+	m.saveUndoState()
+	lines[y] = line[:x] + string(edit(r)) + line[x+size:]
+	m.syntaxEditor.SetValue(strings.Join(lines, "\n"))
+	m.syntaxEditor.SetCursorPosition(x, y)
+	return true
+}
+
+// replaceChar replaces the character under the cursor with key (r<char>)
+func (m *Model) replaceChar(key string) {
+	if key == "space" {
+		key = " "
+	}
+	if utf8.RuneCountInString(key) != 1 {
+		return
+	}
+	replacement, _ := utf8.DecodeRuneInString(key)
+	m.editCharUnderCursor(func(rune) rune { return replacement })
+}
+
+// toggleCase swaps the case of the character under the cursor and moves right (~)
+func (m *Model) toggleCase() {
+	if !m.editCharUnderCursor(func(r rune) rune {
+		if unicode.IsUpper(r) {
+			return unicode.ToLower(r)
+		}
+		return unicode.ToUpper(r)
+	}) {
+		return
+	}
+
+	x, y := m.syntaxEditor.CursorX(), m.syntaxEditor.CursorY()
+	line := strings.Split(m.syntaxEditor.Value(), "\n")[y]
+	_, size := utf8.DecodeRuneInString(line[x:])
+	// Like vim, stay on the last character instead of moving past the line end
+	if x+size < len(line) {
+		m.syntaxEditor.SetCursorPosition(x+size, y)
+	}
+}
+
+// findChar moves the cursor to the next occurrence of key on the current line (f<char>),
+// or to the character just before it when till is set (t<char>)
+func (m *Model) findChar(key string, till bool) {
+	if key == "space" {
+		key = " "
+	}
+	if utf8.RuneCountInString(key) != 1 {
+		return
+	}
+
+	x, y := m.syntaxEditor.CursorX(), m.syntaxEditor.CursorY()
+	lines := strings.Split(m.syntaxEditor.Value(), "\n")
+	if y >= len(lines) || x >= len(lines[y]) {
+		return
+	}
+
+	line := lines[y]
+	_, size := utf8.DecodeRuneInString(line[x:])
+	start := x + size
+	idx := strings.Index(line[start:], key)
+	if idx == -1 {
+		return
+	}
+	target := start + idx
+	if till {
+		_, prev := utf8.DecodeLastRuneInString(line[:target])
+		target -= prev
+	}
+	m.syntaxEditor.SetCursorPosition(target, y)
+}
@@ -0,0 +1,84 @@
+package queryeditor
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends keys to the editor in normal mode, one key per rune of keys
+func press(m Model, keys string) Model {
+	for _, r := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+		if r == ' ' {
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+		}
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+func TestCharCommands(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		x, y  int
+		keys  string
+		want  string
+		wantX int
+		wantY int
+	}{
+		{"replace", "select", 0, 0, "rS", "Select", 0, 0},
+		{"replace keeps cursor", "select", 3, 0, "rE", "selEct", 3, 0},
+		{"replace with space", "a,b", 1, 0, "r ", "a b", 1, 0},
+		{"replace last char", "abc", 2, 0, "rX", "abX", 2, 0},
+		{"replace twice", "abc", 0, 0, "rxrY", "Ybc", 0, 0},
+		{"replace on second line", "abc\ndef", 1, 1, "rZ", "abc\ndZf", 1, 1},
+		{"replace multibyte", "a→b", 1, 0, "r-", "a-b", 1, 0},
+		{"replace on empty line", "abc\n\ndef", 0, 1, "rx", "abc\n\ndef", 0, 1},
+		{"toggle case moves right", "select", 0, 0, "~", "Select", 1, 0},
+		{"toggle case repeated", "select", 0, 0, "~~~", "SELect", 3, 0},
+		{"toggle case lower", "SELECT", 0, 0, "~~", "seLECT", 2, 0},
+		{"toggle case non letter", "a1b", 1, 0, "~", "a1b", 2, 0},
+		{"toggle case stops at line end", "ab", 0, 0, "~~", "AB", 1, 0},
+		{"toggle case toggles the last char back", "ab", 0, 0, "~~~", "Ab", 1, 0},
+		{"toggle case stays on its line", "ab\ncd", 1, 0, "~", "aB\ncd", 1, 0},
+		{"toggle case multibyte", "äb", 0, 0, "~~", "ÄB", 2, 0},
+		{"toggle case on empty line", "abc\n\ndef", 0, 1, "~", "abc\n\ndef", 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New("conn", "db")
+			m.SetQuery(tt.value)
+			m.syntaxEditor.SetCursorPosition(tt.x, tt.y)
+
+			m = press(m, tt.keys)
+
+			if got := m.syntaxEditor.Value(); got != tt.want {
+				t.Errorf("buffer = %q, want %q", got, tt.want)
+			}
+			if x, y := m.syntaxEditor.CursorX(), m.syntaxEditor.CursorY(); x != tt.wantX || y != tt.wantY {
+				t.Errorf("cursor = (%d, %d), want (%d, %d)", x, y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestCharCommandsUndo(t *testing.T) {
+	m := New("conn", "db")
+	m.SetQuery("abc")
+	m.syntaxEditor.SetCursorPosition(0, 0)
+
+	m = press(m, "~rX")
+	if got := m.syntaxEditor.Value(); got != "AXc" {
+		t.Fatalf("buffer = %q, want %q", got, "AXc")
+	}
+	m = press(m, "u")
+	if got := m.syntaxEditor.Value(); got != "Abc" {
+		t.Errorf("after one undo buffer = %q, want %q", got, "Abc")
+	}
+	m = press(m, "u")
+	if got := m.syntaxEditor.Value(); got != "abc" {
+		t.Errorf("after two undos buffer = %q, want %q", got, "abc")
+	}
+}
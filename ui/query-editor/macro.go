@@ -0,0 +1,68 @@
+package queryeditor
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/logger"
+)
+
+// isMacroRegister reports whether key names a macro register (a-z or 0-9)
+func isMacroRegister(key string) bool {
+	if len(key) != 1 {
+		return false
+	}
+	c := key[0]
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
+
+// startRecording starts capturing keys into a register (q<reg>)
+func (m *Model) startRecording(register string) {
+	if !isMacroRegister(register) {
+		return
+	}
+	m.recording = register
+	m.recordedKeys = nil
+	logger.Debug("Macro recording started", map[string]any{
+		"register": register,
+	})
+}
+
+// stopRecording stores the captured keys in the register being recorded (q)
+func (m *Model) stopRecording() {
+	// The q that stopped the recording was captured too
+	keys := m.recordedKeys
+	if len(keys) > 0 {
+		keys = keys[:len(keys)-1]
+	}
+
+	m.macros[m.recording] = keys
+	logger.Debug("Macro recording stopped", map[string]any{
+		"register": m.recording,
+		"keys":     len(keys),
+	})
+	m.recording = ""
+	m.recordedKeys = nil
+}
+
+// replayMacro feeds the keys of a register back through Update (@<reg>, @@ for the last one)
+func (m Model) replayMacro(register string) (Model, tea.Cmd) {
+	if register == "@" {
+		register = m.lastMacro
+	}
+	keys, ok := m.macros[register]
+	// Macros calling macros are not supported, they could replay forever
+	if !ok || m.replaying {
+		return m, nil
+	}
+	m.lastMacro = register
+
+	m.replaying = true
+	var cmds []tea.Cmd
+	for _, key := range keys {
+		var cmd tea.Cmd
+		m, cmd = m.Update(key)
+		cmds = append(cmds, cmd)
+	}
+	m.replaying = false
+
+	return m, tea.Batch(cmds...)
+}
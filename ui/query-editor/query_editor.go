@@ -1,12 +1,15 @@
 package queryeditor
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/cockroachdb/cockroachdb-parser/pkg/sql/sem/tree"
 	"github.com/mjibson/sqlfmt"
+	"github.com/sheenazien8/sq/config"
 	"github.com/sheenazien8/sq/logger"
 	syntaxeditor "github.com/sheenazien8/sq/ui/syntax-editor"
 	"github.com/sheenazien8/sq/ui/table"
@@ -46,11 +49,31 @@ type YankCellMsg struct {
 	Content string
 }
 
+// ExportResultsMsg is sent when the user wants to export the shown result set;
+// Marked holds the rows marked with m, if any
+type ExportResultsMsg struct {
+	Columns []table.Column
+	Rows    []table.Row
+	Marked  []table.Row
+}
+
 // YankQueryMsg is sent when user wants to copy the entire query to system clipboard
 type YankQueryMsg struct {
 	Content string
 }
 
+// ResultSet is one result set of an executed query
+type ResultSet struct {
+	Columns   []table.Column
+	Rows      []table.Row
+	TotalRows int    // Rows the query matches without its LIMIT, -1 when unknown
+	Limited   bool   // The statement ends in a LIMIT its rows reached, so more may match
+	Statement string // Statement the rows come from
+}
+
+// resultCountBarHeight is the height of the row count line above the result table
+const resultCountBarHeight = 1
+
 // UndoState represents a snapshot of the editor state for undo
 type UndoState struct {
 	content string
@@ -62,23 +85,35 @@ type UndoState struct {
 type Model struct {
 	syntaxEditor   syntaxeditor.Model
 	resultTable    table.Model
+	resultSets     []ResultSet // Result sets of the last execution, one per row-returning statement
+	activeResult   int         // Index of the result set shown in resultTable
+	resultSummary  string      // Affected-row counts of statements without a result set
 	connectionName string
 	databaseName   string
 	width          int
 	height         int
 	focused        bool
 	showResults    bool
+	fullscreen     bool // Results take the whole area, hiding the editor
 	lastError      string
 	editorHeight   int // Height of the editor area
 	resultHeight   int // Height of the result area
 	vimMode        VimMode
 	vimEnabled     bool
-	pendingCommand string      // Pending vim command (e.g., "d" for dd)
-	yankBuffer     string      // Buffer for yanked text
-	visualStartX   int         // Start X for visual selection
-	visualStartY   int         // Start Y for visual selection
-	undoStack      []UndoState // Undo history stack
-	maxUndoSize    int         // Maximum undo history size
+	pendingCommand string                  // Pending vim command (e.g., "d" for dd)
+	yankBuffer     string                  // Buffer for yanked text
+	visualStartX   int                     // Start X for visual selection
+	visualStartY   int                     // Start Y for visual selection
+	undoStack      []UndoState             // Undo history stack
+	maxUndoSize    int                     // Maximum undo history size
+	macros         map[string][]tea.KeyMsg // Recorded vim macros by register
+	recording      string                  // Register being recorded into, "" when not recording
+	recordedKeys   []tea.KeyMsg            // Keys captured for the macro being recorded
+	lastMacro      string                  // Register replayed last, for @@
+	replaying      bool                    // Whether a macro is being replayed
+	formatOptions  config.FormatOptions    // Settings for formatSQL
+	lastExecuted   string                  // Query sent by the last execute, rerun with Ctrl+L
+	running        string                  // Spinner frame shown while the query runs, "" when idle
 }
 
 // New creates a new query editor model
@@ -106,9 +141,17 @@ func New(connectionName, databaseName string) Model {
 		visualStartY:   0,
 		undoStack:      make([]UndoState, 0),
 		maxUndoSize:    100,
+		macros:         make(map[string][]tea.KeyMsg),
+		formatOptions:  config.DefaultConfig().FormatOptions(),
 	}
 }
 
+// SetEditorOptions applies the configured auto-indent and formatter settings
+func (m *Model) SetEditorOptions(autoIndent bool, format config.FormatOptions) {
+	m.syntaxEditor.SetAutoIndent(autoIndent)
+	m.formatOptions = format
+}
+
 // saveUndoState saves the current editor state to the undo stack
 func (m *Model) saveUndoState() {
 	state := UndoState{
@@ -149,7 +192,11 @@ func (m *Model) SetSize(width, height int) {
 	m.height = height
 
 	// Calculate heights: editor gets top portion, results get the rest
-	if m.showResults {
+	if m.showResults && m.fullscreen {
+		m.editorHeight = 0
+		m.resultHeight = height - 2 // 2 for status bar and results title
+	} else if m.showResults || m.lastError != "" {
+		// Errors use the results area so long messages can wrap
 		m.editorHeight = max(5, height/3)
 		m.resultHeight = height - m.editorHeight - 3 // 3 for borders and separator
 	} else {
@@ -158,11 +205,13 @@ func (m *Model) SetSize(width, height int) {
 	}
 
 	// Set syntax editor size (account for borders and padding)
-	m.syntaxEditor.SetSize(width-4, m.editorHeight-2)
+	if m.editorHeight > 0 {
+		m.syntaxEditor.SetSize(width-4, m.editorHeight-2)
+	}
 
 	// Set result table size if showing results
 	if m.showResults && m.resultHeight > 0 {
-		m.resultTable.SetSize(width-4, m.resultHeight-2)
+		m.resultTable.SetSize(width-4, m.resultHeight-2-resultCountBarHeight)
 	}
 }
 
@@ -191,6 +240,19 @@ func (m *Model) SetQuery(query string) {
 	m.syntaxEditor.SetValue(query)
 }
 
+// InsertSnippet inserts query at the cursor, or makes it the whole query when
+// the editor is empty, returning the command that saves the recovery file
+func (m *Model) InsertSnippet(query string) tea.Cmd {
+	m.saveUndoState()
+	if strings.TrimSpace(m.GetQuery()) == "" {
+		m.syntaxEditor.SetValue(query)
+		m.syntaxEditor.SetCursorPosition(0, 0)
+	} else {
+		m.syntaxEditor.InsertText(query)
+	}
+	return m.scheduleRecoverySave()
+}
+
 // GetConnectionName returns the connection name
 func (m Model) GetConnectionName() string {
 	return m.connectionName
@@ -203,21 +265,75 @@ func (m Model) GetDatabaseName() string {
 
 // SetResults sets the query results
 func (m *Model) SetResults(columns []table.Column, rows []table.Row) {
-	m.resultTable = table.New(columns, rows)
-	m.resultTable.SetSize(m.width-4, m.resultHeight-2)
-	m.resultTable.SetFocused(false)
+	m.SetResultSets([]ResultSet{{Columns: columns, Rows: rows, TotalRows: len(rows)}}, "")
+}
+
+// SetResultSets sets the result sets of a multi-statement execution, along with
+// a summary line for statements that returned no rows
+func (m *Model) SetResultSets(sets []ResultSet, summary string) {
+	if len(sets) == 0 {
+		sets = []ResultSet{{}}
+	}
+	m.resultSets = sets
+	m.resultSummary = summary
 	m.showResults = true
 	m.lastError = ""
+	m.showResultSet(0)
+	m.resultTable.SetFocused(false)
 	m.SetSize(m.width, m.height) // Recalculate sizes
 }
 
+// SetResultTotal sets how many rows the statement of the result set at index
+// matches without its LIMIT, once counted. Result sets of another statement,
+// shown since the count started, are left alone.
+func (m *Model) SetResultTotal(index int, statement string, total int) {
+	if index < 0 || index >= len(m.resultSets) || m.resultSets[index].Statement != statement {
+		return
+	}
+	m.resultSets[index].TotalRows = total
+}
+
+// showResultSet loads the result set at index into the result table
+func (m *Model) showResultSet(index int) {
+	if index < 0 || index >= len(m.resultSets) {
+		return
+	}
+	focused := m.resultTable.Focused()
+	set := m.resultSets[index]
+	m.activeResult = index
+	m.resultTable = table.New(set.Columns, set.Rows)
+	m.resultTable.SetSize(m.width-4, m.resultHeight-2-resultCountBarHeight)
+	m.resultTable.SetFocused(focused)
+}
+
 // SetError sets an error message
 func (m *Model) SetError(err string) {
 	m.lastError = err
 	m.showResults = false
+	m.fullscreen = false
 	m.SetSize(m.width, m.height) // Recalculate sizes
 }
 
+// SetRunning shows indicator, a spinner frame, in the status bar while the query
+// runs in the background; "" once it finished
+func (m *Model) SetRunning(indicator string) {
+	m.running = indicator
+}
+
+// Running returns whether the last executed query is still running
+func (m Model) Running() bool {
+	return m.running != ""
+}
+
+// setFullscreen shows the results over the whole area or restores the split with the editor
+func (m *Model) setFullscreen(fullscreen bool) {
+	if m.fullscreen == fullscreen {
+		return
+	}
+	m.fullscreen = fullscreen
+	m.SetSize(m.width, m.height)
+}
+
 // HasResults returns whether there are query results to display
 func (m Model) HasResults() bool {
 	return m.showResults
@@ -228,8 +344,17 @@ func (m Model) GetError() string {
 	return m.lastError
 }
 
-// Update handles input
+// Update handles input, scheduling a recovery save when a key changes the query
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	before := m.GetQuery()
+	m, cmd := m.update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok && m.GetQuery() != before {
+		cmd = tea.Batch(cmd, m.scheduleRecoverySave())
+	}
+	return m, cmd
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
@@ -241,6 +366,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			"vimMode": m.vimMode,
 		})
 
+		// Capture keys for the macro being recorded
+		if m.recording != "" && !m.replaying {
+			m.recordedKeys = append(m.recordedKeys, msg)
+		}
+
 		// Global shortcuts that work in any mode
 		switch keyStr {
 		case "f5", "ctrl+e":
@@ -252,21 +382,27 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				"database":   m.databaseName,
 			})
 			if query != "" {
-				return m, func() tea.Msg {
-					return QueryExecuteMsg{
-						Query:          query,
-						ConnectionName: m.connectionName,
-						DatabaseName:   m.databaseName,
-					}
-				}
+				m.lastExecuted = query
+				return m, m.executeCmd(query)
 			}
 			return m, nil
+		case "ctrl+l":
+			// Run the last executed query again, even if the editor changed since
+			if m.lastExecuted == "" {
+				return m, nil
+			}
+			logger.Debug("Re-running last query", map[string]any{
+				"query":      m.lastExecuted,
+				"connection": m.connectionName,
+			})
+			return m, m.executeCmd(m.lastExecuted)
 		case "ctrl+r":
 			// Toggle between editor and results focus
 			if m.showResults {
 				if m.resultTable.Focused() {
 					// Switch from results to editor
 					m.resultTable.SetFocused(false)
+					m.setFullscreen(false)
 					m.syntaxEditor.Focus()
 					m.vimMode = VimNormal
 				} else {
@@ -296,11 +432,26 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			// Allow switching back to editor
 			if keyStr == "i" || keyStr == "a" {
 				m.resultTable.SetFocused(false)
+				m.setFullscreen(false)
 				m.vimMode = VimInsert
 				m.syntaxEditor.SetCursorStyle(syntaxeditor.CursorLine)
 				m.syntaxEditor.Focus()
 				return m, nil
 			}
+			// Toggle full-screen results, the editor keeps its content while hidden
+			if keyStr == "z" {
+				m.setFullscreen(!m.fullscreen)
+				return m, nil
+			}
+			// Switch between result sets
+			if keyStr == "}" {
+				m.showResultSet((m.activeResult + 1) % len(m.resultSets))
+				return m, nil
+			}
+			if keyStr == "{" {
+				m.showResultSet((m.activeResult - 1 + len(m.resultSets)) % len(m.resultSets))
+				return m, nil
+			}
 			// Preview cell content
 			if keyStr == "p" {
 				cellContent := m.resultTable.SelectedCell()
@@ -311,6 +462,16 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				}
 				return m, nil
 			}
+			// Export the shown result set to a file
+			if keyStr == "x" {
+				set := m.resultSets[m.activeResult]
+				export := ExportResultsMsg{
+					Columns: set.Columns,
+					Rows:    set.Rows,
+					Marked:  m.resultTable.MarkedRows(),
+				}
+				return m, func() tea.Msg { return export }
+			}
 			// Yank (copy) cell content
 			if keyStr == "y" {
 				cellContent := m.resultTable.SelectedCell()
@@ -371,6 +532,26 @@ func (m Model) handleVimNormal(msg tea.KeyMsg) (Model, tea.Cmd) {
 
 	// Handle pending commands (e.g., dd, yy)
 	if m.pendingCommand != "" {
+		if m.pendingCommand == "@" {
+			m.pendingCommand = ""
+			return m.replayMacro(keyStr)
+		}
+		if m.pendingCommand == "q" {
+			m.pendingCommand = ""
+			m.startRecording(keyStr)
+			return m, nil
+		}
+		if m.pendingCommand == "r" {
+			m.pendingCommand = ""
+			m.replaceChar(keyStr)
+			return m, nil
+		}
+		if m.pendingCommand == "f" || m.pendingCommand == "t" {
+			till := m.pendingCommand == "t"
+			m.pendingCommand = ""
+			m.findChar(keyStr, till)
+			return m, nil
+		}
 		if m.pendingCommand == "d" && keyStr == "d" {
 			// Delete line and yank it
 			m.saveUndoState() // Save state before delete
@@ -488,6 +669,10 @@ func (m Model) handleVimNormal(msg tea.KeyMsg) (Model, tea.Cmd) {
 		// Move word backward
 		m.syntaxEditor, _ = m.syntaxEditor.Update(tea.KeyMsg{Type: tea.KeyCtrlLeft})
 		return m, nil
+	case "f", "t":
+		// Find character on line (f<char>), or stop before it (t<char>)
+		m.pendingCommand = keyStr
+		return m, nil
 
 	// Deletion
 	case "x":
@@ -503,6 +688,13 @@ func (m Model) handleVimNormal(msg tea.KeyMsg) (Model, tea.Cmd) {
 	case "d":
 		m.pendingCommand = "d"
 		return m, nil
+	case "r":
+		// Replace character under cursor with the next key
+		m.pendingCommand = "r"
+		return m, nil
+	case "~":
+		m.toggleCase()
+		return m, nil
 
 	// Undo
 	case "u":
@@ -511,6 +703,18 @@ func (m Model) handleVimNormal(msg tea.KeyMsg) (Model, tea.Cmd) {
 	case "y":
 		m.pendingCommand = "y"
 		return m, nil
+
+	// Macros
+	case "q":
+		if m.recording != "" {
+			m.stopRecording()
+		} else {
+			m.pendingCommand = "q"
+		}
+		return m, nil
+	case "@":
+		m.pendingCommand = "@"
+		return m, nil
 	case "Y":
 		// Yank entire query to system clipboard
 		query := m.GetQuery()
@@ -810,9 +1014,9 @@ func (m *Model) formatSQL() {
 	}
 
 	cfg := tree.DefaultPrettyCfg()
-	cfg.LineWidth = 80
-	cfg.TabWidth = 2
-	cfg.Simplify = true
+	cfg.LineWidth = m.formatOptions.LineWidth
+	cfg.TabWidth = m.formatOptions.TabWidth
+	cfg.Simplify = m.formatOptions.Simplify
 
 	formatted, err := sqlfmt.FmtSQL(cfg, []string{query})
 	if err != nil {
@@ -838,6 +1042,27 @@ func (m Model) GetVimMode() string {
 	}
 }
 
+// executeCmd returns a command asking the app to run query on the editor's connection
+func (m Model) executeCmd(query string) tea.Cmd {
+	return func() tea.Msg {
+		return QueryExecuteMsg{
+			Query:          query,
+			ConnectionName: m.connectionName,
+			DatabaseName:   m.databaseName,
+		}
+	}
+}
+
+// cursorStatus returns the cursor position and size of the query, e.g. "Ln 3, Col 12 | 20 lines"
+func (m Model) cursorStatus() string {
+	lines := m.syntaxEditor.LineCount()
+	unit := "lines"
+	if lines == 1 {
+		unit = "line"
+	}
+	return fmt.Sprintf("Ln %d, Col %d | %d %s", m.syntaxEditor.CursorY()+1, m.syntaxEditor.CursorX()+1, lines, unit)
+}
+
 // View renders the query editor
 func (m Model) View() string {
 	if m.width <= 0 || m.height <= 0 {
@@ -883,7 +1108,10 @@ func (m Model) View() string {
 
 	var statusText string
 	if m.showResults && m.resultTable.Focused() {
-		statusText = "hjkl: Navigate | p: Preview | y: Yank | i: Back to Editor | Ctrl+R: Editor"
+		statusText = "hjkl: Navigate | p: Preview | y: Yank | z: Fullscreen | i: Back to Editor | Ctrl+R: Editor"
+		if len(m.resultSets) > 1 {
+			statusText = "{/}: Result Set | " + statusText
+		}
 	} else if m.vimMode == VimNormal {
 		statusText = "i: Insert | hjkl: Navigate | Y: Copy Query | F5: Execute | Ctrl+F: Format"
 	} else if m.vimMode == VimVisual {
@@ -894,13 +1122,26 @@ func (m Model) View() string {
 	if m.lastError != "" {
 		statusText = lipgloss.NewStyle().
 			Foreground(t.Colors.Error).
-			Render("Error: " + truncateText(m.lastError, m.width-20))
+			Render("Query failed, see the error below")
+	}
+	if m.running != "" {
+		statusText = lipgloss.NewStyle().
+			Foreground(t.Colors.Warning).
+			Render(m.running + " Running query…")
+	}
+	if m.recording != "" {
+		modeIndicator += lipgloss.NewStyle().
+			Foreground(t.Colors.Warning).
+			Render(" recording @" + m.recording)
 	}
 	statusBar := lipgloss.JoinHorizontal(lipgloss.Left,
 		modeIndicator,
 		" ",
-		lipgloss.NewStyle().Foreground(t.Colors.ForegroundDim).Render(statusText),
+		lipgloss.NewStyle().Foreground(t.Colors.Foreground).Render(m.cursorStatus()),
+		lipgloss.NewStyle().Foreground(t.Colors.ForegroundDim).Render(" | "+statusText),
 	)
+	// Cut the help, not the layout, when the status bar is wider than the editor
+	statusBar = lipgloss.NewStyle().MaxWidth(m.width).Render(statusBar)
 
 	// Results section (if showing)
 	if m.showResults && m.resultHeight > 0 {
@@ -908,6 +1149,15 @@ func (m Model) View() string {
 			Foreground(t.Colors.Success).
 			Bold(true).
 			Render("Results")
+		if len(m.resultSets) > 1 {
+			resultsTitle += " " + m.resultSetSelector()
+		}
+		if m.resultSummary != "" {
+			available := m.width - 4 - lipgloss.Width(resultsTitle) - 2
+			resultsTitle += "  " + lipgloss.NewStyle().
+				Foreground(t.Colors.ForegroundDim).
+				Render(truncateText(m.resultSummary, available))
+		}
 
 		resultsStyle := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -915,12 +1165,22 @@ func (m Model) View() string {
 			Width(m.width - 4).
 			Height(m.resultHeight - 2)
 
-		resultsContent := m.resultTable.View()
+		resultsContent := lipgloss.JoinVertical(lipgloss.Left,
+			m.renderResultCount(),
+			m.resultTable.View(),
+		)
 		resultsSection := lipgloss.JoinVertical(lipgloss.Left,
 			resultsTitle,
 			resultsStyle.Render(resultsContent),
 		)
 
+		if m.fullscreen {
+			return lipgloss.JoinVertical(lipgloss.Left,
+				statusBar,
+				resultsSection,
+			)
+		}
+
 		return lipgloss.JoinVertical(lipgloss.Left,
 			editorSection,
 			statusBar,
@@ -928,12 +1188,109 @@ func (m Model) View() string {
 		)
 	}
 
+	// Error section (if the last execution failed)
+	if m.lastError != "" && m.resultHeight > 0 {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			editorSection,
+			statusBar,
+			m.renderError(),
+		)
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Left,
 		editorSection,
 		statusBar,
 	)
 }
 
+// renderResultCount renders how many rows the shown result set has, and how many
+// the query matches in total when a LIMIT cut the result short
+func (m Model) renderResultCount() string {
+	t := theme.Current
+
+	loaded := 0
+	total := -1
+	limited := false
+	if m.activeResult < len(m.resultSets) {
+		set := m.resultSets[m.activeResult]
+		loaded = len(set.Rows)
+		total = set.TotalRows
+		limited = set.Limited
+	}
+
+	var text string
+	switch {
+	case total > loaded:
+		text = fmt.Sprintf("Showing %d of %d rows · cut short by LIMIT", loaded, total)
+	case limited && total < 0:
+		text = fmt.Sprintf("Showing %d rows · LIMIT reached, more rows may match", loaded)
+	case total >= 0:
+		text = fmt.Sprintf("Showing %d of %d rows", loaded, loaded)
+	default:
+		text = fmt.Sprintf("Showing %d rows", loaded)
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim).
+		Render(truncateText(text, m.width-4))
+}
+
+// renderError renders the full error message wrapped over the results area
+func (m Model) renderError() string {
+	t := theme.Current
+
+	errorTitle := lipgloss.NewStyle().
+		Foreground(t.Colors.Error).
+		Bold(true).
+		Render("Error")
+
+	// Wrap to the inner width, clipping what doesn't fit the area
+	innerHeight := max(1, m.resultHeight-2)
+	wrapped := lipgloss.NewStyle().
+		Width(max(1, m.width-6)).
+		Render(m.lastError)
+	lines := strings.Split(wrapped, "\n")
+	if len(lines) > innerHeight {
+		lines = lines[:innerHeight]
+		lines[innerHeight-1] = "…"
+	}
+
+	errorStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Colors.Error).
+		Foreground(t.Colors.Error).
+		Width(m.width - 4).
+		Height(innerHeight)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		errorTitle,
+		errorStyle.Render(strings.Join(lines, "\n")),
+	)
+}
+
+// resultSetSelector renders the numbered result sets, highlighting the active one
+func (m Model) resultSetSelector() string {
+	t := theme.Current
+
+	activeStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.Background).
+		Background(t.Colors.Primary).
+		Bold(true)
+	inactiveStyle := lipgloss.NewStyle().
+		Foreground(t.Colors.ForegroundDim)
+
+	items := make([]string, len(m.resultSets))
+	for i := range m.resultSets {
+		label := " " + strconv.Itoa(i+1) + " "
+		if i == m.activeResult {
+			items[i] = activeStyle.Render(label)
+		} else {
+			items[i] = inactiveStyle.Render(label)
+		}
+	}
+	return strings.Join(items, "")
+}
+
 // truncateText truncates text to a maximum width
 func truncateText(s string, maxWidth int) string {
 	if len(s) <= maxWidth {
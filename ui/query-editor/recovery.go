@@ -0,0 +1,38 @@
+package queryeditor
+
+import (
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recoverySaveDelay is how long the query must stay unchanged before it is saved for recovery
+const recoverySaveDelay = 2 * time.Second
+
+// recoveryEdits counts query changes across all editors; a scheduled save only
+// fires if no edit happened after it, which debounces writes while typing
+var recoveryEdits atomic.Int64
+
+// RecoverySaveMsg is sent when the query should be written to the recovery file
+type RecoverySaveMsg struct {
+	Query          string
+	ConnectionName string
+	DatabaseName   string
+}
+
+// scheduleRecoverySave returns a command that saves the current query once typing pauses
+func (m Model) scheduleRecoverySave() tea.Cmd {
+	edit := recoveryEdits.Add(1)
+	msg := RecoverySaveMsg{
+		Query:          m.GetQuery(),
+		ConnectionName: m.connectionName,
+		DatabaseName:   m.databaseName,
+	}
+	return tea.Tick(recoverySaveDelay, func(time.Time) tea.Msg {
+		if recoveryEdits.Load() != edit {
+			return nil
+		}
+		return msg
+	})
+}
@@ -6,27 +6,45 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/drivers"
 	"github.com/sheenazien8/sq/logger"
 	"github.com/sheenazien8/sq/storage"
 	"github.com/sheenazien8/sq/ui/theme"
 )
 
+// mouseWheelStep is the number of items a mouse wheel notch scrolls
+const mouseWheelStep = 3
+
 type Table struct {
 	Name     string
-	RowCount int64
-	Selected bool
+	Schema   string // Schema holding the table; empty for drivers without schemas
+	RowCount int64  // -1 until the count has been fetched
+	Estimate bool   // RowCount comes from table statistics rather than counting
+	Selected bool   // Marked with Space, for copying the names of several tables
+	Locked   bool   // Reading the table failed with a permission error
+}
+
+// QualifiedName returns the table name prefixed with its schema, if it has one
+func (t Table) QualifiedName() string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
 }
 
 // Connection represents a database item in the sidebar
 type Connection struct {
-	ID        int64
-	Name      string
-	Type      string
-	Host      string
-	Selected  bool
-	Expanded  bool
-	Connected bool
-	Tables    []Table
+	ID         int64
+	Name       string
+	Type       string
+	Host       string
+	Selected   bool
+	Expanded   bool
+	Connected  bool
+	Connecting bool                      // A connection attempt is running in the background
+	Ephemeral  bool                      // Given on the command line; never saved to storage
+	Options    drivers.ConnectionOptions // SSH tunnel and SSL settings
+	Tables     []Table
 }
 
 // TreeItem represents an item in the tree (connection or table)
@@ -41,6 +59,16 @@ type TreeItem struct {
 type TableSelectedMsg struct {
 	ConnectionName string
 	TableName      string
+	Schema         string // Schema of the table; empty for drivers without schemas
+	NewTab         bool   // Open another tab even if the table is already open
+	Confirmed      bool   // The user agreed to open the table despite its estimated size
+}
+
+// MarkedTable is a table marked in the sidebar
+type MarkedTable struct {
+	ConnectionName string
+	TableName      string
+	Schema         string
 }
 
 // ConnectionSelectedMsg is sent when a connection is selected (expanded/activated)
@@ -63,6 +91,9 @@ type Model struct {
 	filterInput textinput.Model
 	filterText  string
 	showFilter  bool
+
+	// Show tables as schema.table, telling apart same-named tables of different schemas
+	qualifiedNames bool
 }
 
 // New creates a new sidebar model with sample databases
@@ -103,6 +134,7 @@ func getConnections() (data []Connection) {
 			Name:      connection.Name,
 			Type:      connection.Driver,
 			Host:      connection.URL,
+			Options:   connection.Options(),
 			Tables:    []Table{}, // Empty initially
 			Expanded:  false,     // start collapsed
 			Connected: false,     // start disconnected
@@ -302,17 +334,27 @@ func (m Model) GetConnections() []Connection {
 }
 
 // UpdateConnection updates a specific connection with new table data and connection status
-func (m *Model) UpdateConnection(name string, tableNames []string, connected bool) {
+func (m *Model) UpdateConnection(name string, tables []Table, connected bool) {
 	for i := range m.connections {
 		if m.connections[i].Name == name {
+			// Keep counts already fetched, known permission errors and marks for
+			// tables that are still there
+			previous := make(map[string]Table, len(m.connections[i].Tables))
+			for _, table := range m.connections[i].Tables {
+				previous[table.QualifiedName()] = table
+			}
+
 			m.connections[i].Connected = connected
-			m.connections[i].Tables = make([]Table, len(tableNames))
-			for j, tableName := range tableNames {
-				m.connections[i].Tables[j] = Table{
-					Name:     tableName,
-					RowCount: 0, // TODO: Get actual row count
-					Selected: false,
+			m.connections[i].Tables = make([]Table, len(tables))
+			for j, table := range tables {
+				table.RowCount = -1
+				if old, ok := previous[table.QualifiedName()]; ok {
+					table.RowCount = old.RowCount
+					table.Estimate = old.Estimate
+					table.Selected = old.Selected
+					table.Locked = old.Locked
 				}
+				m.connections[i].Tables[j] = table
 			}
 			break
 		}
@@ -325,9 +367,182 @@ func (m *Model) UpdateConnection(name string, tableNames []string, connected boo
 	}
 }
 
-// RefreshConnections reloads the connections from storage
+// SetConnecting marks a connection as being connected in the background, or done
+func (m *Model) SetConnecting(name string, connecting bool) {
+	for i := range m.connections {
+		if m.connections[i].Name == name {
+			m.connections[i].Connecting = connecting
+			return
+		}
+	}
+}
+
+// IsConnecting returns whether a connection attempt is running for the named connection
+func (m Model) IsConnecting(name string) bool {
+	for _, conn := range m.connections {
+		if conn.Name == name {
+			return conn.Connecting
+		}
+	}
+	return false
+}
+
+// AddEphemeralConnection adds a connection that is not backed by storage and
+// selects it. It stays in the sidebar until the app exits.
+func (m *Model) AddEphemeralConnection(name, connType, url string) {
+	for i := range m.connections {
+		m.connections[i].Selected = false
+	}
+	m.connections = append(m.connections, Connection{
+		Name:      name,
+		Type:      connType,
+		Host:      url,
+		Selected:  true,
+		Expanded:  true,
+		Ephemeral: true,
+		Tables:    []Table{},
+	})
+	m.cursor = m.connectionRow(len(m.connections) - 1)
+}
+
+// ActivateConnection selects and expands the connection with the given name, as
+// pressing Enter on it would
+func (m *Model) ActivateConnection(name string) {
+	for i := range m.connections {
+		m.connections[i].Selected = m.connections[i].Name == name
+		if m.connections[i].Selected {
+			m.connections[i].Expanded = true
+		}
+	}
+	m.adjustScrolling()
+}
+
+// HasConnection reports whether a connection with the given name exists
+func (m Model) HasConnection(name string) bool {
+	for _, conn := range m.connections {
+		if conn.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// connectionRow returns the tree row of the connection at connIdx, or 0 when
+// it is filtered out
+func (m Model) connectionRow(connIdx int) int {
+	for i, item := range m.getTreeItems() {
+		if item.ConnectionIndex == connIdx && item.Level == 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// SetTableRowCount sets the row count shown next to a table of a connection,
+// named by its QualifiedName; estimate marks a count from table statistics
+func (m *Model) SetTableRowCount(connectionName, tableName string, count int64, estimate bool) {
+	for i := range m.connections {
+		if m.connections[i].Name != connectionName {
+			continue
+		}
+		for j := range m.connections[i].Tables {
+			if m.connections[i].Tables[j].QualifiedName() == tableName {
+				m.connections[i].Tables[j].RowCount = count
+				m.connections[i].Tables[j].Estimate = estimate
+				return
+			}
+		}
+	}
+}
+
+// SetTableLocked marks a table of a connection, named by its QualifiedName, as
+// unreadable, showing a lock icon
+func (m *Model) SetTableLocked(connectionName, tableName string) {
+	for i := range m.connections {
+		if m.connections[i].Name != connectionName {
+			continue
+		}
+		for j := range m.connections[i].Tables {
+			if m.connections[i].Tables[j].QualifiedName() == tableName {
+				m.connections[i].Tables[j].Locked = true
+				return
+			}
+		}
+	}
+}
+
+// ToggleMark marks the table under the cursor, or unmarks it, and moves the
+// cursor to the next item so several tables can be marked in a row
+func (m *Model) ToggleMark() {
+	treeItems := m.getTreeItems()
+	if m.cursor < 0 || m.cursor >= len(treeItems) || treeItems[m.cursor].Level != 1 {
+		return
+	}
+	item := treeItems[m.cursor]
+	table := &m.connections[item.ConnectionIndex].Tables[item.TableIndex]
+	table.Selected = !table.Selected
+
+	if m.cursor < len(treeItems)-1 {
+		m.cursor++
+		m.adjustScrolling()
+		m.updateSelectedConnectionForCursor()
+	}
+}
+
+// MarkedTables returns the marked tables in sidebar order
+func (m Model) MarkedTables() []MarkedTable {
+	var marked []MarkedTable
+	for _, conn := range m.connections {
+		for _, table := range conn.Tables {
+			if table.Selected {
+				marked = append(marked, MarkedTable{ConnectionName: conn.Name, TableName: table.Name, Schema: table.Schema})
+			}
+		}
+	}
+	return marked
+}
+
+// ToggleQualifiedNames switches between showing tables as schema.table and by name alone
+func (m *Model) ToggleQualifiedNames() {
+	m.qualifiedNames = !m.qualifiedNames
+}
+
+// SetQualifiedNames sets whether tables are shown as schema.table
+func (m *Model) SetQualifiedNames(qualified bool) {
+	m.qualifiedNames = qualified
+}
+
+// QualifiedNames returns whether tables are shown as schema.table
+func (m Model) QualifiedNames() bool {
+	return m.qualifiedNames
+}
+
+// tableLabel returns the name a table is listed under
+func (m Model) tableLabel(table Table) string {
+	if m.qualifiedNames {
+		return table.QualifiedName()
+	}
+	return table.Name
+}
+
+// ClearMarks unmarks every table
+func (m *Model) ClearMarks() {
+	for i := range m.connections {
+		for j := range m.connections[i].Tables {
+			m.connections[i].Tables[j].Selected = false
+		}
+	}
+}
+
+// RefreshConnections reloads the connections from storage, keeping ephemeral ones
 func (m *Model) RefreshConnections() {
-	m.connections = getConnections()
+	connections := getConnections()
+	for _, conn := range m.connections {
+		if conn.Ephemeral {
+			connections = append(connections, conn)
+		}
+	}
+	m.connections = connections
 	treeItems := m.getTreeItems()
 	if m.cursor >= len(treeItems) {
 		m.cursor = max(0, len(treeItems)-1)
@@ -347,7 +562,7 @@ func (m Model) getTreeItems() []TreeItem {
 		// Check tables for matches
 		var matchingTableIndices []int
 		for tableIdx, table := range conn.Tables {
-			tableLower := strings.ToLower(table.Name)
+			tableLower := strings.ToLower(m.tableLabel(table))
 			if m.filterText == "" || strings.Contains(tableLower, filterLower) {
 				matchingTableIndices = append(matchingTableIndices, tableIdx)
 			}
@@ -457,6 +672,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				item := treeItems[m.cursor]
 				if item.Level == 0 {
 					conn := &m.connections[item.ConnectionIndex]
+					if conn.Connecting {
+						// Wait for the running attempt instead of starting another
+						return m, nil
+					}
 					conn.Expanded = !conn.Expanded
 
 					for i := range m.connections {
@@ -511,16 +730,69 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 						return TableSelectedMsg{
 							ConnectionName: conn.Name,
 							TableName:      table.Name,
+							Schema:         table.Schema,
 						}
 					}
 				}
 			}
+		case " ":
+			m.ToggleMark()
+		case "esc":
+			m.ClearMarks()
+		case ".":
+			m.ToggleQualifiedNames()
+		case "O":
+			// Open the table under the cursor in an additional tab
+			if m.cursor >= 0 && m.cursor < len(treeItems) && treeItems[m.cursor].Level == 1 {
+				item := treeItems[m.cursor]
+				conn := &m.connections[item.ConnectionIndex]
+				for i := range m.connections {
+					m.connections[i].Selected = false
+				}
+				conn.Selected = true
+
+				table := conn.Tables[item.TableIndex]
+				return m, func() tea.Msg {
+					return TableSelectedMsg{
+						ConnectionName: conn.Name,
+						TableName:      table.Name,
+						Schema:         table.Schema,
+						NewTab:         true,
+					}
+				}
+			}
+		}
+
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.scroll(-mouseWheelStep)
+		case tea.MouseButtonWheelDown:
+			m.scroll(mouseWheelStep)
 		}
 	}
 
 	return m, nil
 }
 
+// scroll moves the viewport by delta items, keeping the cursor on a visible item
+func (m *Model) scroll(delta int) {
+	treeItems := m.getTreeItems()
+	visibleCount := m.visibleItems()
+
+	maxOffset := max(0, len(treeItems)-visibleCount)
+	m.offset = max(0, min(maxOffset, m.offset+delta))
+	if m.cursor < m.offset {
+		m.cursor = m.offset
+	} else if m.cursor >= m.offset+visibleCount {
+		m.cursor = max(0, m.offset+visibleCount-1)
+	}
+	m.updateSelectedConnectionForCursor()
+}
+
 // View renders the sidebar
 func (m Model) View() string {
 	if m.width <= 0 || m.height <= 0 {
@@ -531,7 +803,7 @@ func (m Model) View() string {
 	t := theme.Current
 
 	// Inner content width (minus border)
-	innerWidth := m.width - 4
+	innerWidth := max(0, m.width-4)
 
 	var lines []string
 
@@ -586,7 +858,9 @@ func (m Model) View() string {
 			}
 
 			checkIcon := ""
-			if conn.Connected {
+			if conn.Connecting {
+				checkIcon = "… "
+			} else if conn.Connected {
 				checkIcon = "✓ "
 			}
 
@@ -618,9 +892,22 @@ func (m Model) View() string {
 			}
 
 			tableIcon := "󰓫"
+			if table.Locked {
+				tableIcon = ""
+			}
+			if table.Selected {
+				tableIcon = "●"
+			}
 
-			// Calculate row count suffix
-			rowCountSuffix := " (" + intToStr(int(table.RowCount)) + ")"
+			// Calculate row count suffix, left out until the count arrives
+			rowCountSuffix := ""
+			if table.RowCount >= 0 {
+				approx := ""
+				if table.Estimate {
+					approx = "~"
+				}
+				rowCountSuffix = " (" + approx + intToStr(int(table.RowCount)) + ")"
+			}
 
 			// Account for: prefix (4-5 chars) + space + icon + space + row count suffix
 			// Leave room for all parts
@@ -629,10 +916,12 @@ func (m Model) View() string {
 			suffixLen := lipgloss.Width(rowCountSuffix)
 			availableForName := innerWidth - prefixLen - 1 - iconLen - 1 - suffixLen
 
-			text = prefix + " " + tableIcon + " " + truncateString(table.Name, availableForName) + rowCountSuffix
+			text = prefix + " " + tableIcon + " " + truncateString(m.tableLabel(table), availableForName) + rowCountSuffix
 
 			if isSelected && m.focused {
 				style = t.SidebarSelected
+			} else if table.Selected {
+				style = t.SidebarActive
 			} else {
 				style = t.SidebarItem
 			}
@@ -648,8 +937,20 @@ func (m Model) View() string {
 	}
 
 	// Status bar
+	statusText := intToStr(m.cursor+1) + "/" + intToStr(len(treeItems))
+	if marked := len(m.MarkedTables()); marked > 0 {
+		statusText = intToStr(marked) + " marked  " + statusText
+	}
+	for _, conn := range m.connections {
+		if conn.Connecting {
+			// Shortened so the status stays on one line
+			connecting := truncateString("Connecting to "+conn.Name+"…", innerWidth-lipgloss.Width(statusText)-2)
+			statusText = connecting + "  " + statusText
+			break
+		}
+	}
 	status := t.StatusBar.Width(innerWidth).Align(lipgloss.Right).
-		Render(intToStr(m.cursor+1) + "/" + intToStr(len(treeItems)))
+		Render(statusText)
 	lines = append(lines, status)
 
 	// Join content
@@ -678,8 +979,12 @@ func getConnectionIcon(dbType string) string {
 		return "[P]"
 	case "sqlite":
 		return "[S]"
+	case "mssql":
+		return "[T]"
 	case "redis":
 		return "[R]"
+	case "oracle":
+		return "[O]"
 	case "mongodb":
 		return "[m]"
 	default:
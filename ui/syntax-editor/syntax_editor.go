@@ -45,6 +45,8 @@ type Model struct {
 	inVisualMode bool          // Whether in visual mode
 	visualStartX int           // Visual selection start X
 	visualStartY int           // Visual selection start Y
+	autoIndent   bool          // Whether new lines keep (and after keywords, deepen) the indentation
+	language     string        // Language being edited, "sql" or "json"; decides highlighting and indentation
 }
 
 // New creates a new syntax-highlighting text editor
@@ -78,6 +80,8 @@ func New() Model {
 		inVisualMode: false,
 		visualStartX: 0,
 		visualStartY: 0,
+		autoIndent:   true,
+		language:     "sql",
 	}
 }
 
@@ -137,6 +141,13 @@ func (m *Model) SetLexer(lexer chroma.Lexer) {
 	m.lexer = lexer
 }
 
+// SetLanguage switches the editor between "sql" and "json", changing the lexer
+// and what deepens the indentation of a new line
+func (m *Model) SetLanguage(language string) {
+	m.language = language
+	m.lexer = lexers.Get(language)
+}
+
 // SetStyle sets the highlighting style
 func (m *Model) SetStyle(style *chroma.Style) {
 	m.style = style
@@ -157,6 +168,31 @@ func (m *Model) SetCursorStyle(style CursorStyle) {
 	m.cursorStyle = style
 }
 
+// SetAutoIndent sets whether new lines are indented automatically
+func (m *Model) SetAutoIndent(enabled bool) {
+	m.autoIndent = enabled
+}
+
+// deepensIndent returns whether a new line after text is indented one level
+// deeper: after SQL clause keywords, ( and , in SQL, and after { and [ in JSON
+func (m Model) deepensIndent(text string) bool {
+	trimmed := strings.TrimSpace(strings.ToUpper(text))
+	if m.language == "json" {
+		return strings.HasSuffix(trimmed, "{") || strings.HasSuffix(trimmed, "[")
+	}
+	return strings.HasSuffix(trimmed, "SELECT") ||
+		strings.HasSuffix(trimmed, "FROM") ||
+		strings.HasSuffix(trimmed, "WHERE") ||
+		strings.HasSuffix(trimmed, "AND") ||
+		strings.HasSuffix(trimmed, "OR") ||
+		strings.HasSuffix(trimmed, "JOIN") ||
+		strings.HasSuffix(trimmed, "ON") ||
+		strings.HasSuffix(trimmed, "SET") ||
+		strings.HasSuffix(trimmed, "VALUES") ||
+		strings.HasSuffix(trimmed, "(") ||
+		strings.HasSuffix(trimmed, ",")
+}
+
 // SetCharLimit sets the character limit
 func (m *Model) SetCharLimit(limit int) {
 	m.charLimit = limit
@@ -492,22 +528,15 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 
 			// Check if we should add extra indentation (after certain SQL keywords)
-			trimmedBefore := strings.TrimSpace(strings.ToUpper(before))
 			extraIndent := ""
-			if strings.HasSuffix(trimmedBefore, "SELECT") ||
-				strings.HasSuffix(trimmedBefore, "FROM") ||
-				strings.HasSuffix(trimmedBefore, "WHERE") ||
-				strings.HasSuffix(trimmedBefore, "AND") ||
-				strings.HasSuffix(trimmedBefore, "OR") ||
-				strings.HasSuffix(trimmedBefore, "JOIN") ||
-				strings.HasSuffix(trimmedBefore, "ON") ||
-				strings.HasSuffix(trimmedBefore, "SET") ||
-				strings.HasSuffix(trimmedBefore, "VALUES") ||
-				strings.HasSuffix(trimmedBefore, "(") ||
-				strings.HasSuffix(trimmedBefore, ",") {
+			if m.deepensIndent(before) {
 				extraIndent = "  "
 			}
 
+			if !m.autoIndent {
+				indent, extraIndent = "", ""
+			}
+
 			// Insert new line with indentation
 			m.content = append(m.content[:m.cursorY+1], m.content[m.cursorY:]...)
 			m.content[m.cursorY] = before
@@ -605,6 +634,34 @@ func (m Model) CursorX() int {
 	return m.cursorX
 }
 
+// LineCount returns the number of lines of text
+func (m Model) LineCount() int {
+	return len(m.content)
+}
+
+// InsertText inserts text, which may span several lines, at the cursor and
+// moves the cursor to the end of it
+func (m *Model) InsertText(text string) {
+	line := m.content[m.cursorY]
+	before, after := line[:m.cursorX], line[m.cursorX:]
+
+	inserted := strings.Split(text, "\n")
+	last := len(inserted) - 1
+	cursorX := len(inserted[last])
+	if last == 0 {
+		cursorX += len(before)
+	}
+	inserted[0] = before + inserted[0]
+	inserted[last] += after
+
+	content := append([]string{}, m.content[:m.cursorY]...)
+	content = append(content, inserted...)
+	m.content = append(content, m.content[m.cursorY+1:]...)
+	m.cursorY += last
+	m.cursorX = cursorX
+	m.adjustScroll()
+}
+
 // SetVisualMode sets whether the editor is in visual mode
 func (m *Model) SetVisualMode(visual bool) {
 	m.inVisualMode = visual
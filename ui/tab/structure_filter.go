@@ -0,0 +1,157 @@
+package tab
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sheenazien8/sq/drivers"
+	"github.com/sheenazien8/sq/ui/table"
+)
+
+// newStructureFilterInput creates the input used to filter structure sections
+func newStructureFilterInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "Filter section"
+	ti.CharLimit = 100
+	return ti
+}
+
+// Filtering reports whether the section filter input has focus
+func (sv StructureView) Filtering() bool {
+	return sv.filtering
+}
+
+// SectionFilter returns the filter text of a section, empty when unfiltered
+func (sv StructureView) SectionFilter(section StructureSection) string {
+	return sv.filters[section]
+}
+
+// startFilter focuses the filter input for the active section
+func (sv *StructureView) startFilter() {
+	sv.filtering = true
+	sv.filterInput.SetValue(sv.filters[sv.ActiveSection])
+	sv.filterInput.CursorEnd()
+	sv.filterInput.Focus()
+	sv.rebuildSection(sv.ActiveSection)
+}
+
+// updateFilter handles a key while the filter input has focus. Enter keeps the
+// filter, Esc clears it; every other key narrows the section as you type.
+func (sv StructureView) updateFilter(msg tea.KeyMsg) (StructureView, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		sv.stopFilter()
+		return sv, nil
+	case "esc":
+		sv.clearFilter()
+		return sv, nil
+	}
+
+	var cmd tea.Cmd
+	sv.filterInput, cmd = sv.filterInput.Update(msg)
+	sv.setFilter(sv.ActiveSection, sv.filterInput.Value())
+	return sv, cmd
+}
+
+// stopFilter blurs the filter input, keeping the active section filtered
+func (sv *StructureView) stopFilter() {
+	sv.filtering = false
+	sv.filterInput.Blur()
+	sv.rebuildSection(sv.ActiveSection)
+}
+
+// clearFilter removes the active section's filter
+func (sv *StructureView) clearFilter() {
+	sv.filterInput.SetValue("")
+	sv.setFilter(sv.ActiveSection, "")
+	sv.stopFilter()
+}
+
+// setFilter sets the filter text of a section and rebuilds its table
+func (sv *StructureView) setFilter(section StructureSection, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		delete(sv.filters, section)
+	} else {
+		sv.filters[section] = text
+	}
+	sv.rebuildSection(section)
+}
+
+// filterLineVisible reports whether the filter line is shown above a section table
+func (sv StructureView) filterLineVisible(section StructureSection) bool {
+	return sv.filters[section] != "" || (sv.filtering && section == sv.ActiveSection)
+}
+
+// sectionHeight returns the table height of a section, leaving room for the filter line
+func (sv StructureView) sectionHeight(section StructureSection) int {
+	height := sv.Height - 4 // Reserve space for section tabs
+	if sv.filterLineVisible(section) {
+		height--
+	}
+	return height
+}
+
+// rebuildSection recreates a section table from the rows matching its filter
+func (sv *StructureView) rebuildSection(section StructureSection) {
+	query := strings.ToLower(sv.filters[section])
+
+	var tbl table.Model
+	var matches int
+	switch section {
+	case SectionColumns:
+		columns := filterStructure(sv.Structure.Columns, query, func(c drivers.ColumnInfo) []string {
+			return []string{c.Name, c.DataType}
+		})
+		tbl, matches = sv.createColumnsTable(columns), len(columns)
+	case SectionIndexes:
+		indexes := filterStructure(sv.Structure.Indexes, query, func(idx drivers.IndexInfo) []string {
+			return append([]string{idx.Name}, idx.Columns...)
+		})
+		tbl, matches = sv.createIndexesTable(indexes), len(indexes)
+	case SectionRelations:
+		// Find the foreign key of a column, or every key pointing at a table
+		relations := filterStructure(sv.Structure.Relations, query, func(rel drivers.RelationInfo) []string {
+			return []string{rel.Column, rel.ReferencedTable}
+		})
+		tbl, matches = sv.createRelationsTable(relations), len(relations)
+	case SectionTriggers:
+		triggers := filterStructure(sv.Structure.Triggers, query, func(trig drivers.TriggerInfo) []string {
+			return []string{trig.Name, trig.Event}
+		})
+		tbl, matches = sv.createTriggersTable(triggers), len(triggers)
+	case SectionDependencies:
+		deps := filterStructure(sv.Structure.Dependencies, query, func(dep drivers.DependencyInfo) []string {
+			return []string{dep.Name, dep.Table}
+		})
+		tbl, matches = sv.createDependenciesTable(deps), len(deps)
+	default:
+		return
+	}
+
+	tbl.SetSize(sv.Width, sv.sectionHeight(section))
+	tbl.SetFocused(sv.Focused && section == sv.ActiveSection && !sv.filtering)
+	sv.SectionTables[section] = tbl
+	sv.matches[section] = matches
+}
+
+// filterStructure returns the items with a field containing query, matched
+// case-insensitively. An empty query keeps every item.
+func filterStructure[T any](items []T, query string, fields func(T) []string) []T {
+	if query == "" {
+		return items
+	}
+
+	var matched []T
+	for _, item := range items {
+		for _, field := range fields(item) {
+			if strings.Contains(strings.ToLower(field), query) {
+				matched = append(matched, item)
+				break
+			}
+		}
+	}
+	return matched
+}
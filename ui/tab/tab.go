@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sheenazien8/sq/config"
 	"github.com/sheenazien8/sq/drivers"
 	"github.com/sheenazien8/sq/logger"
 	"github.com/sheenazien8/sq/ui/filter"
@@ -27,6 +29,8 @@ type Tab struct {
 	ColumnNames  []string       // Column names for filtering
 	ActiveFilter *filter.Filter // Single active filter for this tab
 	FilterUI     filter.Model   // Filter UI component for table tabs
+	Editable     bool           // False for views and other read-only table tabs
+	Copy         int            // Number shown after the name of extra tabs of the same table, 0 for the first
 }
 
 // TabType represents the type of content in a tab
@@ -38,6 +42,9 @@ const (
 	TabTypeQuery
 )
 
+// tabBarHeight is the number of lines taken by the tab bar above the tab content
+const tabBarHeight = 1
+
 // GenerateTableTabID creates a unique ID for a table tab
 // Format: connection.table or connection.table.filter_hash if filter is present
 func GenerateTableTabID(connectionName, tableName string, filter *filter.Filter) string {
@@ -69,6 +76,11 @@ type TabSwitchedMsg struct {
 	TabName  string
 }
 
+// DependencySelectedMsg is sent when a dependent object is opened from the structure view
+type DependencySelectedMsg struct {
+	TableName string
+}
+
 // FilterAppliedMsg is sent when a filter is applied in a table tab
 type FilterAppliedMsg struct {
 	TabIndex int
@@ -82,6 +94,7 @@ const (
 	SectionIndexes
 	SectionRelations
 	SectionTriggers
+	SectionDependencies
 )
 
 // StructureView holds the table structure data and navigation state
@@ -93,6 +106,12 @@ type StructureView struct {
 	Height         int
 	Focused        bool
 	AutoFitColumns bool
+
+	// Per-section filtering, started with /
+	filterInput textinput.Model
+	filtering   bool                        // The filter input has focus
+	filters     map[StructureSection]string // Filter text of each filtered section
+	matches     map[StructureSection]int    // Rows shown by each section table
 }
 
 // NewStructureView creates a new structure view from table structure data
@@ -104,6 +123,9 @@ func NewStructureView(structure *drivers.TableStructure, width, height int) Stru
 		Width:         width,
 		Height:        height,
 		Focused:       false,
+		filterInput:   newStructureFilterInput(),
+		filters:       make(map[StructureSection]string),
+		matches:       make(map[StructureSection]int),
 	}
 
 	// Create table for columns
@@ -126,6 +148,11 @@ func NewStructureView(structure *drivers.TableStructure, width, height int) Stru
 	triggersTable.SetSize(width, height-4)
 	sv.SectionTables[SectionTriggers] = triggersTable
 
+	// Create table for dependent objects
+	dependenciesTable := sv.createDependenciesTable(structure.Dependencies)
+	dependenciesTable.SetSize(width, height-4)
+	sv.SectionTables[SectionDependencies] = dependenciesTable
+
 	return sv
 }
 
@@ -247,11 +274,55 @@ func (sv *StructureView) createTriggersTable(triggers []drivers.TriggerInfo) tab
 	return table.New(cols, rows)
 }
 
+func (sv *StructureView) createDependenciesTable(deps []drivers.DependencyInfo) table.Model {
+	cols := []table.Column{
+		{Title: "Name", Width: 25},
+		{Title: "Type", Width: 12},
+		{Title: "Table", Width: 20},
+		{Title: "Detail", Width: 30},
+	}
+
+	var rows []table.Row
+	for _, dep := range deps {
+		rows = append(rows, table.Row{
+			dep.Name,
+			dep.Type,
+			dep.Table,
+			dep.Detail,
+		})
+	}
+
+	return table.New(cols, rows)
+}
+
+// selectedDependencyCmd returns a command that opens the selected dependent object.
+// Triggers live on the table itself, so only views and foreign keys can be opened.
+func (sv StructureView) selectedDependencyCmd() tea.Cmd {
+	tbl, ok := sv.SectionTables[SectionDependencies]
+	if !ok {
+		return nil
+	}
+
+	row := tbl.SelectedRow()
+	if len(row) < 3 {
+		return nil
+	}
+
+	if row[1] != drivers.DependencyTypeView && row[1] != drivers.DependencyTypeForeignKey {
+		return nil
+	}
+
+	tableName := row[2]
+	return func() tea.Msg {
+		return DependencySelectedMsg{TableName: tableName}
+	}
+}
+
 func (sv *StructureView) SetSize(width, height int) {
 	sv.Width = width
 	sv.Height = height
 	for section, tbl := range sv.SectionTables {
-		tbl.SetSize(width, height-4)
+		tbl.SetSize(width, sv.sectionHeight(section))
 		sv.SectionTables[section] = tbl
 	}
 }
@@ -259,7 +330,7 @@ func (sv *StructureView) SetSize(width, height int) {
 func (sv *StructureView) SetFocused(focused bool) {
 	sv.Focused = focused
 	if tbl, ok := sv.SectionTables[sv.ActiveSection]; ok {
-		tbl.SetFocused(focused)
+		tbl.SetFocused(focused && !sv.filtering)
 		sv.SectionTables[sv.ActiveSection] = tbl
 	}
 }
@@ -271,7 +342,7 @@ func (sv *StructureView) NextSection() {
 		sv.SectionTables[sv.ActiveSection] = tbl
 	}
 
-	sv.ActiveSection = (sv.ActiveSection + 1) % 4
+	sv.ActiveSection = (sv.ActiveSection + 1) % (SectionDependencies + 1)
 
 	// Focus new section table
 	if tbl, ok := sv.SectionTables[sv.ActiveSection]; ok {
@@ -288,7 +359,7 @@ func (sv *StructureView) PrevSection() {
 	}
 
 	if sv.ActiveSection == 0 {
-		sv.ActiveSection = SectionTriggers
+		sv.ActiveSection = SectionDependencies
 	} else {
 		sv.ActiveSection--
 	}
@@ -303,7 +374,18 @@ func (sv *StructureView) PrevSection() {
 func (sv StructureView) Update(msg tea.Msg) (StructureView, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if sv.filtering {
+			return sv.updateFilter(msg)
+		}
+
 		switch msg.String() {
+		case "/", "f":
+			sv.startFilter()
+			return sv, textinput.Blink
+		case "esc":
+			if sv.filters[sv.ActiveSection] != "" {
+				sv.clearFilter()
+			}
 		case "1":
 			sv.switchToSection(SectionColumns)
 		case "2":
@@ -312,6 +394,12 @@ func (sv StructureView) Update(msg tea.Msg) (StructureView, tea.Cmd) {
 			sv.switchToSection(SectionRelations)
 		case "4":
 			sv.switchToSection(SectionTriggers)
+		case "5":
+			sv.switchToSection(SectionDependencies)
+		case "enter":
+			if sv.ActiveSection == SectionDependencies {
+				return sv, sv.selectedDependencyCmd()
+			}
 		case "tab":
 			sv.NextSection()
 		case "shift+tab":
@@ -358,12 +446,16 @@ func (sv StructureView) View() string {
 		{"2:Indexes", SectionIndexes, len(sv.Structure.Indexes)},
 		{"3:Relations", SectionRelations, len(sv.Structure.Relations)},
 		{"4:Triggers", SectionTriggers, len(sv.Structure.Triggers)},
+		{"5:Dependencies", SectionDependencies, len(sv.Structure.Dependencies)},
 	}
 
 	var tabItems []string
 	for _, sec := range sections {
 		var tabStyle lipgloss.Style
 		label := sec.name + " (" + intToStr(sec.count) + ")"
+		if sv.filters[sec.section] != "" {
+			label = sec.name + " (" + intToStr(sv.matches[sec.section]) + "/" + intToStr(sec.count) + ")"
+		}
 		if sec.section == sv.ActiveSection {
 			tabStyle = t.TableHeader.Copy().
 				Background(t.Colors.Primary).
@@ -385,6 +477,16 @@ func (sv StructureView) View() string {
 		content = tbl.View()
 	}
 
+	if sv.filterLineVisible(sv.ActiveSection) {
+		filterLine := sv.filterInput.View()
+		if !sv.filtering {
+			filterLine = lipgloss.NewStyle().
+				Foreground(t.Colors.ForegroundDim).
+				Render("/" + sv.filters[sv.ActiveSection] + "  (/: Edit, Esc: Clear)")
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, sectionBar, filterLine, content)
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Left, sectionBar, content)
 }
 
@@ -424,6 +526,16 @@ type Model struct {
 	height         int
 	focused        bool
 	autoFitColumns bool // Whether to auto-fit column widths
+
+	// Settings applied to new query editors
+	editorAutoIndent bool
+	editorFormat     config.FormatOptions
+
+	// How table tabs render dates and numbers
+	tableFormat table.DisplayFormat
+
+	// Query tabs opened so far, numbering their IDs so a closed tab's ID is never reused
+	queryTabCount int
 }
 
 // New creates a new tab model
@@ -433,6 +545,32 @@ func New() Model {
 		activeTab:      -1,
 		focused:        false,
 		autoFitColumns: true, // Default to true
+
+		editorAutoIndent: true,
+		editorFormat:     config.DefaultConfig().FormatOptions(),
+	}
+}
+
+// SetQueryEditorOptions sets the auto-indent and formatter settings for query editors
+func (m *Model) SetQueryEditorOptions(autoIndent bool, format config.FormatOptions) {
+	m.editorAutoIndent = autoIndent
+	m.editorFormat = format
+	for i := range m.tabs {
+		if qe, ok := m.tabs[i].Content.(queryeditor.Model); ok {
+			qe.SetEditorOptions(autoIndent, format)
+			m.tabs[i].Content = qe
+		}
+	}
+}
+
+// SetTableDisplayFormat sets how table tabs render date/time and numeric columns
+func (m *Model) SetTableDisplayFormat(format table.DisplayFormat) {
+	m.tableFormat = format
+	for i := range m.tabs {
+		if t, ok := m.tabs[i].Content.(table.Model); ok && m.tabs[i].Type == TabTypeTable {
+			t.SetDisplayFormat(format)
+			m.tabs[i].Content = t
+		}
 	}
 }
 
@@ -460,7 +598,7 @@ func (m *Model) SetSize(width, height int) {
 		case TabTypeTable:
 			if table, ok := m.tabs[i].Content.(table.Model); ok {
 				// For table tabs: tab bar (1) + filter (3) + table = total height
-				table.SetSize(width, height-1-3)
+				table.SetSize(width, max(0, height-1-3))
 				m.tabs[i].Content = table
 			}
 			// Set filter width for table tabs
@@ -472,7 +610,7 @@ func (m *Model) SetSize(width, height int) {
 			}
 		case TabTypeQuery:
 			if qe, ok := m.tabs[i].Content.(queryeditor.Model); ok {
-				qe.SetSize(width, height-1)
+				qe.SetSize(width, max(0, height-1))
 				m.tabs[i].Content = qe
 			}
 		}
@@ -524,6 +662,15 @@ func (m *Model) FocusFilter() {
 	}
 }
 
+// IsStructureFilterFocused reports whether the active structure tab is typing a section filter
+func (m Model) IsStructureFilterFocused() bool {
+	if m.activeTab < 0 || m.activeTab >= len(m.tabs) || m.tabs[m.activeTab].Type != TabTypeStructure {
+		return false
+	}
+	sv, ok := m.tabs[m.activeTab].Content.(StructureView)
+	return ok && sv.Filtering()
+}
+
 // BlurFilter blurs the filter input for the active table tab
 func (m *Model) BlurFilter() {
 	if m.activeTab >= 0 && m.activeTab < len(m.tabs) && m.tabs[m.activeTab].Type == TabTypeTable {
@@ -548,6 +695,16 @@ func (m *Model) SetActiveTabPagination(currentPage, totalPages, totalRows, pageS
 	}
 }
 
+// SetActiveTabTotalApprox marks the active table tab's row total as an estimate
+func (m *Model) SetActiveTabTotalApprox(approx bool) {
+	if m.activeTab >= 0 && m.activeTab < len(m.tabs) {
+		if tbl, ok := m.tabs[m.activeTab].Content.(table.Model); ok {
+			tbl.SetTotalApprox(approx)
+			m.tabs[m.activeTab].Content = tbl
+		}
+	}
+}
+
 // Focused returns whether the tabs are focused
 func (m Model) Focused() bool {
 	return m.focused
@@ -605,6 +762,36 @@ func (m *Model) UpdateActiveTabContent(content interface{}) {
 	}
 }
 
+// GetTab returns the tab at the given index, or nil if out of range
+func (m Model) GetTab(index int) *Tab {
+	if index >= 0 && index < len(m.tabs) {
+		return &m.tabs[index]
+	}
+	return nil
+}
+
+// UpdateTabContent updates the content of the tab at the given index
+func (m *Model) UpdateTabContent(index int, content interface{}) {
+	if index >= 0 && index < len(m.tabs) {
+		m.tabs[index].Content = content
+	}
+}
+
+// SetActiveTabEditable marks the active table tab as editable or read-only
+func (m *Model) SetActiveTabEditable(editable bool) {
+	if m.activeTab >= 0 && m.activeTab < len(m.tabs) && m.tabs[m.activeTab].Type == TabTypeTable {
+		m.tabs[m.activeTab].Editable = editable
+	}
+}
+
+// IsActiveTabEditable returns whether row actions are allowed on the active tab
+func (m Model) IsActiveTabEditable() bool {
+	if m.activeTab >= 0 && m.activeTab < len(m.tabs) {
+		return m.tabs[m.activeTab].Type == TabTypeTable && m.tabs[m.activeTab].Editable
+	}
+	return false
+}
+
 // GetActiveTabData returns the original data and columns for the active tab
 func (m Model) GetActiveTabData() ([]table.Row, []table.Column, []string) {
 	if m.activeTab >= 0 && m.activeTab < len(m.tabs) {
@@ -638,7 +825,7 @@ func (m *Model) SetActiveTabFilter(f *filter.Filter) {
 func (m *Model) AddActiveTabFilter(f filter.Filter) {
 	if m.activeTab >= 0 && m.activeTab < len(m.tabs) {
 		m.tabs[m.activeTab].ActiveFilter = &f
-		m.tabs[m.activeTab].FilterUI.SetActive(true)
+		m.tabs[m.activeTab].FilterUI.SetFilter(&f)
 	}
 }
 
@@ -682,10 +869,37 @@ func (m *Model) AddTableTab(name string, columns []table.Column, rows []table.Ro
 		return false
 	}
 
+	m.addTab(m.newTableTab(tabID, name, columns, rows))
+	return true
+}
+
+// AddTableTabForced always opens a new tab, even if the table is already open.
+// Extra tabs of a table get their own ID and are shown as "name (2)", "name (3)", ...
+func (m *Model) AddTableTabForced(name string, columns []table.Column, rows []table.Row) {
+	tabID := name
+	copyNum := 0
+	for _, t := range m.tabs {
+		if t.Name == name && t.Type == TabTypeTable {
+			copyNum = max(copyNum, t.Copy, 1)
+		}
+	}
+	if copyNum > 0 {
+		copyNum++
+		tabID = fmt.Sprintf("%s#%d", name, copyNum)
+	}
+
+	newTab := m.newTableTab(tabID, name, columns, rows)
+	newTab.Copy = copyNum
+	m.addTab(newTab)
+}
+
+// newTableTab builds a table tab with its own table model and filter UI
+func (m *Model) newTableTab(tabID, name string, columns []table.Column, rows []table.Row) Tab {
 	newTable := table.New(columns, rows)
 	newTable.SetSize(m.width, m.height-3)
 	newTable.SetFocused(m.focused)
 	newTable.SetAutoFit(m.autoFitColumns) // Apply auto-fit setting from config
+	newTable.SetDisplayFormat(m.tableFormat)
 	logger.Info("Creating new table tab", map[string]any{
 		"name": name,
 		"type": TabTypeTable,
@@ -700,7 +914,7 @@ func (m *Model) AddTableTab(name string, columns []table.Column, rows []table.Ro
 	// Initialize filter UI for table tabs
 	filterUI := filter.New(columnNames)
 
-	newTab := Tab{
+	return Tab{
 		ID:          tabID,
 		Name:        name,
 		Content:     newTable,
@@ -710,10 +924,8 @@ func (m *Model) AddTableTab(name string, columns []table.Column, rows []table.Ro
 		Columns:     columns,
 		ColumnNames: columnNames,
 		FilterUI:    filterUI,
+		Editable:    true,
 	}
-
-	m.addTab(newTab)
-	return true
 }
 
 // addTab is a helper to add a tab and manage active state
@@ -747,11 +959,12 @@ func (m *Model) addTab(newTab Tab) {
 // Returns true if a new tab was created, false if switched to existing tab
 func (m *Model) AddStructureTab(name string, structure *drivers.TableStructure) bool {
 	logger.Debug("AddStructureTab called", map[string]any{
-		"name":      name,
-		"columns":   len(structure.Columns),
-		"indexes":   len(structure.Indexes),
-		"relations": len(structure.Relations),
-		"triggers":  len(structure.Triggers),
+		"name":         name,
+		"columns":      len(structure.Columns),
+		"indexes":      len(structure.Indexes),
+		"relations":    len(structure.Relations),
+		"triggers":     len(structure.Triggers),
+		"dependencies": len(structure.Dependencies),
 	})
 
 	// Generate structure tab ID
@@ -794,11 +1007,13 @@ func (m *Model) AddQueryTab(name, connectionName, databaseName string) bool {
 
 	// Generate unique query tab ID with timestamp/counter to ensure uniqueness
 	// Each query tab should be independent, so we don't reuse tabs
-	tabID := fmt.Sprintf("%s.%s[Q]-%d", connectionName, databaseName, len(m.tabs))
+	tabID := fmt.Sprintf("%s.%s[Q]-%d", connectionName, databaseName, m.queryTabCount)
+	m.queryTabCount++
 
 	qe := queryeditor.New(connectionName, databaseName)
 	qe.SetSize(m.width, m.height-3)
 	qe.SetFocused(m.focused)
+	qe.SetEditorOptions(m.editorAutoIndent, m.editorFormat)
 
 	newTab := Tab{
 		ID:      tabID,
@@ -840,6 +1055,18 @@ func (m *Model) SetQueryResults(columns []table.Column, rows []table.Row) {
 	}
 }
 
+// SetQueryResultSets sets the result sets of a multi-statement execution on the active query editor tab
+func (m *Model) SetQueryResultSets(sets []queryeditor.ResultSet, summary string) {
+	if m.activeTab >= 0 && m.activeTab < len(m.tabs) {
+		if m.tabs[m.activeTab].Type == TabTypeQuery {
+			if qe, ok := m.tabs[m.activeTab].Content.(queryeditor.Model); ok {
+				qe.SetResultSets(sets, summary)
+				m.tabs[m.activeTab].Content = qe
+			}
+		}
+	}
+}
+
 // SetQueryError sets an error on the active query editor tab
 func (m *Model) SetQueryError(err string) {
 	if m.activeTab >= 0 && m.activeTab < len(m.tabs) {
@@ -852,6 +1079,18 @@ func (m *Model) SetQueryError(err string) {
 	}
 }
 
+// SetActiveQuery replaces the text of the active query editor tab
+func (m *Model) SetActiveQuery(query string) {
+	if m.activeTab >= 0 && m.activeTab < len(m.tabs) {
+		if m.tabs[m.activeTab].Type == TabTypeQuery {
+			if qe, ok := m.tabs[m.activeTab].Content.(queryeditor.Model); ok {
+				qe.SetQuery(query)
+				m.tabs[m.activeTab].Content = qe
+			}
+		}
+	}
+}
+
 // SwitchTab switches to the tab at the given index
 func (m *Model) SwitchTab(index int) {
 	if index < 0 || index >= len(m.tabs) {
@@ -1048,6 +1287,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				}
 			}
 		}
+
+	case tea.MouseMsg:
+		// Coordinates are relative to the top-left corner of the tab bar
+		if m.activeTab < 0 || m.activeTab >= len(m.tabs) || m.tabs[m.activeTab].Type != TabTypeTable {
+			return m, nil
+		}
+		if tbl, ok := m.tabs[m.activeTab].Content.(table.Model); ok {
+			// The table sits below the tab bar and the filter bar
+			msg.Y -= tabBarHeight + lipgloss.Height(m.tabs[m.activeTab].FilterUI.View())
+			var cmd tea.Cmd
+			tbl, cmd = tbl.Update(msg)
+			m.tabs[m.activeTab].Content = tbl
+			return m, cmd
+		}
 	}
 
 	return m, nil
@@ -1093,6 +1346,11 @@ func (m Model) View() string {
 		name := tab.Name
 		// Add icon based on tab type
 		switch tab.Type {
+		case TabTypeTable:
+			if !tab.Editable {
+				name = "[V] " + name
+			}
+
 		case TabTypeStructure:
 			name = "[S] " + name
 		case TabTypeQuery:
@@ -1101,6 +1359,10 @@ func (m Model) View() string {
 		if len(name) > 18 {
 			name = name[:15] + "..."
 		}
+		// Keep the copy number of extra tabs of a table visible after truncation
+		if tab.Copy > 0 {
+			name += " (" + intToStr(tab.Copy) + ")"
+		}
 
 		closeBtn := " ✕"
 		if tab.Active {
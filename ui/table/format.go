@@ -0,0 +1,96 @@
+package table
+
+import (
+	"strings"
+	"time"
+)
+
+// DisplayFormat controls how typed values are rendered. Only the display changes;
+// row values stay as loaded, so editing and copying see the raw data.
+type DisplayFormat struct {
+	DateLayout         string // Go time layout for date/time columns, "" to show values as stored
+	ThousandsSeparator string // Separator grouping the digits of numeric columns, "" for none
+}
+
+// storedDateLayouts are the layouts drivers return date and time values in
+var storedDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// numericTypes are the base names of numeric column types across the supported databases
+var numericTypes = map[string]bool{
+	"tinyint": true, "smallint": true, "mediumint": true, "int": true, "integer": true, "bigint": true,
+	"decimal": true, "numeric": true, "float": true, "double": true, "real": true,
+	"serial": true, "smallserial": true, "bigserial": true,
+}
+
+// baseType returns the lower-cased type name without size, precision or modifiers,
+// e.g. "decimal" for "DECIMAL(10,2) UNSIGNED" and "int" for "int4"
+func baseType(dataType string) string {
+	fields := strings.FieldsFunc(strings.ToLower(dataType), func(r rune) bool {
+		return r == '(' || r == ' '
+	})
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimRight(fields[0], "0123456789")
+}
+
+// isDateType reports whether a column type holds dates or timestamps
+func isDateType(dataType string) bool {
+	base := baseType(dataType)
+	return base == "date" || base == "datetime" || base == "timestamp" || base == "timestamptz"
+}
+
+// SetDisplayFormat sets how date/time and numeric columns are rendered
+func (m *Model) SetDisplayFormat(format DisplayFormat) {
+	m.displayFormat = format
+}
+
+// displayValue returns a cell value as shown in the table
+func (m Model) displayValue(colIdx int, value string) string {
+	if value == "" || value == "NULL" || colIdx < 0 || colIdx >= len(m.columns) {
+		return value
+	}
+
+	dataType := m.columns[colIdx].Type
+	switch {
+	case m.displayFormat.DateLayout != "" && isDateType(dataType):
+		for _, layout := range storedDateLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t.Format(m.displayFormat.DateLayout)
+			}
+		}
+	case m.displayFormat.ThousandsSeparator != "" && numericTypes[baseType(dataType)]:
+		return groupThousands(value, m.displayFormat.ThousandsSeparator)
+	}
+	return value
+}
+
+// groupThousands inserts sep between groups of three digits of the integer part of a number.
+// Values that are not plain decimal numbers are returned unchanged.
+func groupThousands(value, sep string) string {
+	sign := ""
+	digits := value
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+	intPart, fraction, hasFraction := strings.Cut(digits, ".")
+	if intPart == "" || strings.Trim(intPart, "0123456789") != "" ||
+		strings.Trim(fraction, "0123456789") != "" {
+		return value
+	}
+
+	for i := len(intPart) - 3; i > 0; i -= 3 {
+		intPart = intPart[:i] + sep + intPart[i:]
+	}
+	if hasFraction {
+		return sign + intPart + "." + fraction
+	}
+	return sign + intPart
+}
@@ -14,6 +14,9 @@ type NextPageMsg struct{}
 // PrevPageMsg is sent when user wants to fetch the previous page of results
 type PrevPageMsg struct{}
 
+// mouseWheelStep is the number of rows a mouse wheel notch scrolls
+const mouseWheelStep = 3
+
 // SortMsg is sent when user wants to sort by a column
 type SortMsg struct {
 	ColumnIdx int
@@ -22,7 +25,11 @@ type SortMsg struct {
 // Column represents a table column with title and width
 type Column struct {
 	Title string
-	Width int // Default/max width
+	Width int    // Default/max width
+	Type  string // Database data type, when known; used to format dates and numbers
+
+	// Primary key information
+	IsPrimaryKey bool
 
 	// Foreign key information
 	IsForeignKey     bool
@@ -66,11 +73,21 @@ type Model struct {
 	currentPage int
 	totalPages  int
 	totalRows   int
+	totalApprox bool // totalRows is an estimate from table statistics
 	pageSize    int
 
 	// Column auto-fit state
 	allColumnsAutoFit bool // Global toggle for all columns
 
+	// Whether headers show how many NULL and empty values each column has on this page
+	showNullCounts bool
+
+	// Dense mode drops the space around each cell to fit more columns
+	dense bool
+
+	// How date/time and numeric values are rendered
+	displayFormat DisplayFormat
+
 	// Sort state
 	sortColumnIdx int
 	sortDirection SortDirection
@@ -78,6 +95,15 @@ type Model struct {
 	// Column visibility state
 	// visibleColumnIndices maps display index to actual column index
 	visibleColumnIndices []int
+
+	// Rows marked with m, by index into rows; cleared when the rows change
+	marked map[int]bool
+
+	// Visual mode (V) marks the rows between visualAnchor and the cursor, on
+	// top of the rows marked before it started (visualBase)
+	visual       bool
+	visualAnchor int
+	visualBase   map[int]bool
 }
 
 // New creates a new table model
@@ -121,6 +147,12 @@ func (m *Model) SetPagination(currentPage, totalPages, totalRows, pageSize int)
 	m.totalPages = totalPages
 	m.totalRows = totalRows
 	m.pageSize = pageSize
+	m.totalApprox = false
+}
+
+// SetTotalApprox marks the pagination total as an estimate, shown as ~N
+func (m *Model) SetTotalApprox(approx bool) {
+	m.totalApprox = approx
 }
 
 // GetCurrentPage returns the current page number
@@ -128,6 +160,11 @@ func (m Model) GetCurrentPage() int {
 	return m.currentPage
 }
 
+// GetPageSize returns the number of rows per page
+func (m Model) GetPageSize() int {
+	return m.pageSize
+}
+
 // GetTotalPages returns the total number of pages
 func (m Model) GetTotalPages() int {
 	return m.totalPages
@@ -202,6 +239,8 @@ func (m Model) GetSelectedColumnOriginalIndex() int {
 // SetRows updates the table rows
 func (m *Model) SetRows(rows []Row) {
 	m.rows = rows
+	m.marked = nil
+	m.visual = false
 	if m.cursorRow >= len(rows) {
 		m.cursorRow = max(0, len(rows)-1)
 	}
@@ -211,6 +250,88 @@ func (m *Model) SetRows(rows []Row) {
 	}
 }
 
+// toggleMark marks the row under the cursor, or unmarks it, and moves the cursor down
+func (m *Model) toggleMark() {
+	if m.cursorRow < 0 || m.cursorRow >= len(m.rows) {
+		return
+	}
+	if m.marked[m.cursorRow] {
+		delete(m.marked, m.cursorRow)
+	} else {
+		if m.marked == nil {
+			m.marked = make(map[int]bool)
+		}
+		m.marked[m.cursorRow] = true
+	}
+	if m.cursorRow < len(m.rows)-1 {
+		m.cursorRow++
+		if m.cursorRow >= m.rowOffset+m.visibleRows() {
+			m.rowOffset = m.cursorRow - m.visibleRows() + 1
+		}
+	}
+}
+
+// startVisual starts marking the rows between the cursor and where it moves next
+func (m *Model) startVisual() {
+	if len(m.rows) == 0 {
+		return
+	}
+	m.visualBase = make(map[int]bool, len(m.marked))
+	for i := range m.marked {
+		m.visualBase[i] = true
+	}
+	m.visual = true
+	m.visualAnchor = m.cursorRow
+	m.applyVisual()
+}
+
+// applyVisual marks the rows of the visual range along with those marked before it
+func (m *Model) applyVisual() {
+	m.marked = make(map[int]bool, len(m.visualBase))
+	for i := range m.visualBase {
+		m.marked[i] = true
+	}
+	for i := min(m.visualAnchor, m.cursorRow); i <= max(m.visualAnchor, m.cursorRow) && i < len(m.rows); i++ {
+		m.marked[i] = true
+	}
+}
+
+// extendMark marks the row under the cursor and the one step rows away, moving
+// the cursor there, to mark a range with Shift and the arrow keys
+func (m *Model) extendMark(step int) {
+	next := m.cursorRow + step
+	if next < 0 || next >= len(m.rows) {
+		return
+	}
+	if m.marked == nil {
+		m.marked = make(map[int]bool)
+	}
+	m.marked[m.cursorRow] = true
+	m.marked[next] = true
+	m.cursorRow = next
+	if m.cursorRow < m.rowOffset {
+		m.rowOffset = m.cursorRow
+	} else if m.cursorRow >= m.rowOffset+m.visibleRows() {
+		m.rowOffset = m.cursorRow - m.visibleRows() + 1
+	}
+}
+
+// Rows returns the rows of the page
+func (m Model) Rows() []Row {
+	return m.rows
+}
+
+// MarkedRows returns the marked rows of the page in table order
+func (m Model) MarkedRows() []Row {
+	var rows []Row
+	for i, row := range m.rows {
+		if m.marked[i] {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
 // SetColumns updates the table columns
 func (m *Model) SetColumns(columns []Column) {
 	m.columns = columns
@@ -308,7 +429,7 @@ func (m Model) visibleCols() int {
 
 	for i := m.colOffset; i < len(m.visibleColumnIndices); i++ {
 		originalIdx := m.visibleColumnIndices[i]
-		colWidth := m.getEffectiveColumnWidth(originalIdx) + 3 // +3 for padding and separator
+		colWidth := m.getEffectiveColumnWidth(originalIdx) + m.cellOverhead()
 		if usedWidth+colWidth > m.width {
 			break
 		}
@@ -415,12 +536,112 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, func() tea.Msg {
 				return SortMsg{ColumnIdx: m.cursorCol}
 			}
+		case "m":
+			// Mark the row for copying several rows at once, and move on to the next
+			m.toggleMark()
+		case "M":
+			m.marked = nil
+			m.visual = false
+		case "V":
+			// Mark a range of rows by moving the cursor; V again keeps the marks
+			if m.visual {
+				m.visual = false
+			} else {
+				m.startVisual()
+			}
+		case "esc":
+			// Leaving visual mode with Esc drops the range it marked
+			if m.visual {
+				m.marked = m.visualBase
+				m.visual = false
+			}
+		case "shift+down":
+			m.extendMark(1)
+		case "shift+up":
+			m.extendMark(-1)
+		case "N":
+			// Toggle NULL/empty counts in the column headers
+			m.showNullCounts = !m.showNullCounts
+		case "z":
+			// Toggle dense rendering
+			m.dense = !m.dense
+		}
+		if m.visual {
+			m.applyVisual()
+		}
+
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.scrollRows(-mouseWheelStep)
+		case tea.MouseButtonWheelDown:
+			m.scrollRows(mouseWheelStep)
+		case tea.MouseButtonLeft:
+			return m.handleClick(msg.X, msg.Y)
 		}
 	}
 
 	return m, nil
 }
 
+// handleClick selects the clicked cell, or sorts by the clicked header.
+// Coordinates are relative to the table's top-left corner.
+func (m Model) handleClick(x, y int) (Model, tea.Cmd) {
+	col := m.columnAt(x)
+	if col == -1 {
+		return m, nil
+	}
+
+	// Clicking a header sorts by that column
+	if y == 0 {
+		m.cursorCol = col
+		return m, func() tea.Msg {
+			return SortMsg{ColumnIdx: col}
+		}
+	}
+
+	// Rows start below the header and separator lines
+	row := m.rowOffset + y - 2
+	if y >= 2 && y-2 < m.visibleRows() && row < len(m.rows) {
+		m.cursorRow = row
+		m.cursorCol = col
+	}
+	return m, nil
+}
+
+// scrollRows scrolls the viewport by delta rows, keeping the cursor on a visible row
+func (m *Model) scrollRows(delta int) {
+	m.rowOffset = max(0, min(m.maxRowOffset(), m.rowOffset+delta))
+	if m.cursorRow < m.rowOffset {
+		m.cursorRow = m.rowOffset
+	} else if last := m.rowOffset + m.visibleRows() - 1; m.cursorRow > last {
+		m.cursorRow = max(0, last)
+	}
+	m.cursorRow = min(m.cursorRow, max(0, len(m.rows)-1))
+}
+
+// columnAt returns the visible column index rendered at x, or -1 if there is none
+func (m Model) columnAt(x int) int {
+	if x < 0 {
+		return -1
+	}
+
+	end := min(m.colOffset+m.visibleCols(), len(m.visibleColumnIndices))
+	start := 0
+	for i := m.colOffset; i < end; i++ {
+		// Each cell is padded (unless dense) and followed by a separator
+		cellWidth := m.getEffectiveColumnWidth(m.visibleColumnIndices[i]) + m.cellOverhead()
+		if x < start+cellWidth {
+			return i
+		}
+		start += cellWidth
+	}
+	return -1
+}
+
 // View renders the table
 func (m Model) View() string {
 	if m.width <= 0 || m.height <= 0 {
@@ -433,8 +654,14 @@ func (m Model) View() string {
 	visibleColCount := m.visibleCols()
 	endColOffset := min(m.colOffset+visibleColCount, len(m.visibleColumnIndices))
 
+	// Mark the right edge when more columns are off-screen
+	moreCols := endColOffset < len(m.visibleColumnIndices)
+
 	// Render header
 	headerLine := m.renderHeaderLine(m.colOffset, endColOffset)
+	if moreCols {
+		headerLine = m.markMoreColumns(headerLine)
+	}
 	lines = append(lines, headerLine)
 
 	// Render separator
@@ -447,6 +674,9 @@ func (m Model) View() string {
 
 	for i := m.rowOffset; i < endRow; i++ {
 		rowLine := m.renderDataRow(i, m.colOffset, endColOffset)
+		if moreCols {
+			rowLine = m.markMoreColumns(rowLine)
+		}
 		lines = append(lines, rowLine)
 	}
 
@@ -456,8 +686,8 @@ func (m Model) View() string {
 		lines = append(lines, emptyLine)
 	}
 
-	// Add status bar
-	statusBar := m.renderStatusBar()
+	// Add status bar, noting rows of this page below the viewport
+	statusBar := m.renderStatusBar(len(m.rows) - endRow)
 	lines = append(lines, statusBar)
 
 	return strings.Join(lines, "\n")
@@ -483,13 +713,22 @@ func (m Model) renderHeaderLine(startColIdx, endColIdx int) string {
 			cellText = sortIcon + cellText
 		}
 
+		// Add visual indicator for primary key columns
+		if col.IsPrimaryKey {
+			cellText = cellText + " [PK]"
+		}
+
 		// Add visual indicator for foreign key columns
 		if col.IsForeignKey {
 			cellText = cellText + " [FK]"
 		}
 
+		if m.showNullCounts {
+			cellText = cellText + m.nullCountLabel(originalIdx)
+		}
+
 		cellText = truncateOrPad(cellText, effectiveWidth)
-		cell := t.TableHeader.Render(" " + cellText + " ")
+		cell := t.TableHeader.Render(m.padCell(cellText))
 		cells = append(cells, cell)
 	}
 
@@ -505,6 +744,45 @@ func (m Model) renderHeaderLine(startColIdx, endColIdx int) string {
 	return line
 }
 
+// nullCountLabel summarizes the NULL and empty values of a column in the loaded rows,
+// e.g. " [3 null, 1 empty]", or " [full]" when there are none
+func (m Model) nullCountLabel(colIdx int) string {
+	nulls, empties := 0, 0
+	for _, row := range m.rows {
+		if colIdx >= len(row) {
+			continue
+		}
+		switch row[colIdx] {
+		case "NULL":
+			nulls++
+		case "":
+			empties++
+		}
+	}
+
+	var parts []string
+	if nulls > 0 {
+		parts = append(parts, intToStr(nulls)+" null")
+	}
+	if empties > 0 {
+		parts = append(parts, intToStr(empties)+" empty")
+	}
+	if len(parts) == 0 {
+		return " [full]"
+	}
+	return " [" + strings.Join(parts, ", ") + "]"
+}
+
+// markMoreColumns replaces the last padding column of a line with an arrow
+// showing that more columns are off-screen to the right
+func (m Model) markMoreColumns(line string) string {
+	if lipgloss.Width(line) != m.width || !strings.HasSuffix(line, " ") {
+		return line
+	}
+	marker := lipgloss.NewStyle().Foreground(theme.Current.Colors.ForegroundDim).Render("›")
+	return line[:len(line)-1] + marker
+}
+
 // renderSeparator renders the separator between header and data
 func (m Model) renderSeparator(startColIdx, endColIdx int) string {
 	t := theme.Current
@@ -514,7 +792,7 @@ func (m Model) renderSeparator(startColIdx, endColIdx int) string {
 	for i := startColIdx; i < endColIdx; i++ {
 		originalIdx := m.visibleColumnIndices[i]
 		effectiveWidth := m.getEffectiveColumnWidth(originalIdx)
-		parts = append(parts, strings.Repeat("─", effectiveWidth+2))
+		parts = append(parts, strings.Repeat("─", effectiveWidth+m.cellOverhead()-1))
 	}
 
 	line := separatorStyle.Render(strings.Join(parts, "┼"))
@@ -543,14 +821,16 @@ func (m Model) renderDataRow(rowIdx, startColIdx, endColIdx int) string {
 			cellContent = row[originalIdx]
 		}
 
-		cellText := truncateOrPad(cellContent, effectiveWidth)
+		cellText := truncateOrPad(m.displayValue(originalIdx, cellContent), effectiveWidth)
 
 		var cell string
 		isSelectedCell := isSelectedRow && i == m.cursorCol
 		if isSelectedCell && m.focused {
-			cell = t.TableSelected.Render(" " + cellText + " ")
+			cell = t.TableSelected.Render(m.padCell(cellText))
+		} else if m.marked[rowIdx] {
+			cell = t.TableCell.Copy().Foreground(t.Colors.Primary).Bold(true).Render(m.padCell(cellText))
 		} else {
-			cell = t.TableCell.Render(" " + cellText + " ")
+			cell = t.TableCell.Render(m.padCell(cellText))
 		}
 		cells = append(cells, cell)
 	}
@@ -575,7 +855,7 @@ func (m Model) renderEmptyRow(startColIdx, endColIdx int) string {
 	for i := startColIdx; i < endColIdx; i++ {
 		originalIdx := m.visibleColumnIndices[i]
 		effectiveWidth := m.getEffectiveColumnWidth(originalIdx)
-		cell := t.TableCell.Render(" " + strings.Repeat(" ", effectiveWidth) + " ")
+		cell := t.TableCell.Render(m.padCell(strings.Repeat(" ", effectiveWidth)))
 		cells = append(cells, cell)
 	}
 
@@ -591,13 +871,23 @@ func (m Model) renderEmptyRow(startColIdx, endColIdx int) string {
 	return line
 }
 
-// renderStatusBar renders the status bar with navigation info
-func (m Model) renderStatusBar() string {
+// renderStatusBar renders the status bar with navigation info; rowsBelow is the
+// number of rows of this page below the viewport
+func (m Model) renderStatusBar(rowsBelow int) string {
 	t := theme.Current
 
 	visibleCount := len(m.visibleColumnIndices)
 
 	colInfo := "Col " + intToStr(m.cursorCol+1) + "/" + intToStr(visibleCount)
+	if rowsBelow > 0 {
+		colInfo += "  ↓ " + intToStr(rowsBelow) + " more"
+	}
+	if len(m.marked) > 0 {
+		colInfo += "  " + intToStr(len(m.marked)) + " marked"
+	}
+	if m.visual {
+		colInfo += "  -- VISUAL --"
+	}
 
 	leftInfo := t.StatusBar.Render("Row " + intToStr(m.cursorRow+1) + "/" + intToStr(len(m.rows)) + ", " + colInfo)
 
@@ -606,7 +896,11 @@ func (m Model) renderStatusBar() string {
 
 	// Add pagination info if there are multiple pages
 	if m.totalPages > 1 {
-		rightParts = append(rightParts, "Page "+intToStr(m.currentPage)+"/"+intToStr(m.totalPages)+" ("+intToStr(m.totalRows)+" total)")
+		total := intToStr(m.totalRows)
+		if m.totalApprox {
+			total = "~" + total
+		}
+		rightParts = append(rightParts, "Page "+intToStr(m.currentPage)+"/"+intToStr(m.totalPages)+" ("+total+" total)")
 	}
 
 	rightInfo := t.StatusBar.Render(strings.Join(rightParts, " | "))
@@ -620,6 +914,7 @@ func (m Model) renderStatusBar() string {
 // Helper functions
 
 func truncateOrPad(s string, width int) string {
+	width = max(0, width)
 	// Use lipgloss for proper width calculation
 	currentWidth := lipgloss.Width(s)
 
@@ -668,13 +963,16 @@ func (m *Model) calculateColumnWidth(colIdx int) int {
 		return 10 // Default minimum
 	}
 
-	// Start with the header title width
+	// Start with the header title width, including the NULL counts when shown
 	maxWidth := lipgloss.Width(m.columns[colIdx].Title)
+	if m.showNullCounts {
+		maxWidth += lipgloss.Width(m.nullCountLabel(colIdx))
+	}
 
 	// Check all rows for the maximum content width
 	for _, row := range m.rows {
 		if colIdx < len(row) {
-			cellWidth := lipgloss.Width(row[colIdx])
+			cellWidth := lipgloss.Width(m.displayValue(colIdx, row[colIdx]))
 			if cellWidth > maxWidth {
 				maxWidth = cellWidth
 			}
@@ -685,6 +983,33 @@ func (m *Model) calculateColumnWidth(colIdx int) int {
 	return min(max(maxWidth, 4), 50) // Min 4, max 50 characters
 }
 
+// SetDense enables or disables dense rendering without cell padding
+func (m *Model) SetDense(dense bool) {
+	m.dense = dense
+}
+
+// IsDense returns whether dense rendering is enabled
+func (m Model) IsDense() bool {
+	return m.dense
+}
+
+// padCell surrounds cell text with a space on both sides, unless the table is dense
+func (m Model) padCell(text string) string {
+	if m.dense {
+		return text
+	}
+	return " " + text + " "
+}
+
+// cellOverhead returns the width a cell takes beyond its column width:
+// the padding on both sides (none when dense) and the separator
+func (m Model) cellOverhead() int {
+	if m.dense {
+		return 1
+	}
+	return 3
+}
+
 // SetAutoFit enables or disables auto-fit for all columns (set from config)
 func (m *Model) SetAutoFit(enabled bool) {
 	m.allColumnsAutoFit = enabled